@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+	"github.com/jgoodhcg/mindmeld/internal/importsafety"
+)
+
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	file := fs.String("file", "content/cluster/library.v1.json", "Path to cluster library JSON")
+	studioFile := fs.String("studio-file", "", "Studio JSON source path")
+	sourceDir := fs.String("source-dir", "", "Source directory containing meta.json, axes.tsv, and prompts.tsv")
+	databaseURLFlag := fs.String("database-url", "", "Explicit database URL (fallback: DATABASE_URL)")
+	asJSON := fs.Bool("json", false, "Print the diff as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	databaseURL, err := importsafety.ResolveDatabaseURL(*databaseURLFlag)
+	if err != nil {
+		return err
+	}
+
+	lib, _, pairs, err := loadAndValidate(*file, *sourceDir, *studioFile)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// diff only reads, so like stats it skips the production guard; that is
+	// the point of running it before a production import.
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	diff, err := clustercontent.DiffLive(ctx, pool, lib, pairs)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diff)
+	}
+	printContentDiff(os.Stdout, diff)
+	return nil
+}
+
+func printContentDiff(w io.Writer, diff clustercontent.ContentDiff) {
+	fmt.Fprintf(w, "Created by label: %s\n", diff.CreatedByLabel)
+	printDiffSummary(w, "Axis sets", diff.AxisSetSummary)
+	printDiffSummary(w, "Prompts", diff.PromptSummary)
+	fmt.Fprintf(w, "- Pairs: add=%d, remove=%d, unchanged=%d\n", diff.PairsAdded, diff.PairsRemoved, diff.PairsUnchanged)
+	if diff.Empty() {
+		fmt.Fprintln(w, "No changes.")
+		return
+	}
+	printEntityChanges(w, "axis set", diff.AxisSets)
+	printEntityChanges(w, "prompt", diff.Prompts)
+}
+
+func printDiffSummary(w io.Writer, label string, s clustercontent.EntityDiffSummary) {
+	fmt.Fprintf(w, "- %s: create=%d, reactivate=%d, update=%d, deactivate=%d, unchanged=%d\n",
+		label, s.Create, s.Reactivate, s.Update, s.Deactivate, s.Unchanged)
+}
+
+var diffActionMarks = map[string]string{
+	clustercontent.DiffCreate:     "+",
+	clustercontent.DiffReactivate: "^",
+	clustercontent.DiffUpdate:     "~",
+	clustercontent.DiffDeactivate: "-",
+}
+
+func printEntityChanges(w io.Writer, kind string, changes []clustercontent.EntityChange) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	for _, change := range changes {
+		fmt.Fprintf(w, "%s %s %s (%s)\n", diffActionMarks[change.Action], kind, change.Slug, change.Action)
+		for _, field := range change.Fields {
+			if change.Action == clustercontent.DiffCreate {
+				fmt.Fprintf(w, "    %s: %q\n", field.Field, field.To)
+				continue
+			}
+			fmt.Fprintf(w, "    %s: %q -> %q\n", field.Field, field.From, field.To)
+		}
+		if len(change.AxesAdded) > 0 {
+			fmt.Fprintf(w, "    axes added: %s\n", strings.Join(change.AxesAdded, ", "))
+		}
+		if len(change.AxesRemoved) > 0 {
+			fmt.Fprintf(w, "    axes removed: %s\n", strings.Join(change.AxesRemoved, ", "))
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+	"github.com/jgoodhcg/mindmeld/internal/importsafety"
+)
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	outFile := fs.String("file", "content/cluster/studio.export.json", "Output studio JSON path")
+	libraryFile := fs.String("library-file", "", "Also write the ready prompts as library JSON to this path")
+	label := fs.String("label", "", "Only export rows with this created_by_label (default: all rows)")
+	createdByLabel := fs.String("created-by-label", "", "created_by_label for the exported file (default: -label, or cluster-library-v1)")
+	version := fs.String("version", "v1", "Version for the exported file")
+	databaseURLFlag := fs.String("database-url", "", "Explicit database URL (fallback: DATABASE_URL)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	databaseURL, err := importsafety.ResolveDatabaseURL(*databaseURLFlag)
+	if err != nil {
+		return err
+	}
+
+	targetLabel := strings.TrimSpace(*createdByLabel)
+	if targetLabel == "" {
+		targetLabel = strings.TrimSpace(*label)
+	}
+	if targetLabel == "" {
+		targetLabel = "cluster-library-v1"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// export only reads, so like stats and diff it skips the production guard.
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	live, err := clustercontent.LoadLiveContent(ctx, pool, strings.TrimSpace(*label))
+	if err != nil {
+		return err
+	}
+
+	src, report := clustercontent.ExportStudio(live, strings.TrimSpace(*version), targetLabel)
+	if _, err := clustercontent.ValidateStudio(src); err != nil {
+		return fmt.Errorf("exported content does not validate: %w", err)
+	}
+	if err := clustercontent.SaveStudio(strings.TrimSpace(*outFile), src); err != nil {
+		return err
+	}
+	if path := strings.TrimSpace(*libraryFile); path != "" {
+		if err := clustercontent.SaveLibrary(path, src.ToLibrary()); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote library: %s\n", path)
+	}
+
+	fmt.Printf("Wrote studio source: %s\n", strings.TrimSpace(*outFile))
+	fmt.Printf("Created by label: %s\n", targetLabel)
+	fmt.Printf("Axis sets: %d (skipped %d inactive)\n", report.AxisSets, report.SkippedAxisSets)
+	fmt.Printf("Prompts: %d (ready=%d, draft=%d, archived=%d)\n",
+		report.ReadyPrompts+report.DraftPrompts+report.ArchivedPrompts,
+		report.ReadyPrompts, report.DraftPrompts, report.ArchivedPrompts)
+	fmt.Printf("Generated slugs: %d\n", report.GeneratedSlugs)
+	fmt.Println("Export: OK")
+	return nil
+}
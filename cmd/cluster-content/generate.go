@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+const (
+	defaultGenerateEndpoint = "https://api.openai.com/v1/chat/completions"
+	defaultGenerateModel    = "gpt-4.1-mini"
+)
+
+func runGenerate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	file := fs.String("file", "content/cluster/studio.v1.json", "Studio JSON path to append drafts to")
+	axisSlug := fs.String("axis-set", "", "Axis set slug the drafts are written for (required)")
+	theme := fs.String("theme", "", "Theme for the drafts")
+	rating := fs.String("rating", "20", "Content rating: 10 (Mild), 20 (Polite), 30 (Adults)")
+	count := fs.Int("count", 8, "Number of prompts to request")
+	endpoint := fs.String("endpoint", envOr("CLUSTER_GENERATE_ENDPOINT", defaultGenerateEndpoint), "OpenAI-compatible chat completions URL (fallback: CLUSTER_GENERATE_ENDPOINT)")
+	model := fs.String("model", envOr("CLUSTER_GENERATE_MODEL", defaultGenerateModel), "Model name (fallback: CLUSTER_GENERATE_MODEL)")
+	provider := fs.String("provider", "openai", "Provider label recorded in provenance")
+	apiKeyEnv := fs.String("api-key-env", "OPENAI_API_KEY", "Environment variable holding the API key (may be empty for local endpoints)")
+	dryRun := fs.Bool("dry-run", false, "Print drafts without writing the studio file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path := strings.TrimSpace(*file)
+	minRating, err := contentrating.ParseID(*rating)
+	if err != nil {
+		return err
+	}
+
+	src, err := clustercontent.LoadStudio(path)
+	if err != nil {
+		return err
+	}
+	loadedHash, err := clustercontent.StudioHash(path)
+	if err != nil {
+		return err
+	}
+
+	axis, ok := findStudioAxisSet(src, strings.TrimSpace(*axisSlug))
+	if !ok {
+		if strings.TrimSpace(*axisSlug) == "" {
+			return errors.New("-axis-set is required")
+		}
+		return fmt.Errorf("axis set %q not found in %s", strings.TrimSpace(*axisSlug), path)
+	}
+
+	runID := clustercontent.NewGeneratorRunID(time.Now())
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	drafts, err := clustercontent.GeneratePrompts(ctx, clustercontent.GeneratorConfig{
+		Provider: strings.TrimSpace(*provider),
+		Endpoint: strings.TrimSpace(*endpoint),
+		Model:    strings.TrimSpace(*model),
+		APIKey:   os.Getenv(strings.TrimSpace(*apiKeyEnv)),
+	}, clustercontent.GenerateRequest{
+		Theme:     strings.TrimSpace(*theme),
+		MinRating: minRating,
+		AxisSet:   axis,
+		Count:     *count,
+		Avoid:     existingPromptTexts(src, strings.TrimSpace(*theme), axis.Slug),
+		RunID:     runID,
+	})
+	if err != nil {
+		return err
+	}
+
+	added, skipped := clustercontent.AppendGeneratedPrompts(&src, drafts)
+
+	fmt.Printf("Run: %s (%s %s, %s)\n", runID, strings.TrimSpace(*provider), strings.TrimSpace(*model), clustercontent.GeneratorPromptVersion)
+	fmt.Printf("Axis set: %s · rating %s · theme %s\n", axis.Slug, contentrating.Label(minRating), valueOrDash(strings.TrimSpace(*theme)))
+	for _, p := range added {
+		fmt.Printf("  + %-32s %s\n", p.Slug, p.Text)
+	}
+	for _, text := range skipped {
+		fmt.Printf("  = skipped duplicate: %s\n", text)
+	}
+
+	if *dryRun {
+		fmt.Println("Dry-run: studio file not written.")
+		return nil
+	}
+	if len(added) == 0 {
+		fmt.Println("No new drafts to write.")
+		return nil
+	}
+	if err := clustercontent.SaveStudioIfUnchanged(path, src, loadedHash); err != nil {
+		return err
+	}
+
+	diag := clustercontent.AnalyzeStudio(src)
+	fmt.Printf("Wrote %d draft prompts to %s\n", len(added), path)
+	if n := diag.Summary.NearDuplicatePromptSets; n > 0 {
+		fmt.Printf("Near-duplicate prompt groups: %d (see review UI)\n", n)
+	}
+	return nil
+}
+
+func findStudioAxisSet(src clustercontent.StudioSource, slug string) (clustercontent.AxisSet, bool) {
+	for _, axis := range src.AxisSets {
+		if axis.Slug == slug {
+			return axis, true
+		}
+	}
+	return clustercontent.AxisSet{}, false
+}
+
+// existingPromptTexts lists prompts the model should steer away from: those on
+// the same theme or axis set, which are the likeliest to be repeated.
+func existingPromptTexts(src clustercontent.StudioSource, theme string, axisSlug string) []string {
+	var texts []string
+	for _, p := range src.Prompts {
+		sameTheme := theme != "" && strings.EqualFold(strings.TrimSpace(p.Theme), theme)
+		sameAxis := false
+		for _, slug := range p.AxisSlugs {
+			if slug == axisSlug {
+				sameAxis = true
+				break
+			}
+		}
+		if sameTheme || sameAxis {
+			texts = append(texts, p.Text)
+		}
+	}
+	return texts
+}
+
+func envOr(key string, fallback string) string {
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
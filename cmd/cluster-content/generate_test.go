@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+)
+
+func TestRunGenerateAppendsDrafts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{
+				"role":    "assistant",
+				"content": `{"prompts":[{"slug":"standup-length","text":"How long should a standup last?"}]}`,
+			}}},
+		})
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "studio.json")
+	src := clustercontent.StudioSource{
+		Version:        "v1",
+		CreatedByLabel: "cluster-studio",
+		AxisSets:       []clustercontent.AxisSet{{Slug: "axis-a", XMinLabel: "Short", XMaxLabel: "Long", YMinLabel: "Casual", YMaxLabel: "Formal", MinRating: 10}},
+	}
+	if err := clustercontent.SaveStudio(path, src); err != nil {
+		t.Fatalf("save studio: %v", err)
+	}
+
+	if err := runGenerate([]string{"-file", path, "-axis-set", "axis-a", "-theme", "work", "-endpoint", server.URL, "-model", "stub-model", "-provider", "stub", "-api-key-env", "CLUSTER_GENERATE_TEST_UNSET"}); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	saved, err := clustercontent.LoadStudio(path)
+	if err != nil {
+		t.Fatalf("load studio: %v", err)
+	}
+	if len(saved.Prompts) != 1 {
+		t.Fatalf("expected one draft appended, got %+v", saved.Prompts)
+	}
+	p := saved.Prompts[0]
+	if p.Status != "draft" || p.AuthoringMode != clustercontent.AuthoringAIGenerated || p.Generator == nil || p.Generator.Provider != "stub" || p.Generator.RunID == "" {
+		t.Fatalf("unexpected saved draft: %+v", p)
+	}
+
+	if err := runGenerate([]string{"-file", path, "-axis-set", "missing", "-endpoint", server.URL}); err == nil {
+		t.Fatal("expected unknown axis set to fail")
+	}
+}
@@ -2,11 +2,9 @@ package main
 
 import (
 	"context"
-	"errors"
 	"flag"
 	"fmt"
 	"log"
-	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -14,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
 	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+	"github.com/jgoodhcg/mindmeld/internal/importsafety"
 )
 
 func main() {
@@ -35,8 +34,33 @@ func main() {
 		if err := runReview(os.Args[2:]); err != nil {
 			log.Fatal(err)
 		}
+	case "generate":
+		if err := runGenerate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "stats":
+		if err := runStats(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
 	case "validate":
 		if err := runValidate(os.Args[2:]); err != nil {
+			log.Print(err)
+			os.Exit(validateExitCode(err))
+		}
+	case "diff":
+		if err := runDiff(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "export":
+		if err := runExport(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "releases":
+		if err := runReleases(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "rollback":
+		if err := runRollback(os.Args[2:]); err != nil {
 			log.Fatal(err)
 		}
 	case "import":
@@ -55,9 +79,15 @@ func usage() {
 	fmt.Println("Usage:")
 	fmt.Println("  go run ./cmd/cluster-content bootstrap-studio [-source-dir content/cluster/source] [-file content/cluster/studio.v1.json]")
 	fmt.Println("  go run ./cmd/cluster-content build [-studio-file content/cluster/studio.v1.json | -source-dir content/cluster/source] [-file content/cluster/library.v1.json]")
-	fmt.Println("  go run ./cmd/cluster-content review [-file content/cluster/studio.v1.json] [-listen 127.0.0.1:8097]")
-	fmt.Println("  go run ./cmd/cluster-content validate [-studio-file content/cluster/studio.v1.json | -source-dir content/cluster/source | -file content/cluster/library.v1.json]")
+	fmt.Println("  go run ./cmd/cluster-content review [-file content/cluster/studio.v1.json] [-listen 127.0.0.1:8097] [-database-url url] [-similarity 0.75]")
+	fmt.Println("  go run ./cmd/cluster-content generate -axis-set slug [-theme work] [-rating 20] [-count 8] [-file content/cluster/studio.v1.json] [flags]")
+	fmt.Println("  go run ./cmd/cluster-content stats [-database-url url] [-min-rounds 1] [-flagged]")
+	fmt.Println("  go run ./cmd/cluster-content validate [-studio-file content/cluster/studio.v1.json | -source-dir content/cluster/source | -file content/cluster/library.v1.json] [-rules content/cluster/rules.json] [-strict]")
+	fmt.Println("  go run ./cmd/cluster-content diff [-studio-file content/cluster/studio.v1.json | -source-dir content/cluster/source | -file content/cluster/library.v1.json] [-database-url url] [-json]")
+	fmt.Println("  go run ./cmd/cluster-content export [-file content/cluster/studio.export.json] [-library-file path] [-label label] [-database-url url]")
 	fmt.Println("  go run ./cmd/cluster-content import [-studio-file content/cluster/studio.v1.json | -source-dir content/cluster/source | -file content/cluster/library.v1.json] [flags]")
+	fmt.Println("  go run ./cmd/cluster-content releases [-label cluster-library-v1] [-limit 20] [-database-url url]")
+	fmt.Println("  go run ./cmd/cluster-content rollback [-label cluster-library-v1] [-to release-id] [flags]")
 	fmt.Println()
 	fmt.Println("Studio Flags:")
 	fmt.Println("  bootstrap-studio:")
@@ -68,17 +98,61 @@ func usage() {
 	fmt.Println("    -file string         Studio JSON path (default content/cluster/studio.v1.json)")
 	fmt.Println("    -listen string       HTTP listen address (default 127.0.0.1:8097)")
 	fmt.Println("    -allow-non-local     Allow binding review server to non-local interfaces")
+	fmt.Println("    -database-url string Show gameplay stats from this DB (fallback: DATABASE_URL; omitted when neither is set)")
+	fmt.Println("    -similarity float    Near-duplicate threshold, 0-1 (default 0.75; negative disables)")
+	fmt.Println()
+	fmt.Println("Generate Flags:")
+	fmt.Println("  -axis-set string       Axis set slug the drafts are written for (required)")
+	fmt.Println("  -theme string          Theme for the drafts")
+	fmt.Println("  -rating string         Content rating: 10, 20, or 30 (default 20)")
+	fmt.Println("  -count int             Number of prompts to request (default 8)")
+	fmt.Println("  -endpoint string       OpenAI-compatible chat completions URL (fallback: CLUSTER_GENERATE_ENDPOINT)")
+	fmt.Println("  -model string          Model name (fallback: CLUSTER_GENERATE_MODEL; default gpt-4.1-mini)")
+	fmt.Println("  -provider string       Provider label recorded in provenance (default openai)")
+	fmt.Println("  -api-key-env string    Env var holding the API key (default OPENAI_API_KEY)")
+	fmt.Println("  -dry-run               Print drafts without writing the studio file")
+	fmt.Println()
+	fmt.Println("Stats Flags:")
+	fmt.Println("  -database-url string   Explicit DB URL (fallback: DATABASE_URL)")
+	fmt.Println("  -min-rounds int        Only list prompts played at least this many rounds (default 1)")
+	fmt.Println("  -flagged               Only list prompts flagged as often skipped or low spread")
 	fmt.Println()
 	fmt.Println("Build Flags:")
 	fmt.Println("  -file string           Output library JSON path (default content/cluster/library.v1.json)")
 	fmt.Println("  -studio-file string    Studio JSON source path (preferred for review-first workflow)")
 	fmt.Println("  -source-dir string     Canonical source directory (meta.json, axes.tsv, prompts.tsv)")
 	fmt.Println()
+	fmt.Println("Validate Flags:")
+	fmt.Println("  -rules string          Content rules config (default content/cluster/rules.json; empty skips rules)")
+	fmt.Println("  -strict                Fail on warning-severity rules and AI review findings too")
+	fmt.Println("  -ai-review             Also ask a model to rate each prompt and axis set (findings are warnings)")
+	fmt.Println("  -ai-endpoint string    OpenAI-compatible chat completions URL (fallback: CONTENT_REVIEW_ENDPOINT)")
+	fmt.Println("  -ai-model string       Model name (fallback: CONTENT_REVIEW_MODEL; default gpt-4.1-mini)")
+	fmt.Println("  -api-key-env string    Env var holding the API key (default OPENAI_API_KEY)")
+	fmt.Println("  Exit codes: 0 valid, 1 invalid content or failed rules, 2 bad flags or rules config")
+	fmt.Println()
+	fmt.Println("Diff Flags:")
+	fmt.Println("  -database-url string   Explicit DB URL (fallback: DATABASE_URL)")
+	fmt.Println("  -json                  Print the field-level diff as JSON")
+	fmt.Println()
+	fmt.Println("Export Flags:")
+	fmt.Println("  -file string             Output studio JSON path (default content/cluster/studio.export.json)")
+	fmt.Println("  -library-file string     Also write ready prompts as library JSON")
+	fmt.Println("  -label string            Only export rows with this created_by_label (default: all rows)")
+	fmt.Println("  -created-by-label string Label for the exported file (default: -label, or cluster-library-v1)")
+	fmt.Println("  -version string          Version for the exported file (default v1)")
+	fmt.Println("  -database-url string     Explicit DB URL (fallback: DATABASE_URL)")
+	fmt.Println()
 	fmt.Println("Import Flags:")
 	fmt.Println("  -database-url string   Explicit DB URL (fallback: DATABASE_URL env)")
 	fmt.Println("  -env string            Target environment: dev|prod (default dev)")
 	fmt.Println("  -dry-run               Preview DB changes without writes")
 	fmt.Println("  -allow-production      Required for production-like DB URLs")
+	fmt.Println()
+	fmt.Println("Rollback Flags:")
+	fmt.Println("  -label string          created_by_label whose releases to roll back (default cluster-library-v1)")
+	fmt.Println("  -to string             Release id to restore (default: the release before the latest)")
+	fmt.Println("  -database-url, -env, -dry-run, -allow-production as for import")
 }
 
 func runBuild(args []string) error {
@@ -152,8 +226,23 @@ func runValidate(args []string) error {
 	file := fs.String("file", "content/cluster/library.v1.json", "Path to cluster library JSON")
 	studioFile := fs.String("studio-file", "", "Studio JSON source path")
 	sourceDir := fs.String("source-dir", "", "Source directory containing meta.json, axes.tsv, and prompts.tsv")
+	rulesFile := fs.String("rules", defaultRulesFile, "Content rules config (empty to skip rules)")
+	strict := fs.Bool("strict", false, "Fail on warning-severity rules too")
+	aiReview := fs.Bool("ai-review", false, "Also ask a model to rate each prompt and axis set")
+	aiEndpoint := fs.String("ai-endpoint", envOr("CONTENT_REVIEW_ENDPOINT", defaultGenerateEndpoint), "OpenAI-compatible chat completions URL (fallback: CONTENT_REVIEW_ENDPOINT)")
+	aiModel := fs.String("ai-model", envOr("CONTENT_REVIEW_MODEL", defaultGenerateModel), "Model name (fallback: CONTENT_REVIEW_MODEL)")
+	apiKeyEnv := fs.String("api-key-env", "OPENAI_API_KEY", "Environment variable holding the API key (may be empty for local endpoints)")
 	if err := fs.Parse(args); err != nil {
-		return err
+		return usageError{err}
+	}
+
+	var rules clustercontent.RuleSet
+	if path := strings.TrimSpace(*rulesFile); path != "" {
+		var err error
+		rules, err = clustercontent.LoadRuleSet(path)
+		if err != nil {
+			return usageError{fmt.Errorf("load rules: %w", err)}
+		}
 	}
 
 	lib, report, _, err := loadAndValidate(*file, *sourceDir, *studioFile)
@@ -163,8 +252,34 @@ func runValidate(args []string) error {
 
 	fmt.Printf("Library version: %s\n", lib.Version)
 	fmt.Printf("Created by label: %s\n", lib.CreatedByLabel)
+	if len(lib.Locales) > 0 {
+		fmt.Printf("Translated locales: %s\n", strings.Join(lib.Locales, ", "))
+	}
 	printReport(report)
 	printTargetGap(report)
+
+	if path := strings.TrimSpace(*rulesFile); path != "" {
+		fmt.Printf("Rules: %s\n", path)
+		errCount, warnCount := printRuleViolations(rules.Check(lib))
+		if errCount > 0 || (*strict && warnCount > 0) {
+			return errRulesFailed
+		}
+	}
+	if *aiReview {
+		reviewer := contentrating.ChatReviewer{
+			Provider: "ai-review",
+			Endpoint: strings.TrimSpace(*aiEndpoint),
+			Model:    strings.TrimSpace(*aiModel),
+			APIKey:   os.Getenv(strings.TrimSpace(*apiKeyEnv)),
+		}
+		warnCount, err := printAIReview(context.Background(), reviewer, clustercontent.RatingItems(lib))
+		if err != nil {
+			return err
+		}
+		if *strict && warnCount > 0 {
+			return errRulesFailed
+		}
+	}
 	fmt.Println("Validation: OK")
 	return nil
 }
@@ -182,11 +297,11 @@ func runImport(args []string) error {
 		return err
 	}
 
-	databaseURL, err := resolveDatabaseURL(*databaseURLFlag)
+	databaseURL, err := importsafety.ResolveDatabaseURL(*databaseURLFlag)
 	if err != nil {
 		return err
 	}
-	if err := validateImportSafety(strings.TrimSpace(*targetEnv), databaseURL, *allowProduction); err != nil {
+	if err := importsafety.Validate(strings.TrimSpace(*targetEnv), databaseURL, *allowProduction); err != nil {
 		return err
 	}
 
@@ -213,7 +328,7 @@ func runImport(args []string) error {
 		return err
 	}
 
-	fmt.Printf("Target env: %s\n", normalizeEnv(*targetEnv))
+	fmt.Printf("Target env: %s\n", importsafety.NormalizeEnv(*targetEnv))
 	printReport(report)
 	printTargetGap(report)
 	printPlan(plan)
@@ -223,9 +338,11 @@ func runImport(args []string) error {
 		return nil
 	}
 
-	if err := clustercontent.Import(ctx, pool, lib, pairs); err != nil {
+	rel, err := clustercontent.Import(ctx, pool, lib, pairs)
+	if err != nil {
 		return err
 	}
+	printRelease(rel)
 	fmt.Println("Import: OK")
 	return nil
 }
@@ -258,73 +375,6 @@ func loadAndValidate(path string, sourceDir string, studioFile string) (clusterc
 	return lib, report, pairs, nil
 }
 
-func resolveDatabaseURL(flagValue string) (string, error) {
-	if strings.TrimSpace(flagValue) != "" {
-		return strings.TrimSpace(flagValue), nil
-	}
-	if strings.TrimSpace(os.Getenv("DATABASE_URL")) != "" {
-		return strings.TrimSpace(os.Getenv("DATABASE_URL")), nil
-	}
-	return "", errors.New("database URL is required (set -database-url or DATABASE_URL)")
-}
-
-func validateImportSafety(targetEnv string, databaseURL string, allowProduction bool) error {
-	env := normalizeEnv(targetEnv)
-	isProdLikeURL := isProductionLikeURL(databaseURL)
-
-	if isProdLikeURL && !allowProduction {
-		return errors.New("refusing to run against production-like DB without -allow-production")
-	}
-	if env == "prod" && !allowProduction {
-		return errors.New("refusing prod import without -allow-production")
-	}
-	if isProdLikeURL && env != "prod" {
-		return errors.New("production-like DB requires -env=prod")
-	}
-	if env == "prod" && !isProdLikeURL {
-		return errors.New("-env=prod provided but database URL looks local; use -env=dev instead")
-	}
-
-	return nil
-}
-
-func normalizeEnv(env string) string {
-	value := strings.ToLower(strings.TrimSpace(env))
-	switch value {
-	case "prod", "production":
-		return "prod"
-	default:
-		return "dev"
-	}
-}
-
-func isProductionLikeURL(databaseURL string) bool {
-	parsed, err := url.Parse(strings.TrimSpace(databaseURL))
-	if err != nil {
-		return true
-	}
-
-	host := strings.ToLower(strings.TrimSpace(parsed.Hostname()))
-	if host == "" {
-		return true
-	}
-
-	localHosts := map[string]bool{
-		"localhost": true,
-		"127.0.0.1": true,
-		"0.0.0.0":   true,
-		"::1":       true,
-	}
-	if localHosts[host] {
-		return false
-	}
-	if strings.HasSuffix(host, ".local") {
-		return false
-	}
-
-	return true
-}
-
 func printReport(report clustercontent.Report) {
 	fmt.Printf("Axis sets: %d\n", report.AxisSetCount)
 	fmt.Printf("Prompts: %d\n", report.PromptCount)
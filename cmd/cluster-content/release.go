@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+	"github.com/jgoodhcg/mindmeld/internal/importsafety"
+)
+
+const defaultReleaseLabel = "cluster-library-v1"
+
+func runReleases(args []string) error {
+	fs := flag.NewFlagSet("releases", flag.ContinueOnError)
+	label := fs.String("label", defaultReleaseLabel, "created_by_label to list releases for")
+	limit := fs.Int("limit", 20, "Maximum releases to list")
+	databaseURLFlag := fs.String("database-url", "", "Explicit database URL (fallback: DATABASE_URL)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	databaseURL, err := importsafety.ResolveDatabaseURL(*databaseURLFlag)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	releases, err := clustercontent.ListReleases(ctx, pool, strings.TrimSpace(*label), *limit)
+	if err != nil {
+		return err
+	}
+	if len(releases) == 0 {
+		fmt.Printf("No releases recorded for %s.\n", strings.TrimSpace(*label))
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "RELEASE\tCREATED\tKIND\tVERSION\tHASH\tPROMPTS\tAXIS SETS\tPAIRS\tRESTORED")
+	for _, rel := range releases {
+		restored := "-"
+		if rel.RestoredFromID.Valid {
+			restored = rel.RestoredFromID.UUID.String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			rel.ID, rel.CreatedAt.UTC().Format(time.RFC3339), rel.Kind, rel.LibraryVersion, shortHash(rel.ContentHash),
+			formatReleaseCounts(rel.Prompts), formatReleaseCounts(rel.AxisSets), formatReleaseCounts(rel.Pairs),
+			restored,
+		)
+	}
+	return tw.Flush()
+}
+
+func runRollback(args []string) error {
+	fs := flag.NewFlagSet("rollback", flag.ContinueOnError)
+	label := fs.String("label", defaultReleaseLabel, "created_by_label whose releases to roll back")
+	to := fs.String("to", "", "Release id to restore (default: the release before the latest)")
+	databaseURLFlag := fs.String("database-url", "", "Explicit database URL (fallback: DATABASE_URL)")
+	targetEnv := fs.String("env", "dev", "Target environment: dev|prod")
+	dryRun := fs.Bool("dry-run", false, "Show what would change without committing")
+	allowProduction := fs.Bool("allow-production", false, "Required for production-like DB URLs")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	databaseURL, err := importsafety.ResolveDatabaseURL(*databaseURLFlag)
+	if err != nil {
+		return err
+	}
+	if err := importsafety.Validate(strings.TrimSpace(*targetEnv), databaseURL, *allowProduction); err != nil {
+		return err
+	}
+
+	opts := clustercontent.RollbackOptions{DryRun: *dryRun}
+	if value := strings.TrimSpace(*to); value != "" {
+		opts.To, err = uuid.Parse(value)
+		if err != nil {
+			return fmt.Errorf("invalid -to release id: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	rel, err := clustercontent.Rollback(ctx, pool, strings.TrimSpace(*label), opts)
+	if errors.Is(err, clustercontent.ErrNoPreviousRelease) {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(*label), err)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Target env: %s\n", importsafety.NormalizeEnv(*targetEnv))
+	fmt.Printf("Restoring release %s (version %s, hash %s)\n", rel.RestoredFromID.UUID, rel.LibraryVersion, shortHash(rel.ContentHash))
+	printRelease(rel)
+	if *dryRun {
+		fmt.Println("Dry-run: rollback not committed.")
+		return nil
+	}
+	fmt.Println("Rollback: OK")
+	return nil
+}
+
+func printRelease(rel clustercontent.Release) {
+	fmt.Printf("Release: %s (%s)\n", rel.ID, rel.Kind)
+	fmt.Printf("- Prompts: %s\n", formatReleaseCounts(rel.Prompts))
+	fmt.Printf("- Axis sets: %s\n", formatReleaseCounts(rel.AxisSets))
+	fmt.Printf("- Pairs: %s\n", formatReleaseCounts(rel.Pairs))
+}
+
+func formatReleaseCounts(c clustercontent.ReleaseCounts) string {
+	return fmt.Sprintf("%d active (+%d/-%d)", c.Active, c.Activated, c.Deactivated)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -13,9 +15,11 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
 	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+	"github.com/jgoodhcg/mindmeld/internal/importsafety"
 )
 
 func runBootstrapStudio(args []string) error {
@@ -62,21 +66,42 @@ func runReview(args []string) error {
 	file := fs.String("file", "content/cluster/studio.v1.json", "Studio JSON path")
 	listen := fs.String("listen", "127.0.0.1:8097", "HTTP listen address")
 	allowNonLocal := fs.Bool("allow-non-local", false, "Allow binding to non-local interfaces")
+	databaseURLFlag := fs.String("database-url", "", "Database URL for gameplay stats (fallback: DATABASE_URL)")
+	similarity := fs.Float64("similarity", clustercontent.DefaultSimilarityThreshold, "Near-duplicate similarity threshold (0-1; negative disables)")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
+	// Stats are optional: without a database the review UI only shows the
+	// studio file.
+	databaseURL, _ := importsafety.ResolveDatabaseURL(*databaseURLFlag)
+
 	if err := validateReviewListenAddr(strings.TrimSpace(*listen), *allowNonLocal); err != nil {
 		return err
 	}
 
+	if *similarity > 1 {
+		return fmt.Errorf("-similarity must be at most 1, got %v", *similarity)
+	}
+	cfg := reviewConfig{
+		Path:        strings.TrimSpace(*file),
+		DatabaseURL: databaseURL,
+		Analysis:    clustercontent.StudioAnalysisOptions{SimilarityThreshold: *similarity},
+	}
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-		renderStudioReview(w, r, strings.TrimSpace(*file))
+		renderStudioReview(w, r, cfg)
+	})
+	mux.HandleFunc("/prompts/save", func(w http.ResponseWriter, r *http.Request) {
+		handleStudioEdit(w, r, cfg, applyPromptSave)
+	})
+	mux.HandleFunc("/prompts/archive", func(w http.ResponseWriter, r *http.Request) {
+		handleStudioEdit(w, r, cfg, applyPromptArchive)
 	})
 	mux.HandleFunc("/__meta", func(w http.ResponseWriter, r *http.Request) {
 		info, err := os.Stat(strings.TrimSpace(*file))
@@ -85,14 +110,18 @@ func runReview(args []string) error {
 			return
 		}
 		_ = json.NewEncoder(w).Encode(map[string]any{
-			"file":          strings.TrimSpace(*file),
-			"size":          info.Size(),
-			"modified_unix": info.ModTime().Unix(),
+			"file":               strings.TrimSpace(*file),
+			"size":               info.Size(),
+			"modified_unix":      info.ModTime().Unix(),
+			"modified_unix_nano": info.ModTime().UnixNano(),
 		})
 	})
 
 	fmt.Printf("Cluster review UI: http://%s\n", strings.TrimSpace(*listen))
 	fmt.Printf("Studio file: %s\n", strings.TrimSpace(*file))
+	if databaseURL != "" {
+		fmt.Println("Gameplay stats: on")
+	}
 	fmt.Println("Review server binds localhost by default; use -allow-non-local to override.")
 	return http.ListenAndServe(strings.TrimSpace(*listen), mux)
 }
@@ -114,12 +143,22 @@ func validateReviewListenAddr(addr string, allowNonLocal bool) error {
 	}
 }
 
+// reviewConfig is the review server's fixed setup, shared by the page and
+// edit handlers.
+type reviewConfig struct {
+	Path        string
+	DatabaseURL string
+	Analysis    clustercontent.StudioAnalysisOptions
+}
+
 type reviewFilters struct {
-	Query  string
-	Status string
-	Theme  string
-	Rating string
-	Sort   string
+	Query     string
+	Status    string
+	Theme     string
+	Rating    string
+	Authoring string
+	Run       string
+	Sort      string
 }
 
 type reviewPromptRow struct {
@@ -132,6 +171,12 @@ type reviewPromptRow struct {
 	AxisSlugs   []string
 	AxisCount   int
 	Notes       string
+	Stats       clustercontent.PromptQuality
+	Played      bool
+	SimilarTo   []string
+	Authoring   string
+	AuthorLabel string
+	Generator   *clustercontent.Generator
 }
 
 type reviewPageData struct {
@@ -141,50 +186,108 @@ type reviewPageData struct {
 	Filters        reviewFilters
 	StatusOptions  []string
 	ThemeOptions   []string
+	RunOptions     []string
+	AuthoringModes []string
 	PromptRows     []reviewPromptRow
 	PromptRowCount int
 	Diagnostics    clustercontent.StudioDiagnostics
 	LoadError      string
 	ValidateError  string
+	SaveError      string
+	SavedSlug      string
+	FilterQuery    string
+	SaveAction     template.URL
+	ArchiveAction  template.URL
+	AxisOptions    []string
 	FileModUnix    int64
+	FileHash       string
+	StatsEnabled   bool
+	StatsError     string
+	StatsRounds    int
+	FlaggedCount   int
+	AxisUsage      []clustercontent.AxisSetUsage
+}
+
+func renderStudioReview(w http.ResponseWriter, r *http.Request, cfg reviewConfig) {
+	writeReviewPage(w, http.StatusOK, buildStudioReviewPage(r, cfg))
 }
 
-func renderStudioReview(w http.ResponseWriter, r *http.Request, path string) {
+func buildStudioReviewPage(r *http.Request, cfg reviewConfig) reviewPageData {
+	filterQuery := r.URL.Query()
+	filterQuery.Del("saved")
 	page := reviewPageData{
-		SourcePath: path,
+		FilterQuery: filterQuery.Encode(),
+		SavedSlug:   strings.TrimSpace(r.URL.Query().Get("saved")),
+		SourcePath:  cfg.Path,
 		Filters: reviewFilters{
-			Query:  strings.TrimSpace(r.URL.Query().Get("q")),
-			Status: strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status"))),
-			Theme:  strings.TrimSpace(r.URL.Query().Get("theme")),
-			Rating: strings.TrimSpace(r.URL.Query().Get("rating")),
-			Sort:   strings.TrimSpace(r.URL.Query().Get("sort")),
+			Query:     strings.TrimSpace(r.URL.Query().Get("q")),
+			Status:    strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status"))),
+			Theme:     strings.TrimSpace(r.URL.Query().Get("theme")),
+			Rating:    strings.TrimSpace(r.URL.Query().Get("rating")),
+			Authoring: strings.TrimSpace(r.URL.Query().Get("authoring")),
+			Run:       strings.TrimSpace(r.URL.Query().Get("run")),
+			Sort:      strings.TrimSpace(r.URL.Query().Get("sort")),
 		},
+		AuthoringModes: clustercontent.AuthoringModes,
 	}
 
 	if page.Filters.Sort == "" {
 		page.Filters.Sort = "slug"
 	}
+	page.SaveAction = reviewFormAction("/prompts/save", page.FilterQuery)
+	page.ArchiveAction = reviewFormAction("/prompts/archive", page.FilterQuery)
 
-	src, err := clustercontent.LoadStudio(path)
+	src, err := clustercontent.LoadStudio(cfg.Path)
 	if err != nil {
 		page.LoadError = err.Error()
-		writeReviewPage(w, page)
-		return
+		return page
 	}
 	page.SourceVersion = src.Version
 	page.CreatedByLabel = src.CreatedByLabel
-	if info, err := os.Stat(path); err == nil {
+	for _, axis := range src.AxisSets {
+		page.AxisOptions = append(page.AxisOptions, axis.Slug)
+	}
+	sort.Strings(page.AxisOptions)
+	if info, err := os.Stat(cfg.Path); err == nil {
 		page.FileModUnix = info.ModTime().Unix()
 	}
+	if hash, err := clustercontent.StudioHash(cfg.Path); err == nil {
+		page.FileHash = hash
+	}
 
-	diag, validateErr := clustercontent.ValidateStudio(src)
+	diag, validateErr := clustercontent.ValidateStudioWithOptions(src, cfg.Analysis)
 	page.Diagnostics = diag
 	if validateErr != nil {
 		page.ValidateError = validateErr.Error()
 	}
 
+	var stats clustercontent.QualityReport
+	if cfg.DatabaseURL != "" {
+		page.StatsEnabled = true
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		stats, err = loadQualityReport(ctx, cfg.DatabaseURL)
+		cancel()
+		if err != nil {
+			page.StatsError = err.Error()
+		}
+		page.StatsRounds = stats.Rounds
+		page.AxisUsage = stats.AxisSets
+	}
+
+	similarTo := map[string][]string{}
+	for _, cluster := range diag.NearDuplicatePrompts {
+		for _, slug := range cluster.Slugs {
+			for _, other := range cluster.Slugs {
+				if other != slug {
+					similarTo[slug] = append(similarTo[slug], other)
+				}
+			}
+		}
+	}
+
 	statusSet := map[string]bool{}
 	themeSet := map[string]bool{}
+	runSet := map[string]bool{}
 	rows := make([]reviewPromptRow, 0, len(src.Prompts))
 	for _, p := range src.Prompts {
 		status := normalizeStatusForReview(p.Status)
@@ -193,11 +296,18 @@ func renderStudioReview(w http.ResponseWriter, r *http.Request, path string) {
 		if theme != "" {
 			themeSet[theme] = true
 		}
+		if p.Generator != nil && p.Generator.RunID != "" {
+			runSet[p.Generator.RunID] = true
+		}
 
 		if !matchesReviewFilters(p, page.Filters) {
 			continue
 		}
 
+		quality, played := stats.Prompt(p.Slug)
+		if len(quality.Flags) > 0 {
+			page.FlaggedCount++
+		}
 		rows = append(rows, reviewPromptRow{
 			Slug:        p.Slug,
 			Text:        p.Text,
@@ -208,6 +318,12 @@ func renderStudioReview(w http.ResponseWriter, r *http.Request, path string) {
 			AxisSlugs:   slices.Clone(p.AxisSlugs),
 			AxisCount:   len(p.AxisSlugs),
 			Notes:       strings.TrimSpace(p.Notes),
+			Stats:       quality,
+			Played:      played,
+			SimilarTo:   similarTo[p.Slug],
+			Authoring:   p.EffectiveAuthoringMode(),
+			AuthorLabel: p.AuthorLabel,
+			Generator:   p.Generator,
 		})
 	}
 
@@ -219,12 +335,25 @@ func renderStudioReview(w http.ResponseWriter, r *http.Request, path string) {
 		page.ThemeOptions = append(page.ThemeOptions, theme)
 	}
 	sort.Strings(page.ThemeOptions)
+	for run := range runSet {
+		page.RunOptions = append(page.RunOptions, run)
+	}
+	sort.Strings(page.RunOptions)
 
 	sortReviewPromptRows(rows, page.Filters.Sort)
 	page.PromptRows = rows
 	page.PromptRowCount = len(rows)
 
-	writeReviewPage(w, page)
+	return page
+}
+
+// reviewFormAction keeps the current filters on edit forms so the redirect
+// after a save lands on the same view.
+func reviewFormAction(path string, filterQuery string) template.URL {
+	if filterQuery == "" {
+		return template.URL(path)
+	}
+	return template.URL(path + "?" + filterQuery)
 }
 
 func normalizeStatusForReview(value string) string {
@@ -269,6 +398,18 @@ func matchesReviewFilters(p clustercontent.StudioPrompt, filters reviewFilters)
 		}
 	}
 
+	if mode := strings.TrimSpace(filters.Authoring); mode != "" {
+		if p.EffectiveAuthoringMode() != mode {
+			return false
+		}
+	}
+
+	if run := strings.TrimSpace(filters.Run); run != "" {
+		if p.Generator == nil || p.Generator.RunID != run {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -288,6 +429,20 @@ func sortReviewPromptRows(rows []reviewPromptRow, key string) {
 			}
 			return rows[i].Slug < rows[j].Slug
 		})
+	case "skip":
+		sort.SliceStable(rows, func(i, j int) bool {
+			if rows[i].Stats.SkipRate != rows[j].Stats.SkipRate {
+				return rows[i].Stats.SkipRate > rows[j].Stats.SkipRate
+			}
+			return rows[i].Slug < rows[j].Slug
+		})
+	case "rounds":
+		sort.SliceStable(rows, func(i, j int) bool {
+			if rows[i].Stats.Rounds != rows[j].Stats.Rounds {
+				return rows[i].Stats.Rounds > rows[j].Stats.Rounds
+			}
+			return rows[i].Slug < rows[j].Slug
+		})
 	case "axes":
 		sort.SliceStable(rows, func(i, j int) bool {
 			if rows[i].AxisCount != rows[j].AxisCount {
@@ -300,15 +455,21 @@ func sortReviewPromptRows(rows []reviewPromptRow, key string) {
 	}
 }
 
-func writeReviewPage(w http.ResponseWriter, page reviewPageData) {
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := reviewPageTemplate.Execute(w, page); err != nil {
+func writeReviewPage(w http.ResponseWriter, status int, page reviewPageData) {
+	var buf bytes.Buffer
+	if err := reviewPageTemplate.Execute(&buf, page); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
 }
 
 var reviewPageTemplate = template.Must(template.New("review").Funcs(template.FuncMap{
-	"join": strings.Join,
+	"join":    strings.Join,
+	"percent": func(v float64) string { return fmt.Sprintf("%.0f%%", v*100) },
+	"fixed2":  func(v float64) string { return fmt.Sprintf("%.2f", v) },
 }).Parse(`<!doctype html>
 <html lang="en">
 <head>
@@ -341,8 +502,23 @@ var reviewPageTemplate = template.Must(template.New("review").Funcs(template.Fun
     .status-draft { border-color:#e9d6a2; background:#fff6df; color:#825100; }
     .status-archived { border-color:#d8d8d8; background:#f3f3f3; color:#5a5a5a; }
     .axis-list { color: var(--muted); font-size: 12px; line-height:1.4; }
+    .flag { border-color:#efc6c6; background:#fff4f4; color:var(--err); }
+    .stats { font-size: 12px; line-height:1.5; white-space: nowrap; }
+    h2 { font-size: 16px; margin: 20px 0 8px; }
     .warn-list { margin: 8px 0 0 18px; padding:0; }
     .warn-list li { margin: 2px 0; }
+    .banner.ok { border-color: #b7ddd5; background: #ecfaf6; color: #0e5e4f; }
+    details.edit summary { cursor: pointer; color: var(--accent); font-size: 12px; }
+    form.edit { display:grid; gap:8px; grid-template-columns: repeat(2, minmax(0,1fr)); margin-top: 8px; min-width: 420px; }
+    form.edit label { display:grid; gap:4px; font-size: 12px; color: var(--muted); }
+    form.edit .wide { grid-column: 1 / -1; }
+    form.edit input, form.edit select, form.edit textarea { width:100%; border:1px solid var(--line); border-radius: 8px; padding:6px 8px; background:#fff; font: inherit; color: var(--ink); }
+    form.edit textarea { min-height: 60px; }
+    button { border:1px solid var(--line); border-radius: 8px; padding:6px 10px; background:#f7f2ea; cursor:pointer; font: inherit; font-size: 12px; }
+    button.primary { background: var(--accent); border-color: var(--accent); color:#fff; }
+    form.archive { margin-top: 6px; }
+    .new-prompt { margin-bottom: 12px; }
+    tr.saved td { background: #f2fbf8; }
     @media (max-width: 960px) {
       .grid { grid-template-columns: 1fr 1fr; }
       form.filters { grid-template-columns: 1fr 1fr; }
@@ -360,7 +536,10 @@ var reviewPageTemplate = template.Must(template.New("review").Funcs(template.Fun
     {{if .LoadError}}
       <div class="banner err"><strong>Load error:</strong> {{.LoadError}}</div>
     {{else}}
+      {{if .SaveError}}<div class="banner err"><strong>Save failed:</strong> {{.SaveError}}</div>{{end}}
+      {{if .SavedSlug}}<div class="banner ok">Saved <code>{{.SavedSlug}}</code>. Diagnostics below reflect the updated file.</div>{{end}}
       {{if .ValidateError}}<div class="banner err"><strong>Validation error:</strong> {{.ValidateError}}</div>{{end}}
+      {{if .StatsError}}<div class="banner err"><strong>Stats error:</strong> {{.StatsError}}</div>{{end}}
       {{range .Diagnostics.Warnings}}
         <div class="banner warn">
           <div><strong>{{.Message}}</strong></div>
@@ -375,6 +554,9 @@ var reviewPageTemplate = template.Must(template.New("review").Funcs(template.Fun
         <div class="card"><div class="label">Ready / Draft</div><div class="metric">{{.Diagnostics.Summary.ReadyPrompts}} / {{.Diagnostics.Summary.DraftPrompts}}</div></div>
         <div class="card"><div class="label">Axes / Orphans</div><div class="metric">{{.Diagnostics.Summary.AxisCount}} / {{.Diagnostics.Summary.OrphanAxisCount}}</div></div>
         <div class="card"><div class="label">Missing Refs / Duplicates</div><div class="metric">{{.Diagnostics.Summary.MissingAxisRefCount}} / {{.Diagnostics.Summary.ExactDuplicateTextCount}}</div></div>
+        <div class="card"><div class="label">Near-Duplicate Prompts / Axes</div><div class="metric">{{.Diagnostics.Summary.NearDuplicatePromptSets}} / {{.Diagnostics.Summary.NearDuplicateAxisSets}}</div></div>
+        {{if .Diagnostics.Summary.MissingTranslations}}<div class="card"><div class="label">Missing Translations</div><div class="metric">{{.Diagnostics.Summary.MissingTranslations}}</div></div>{{end}}
+        {{if .StatsEnabled}}<div class="card"><div class="label">Rounds Played / Flagged Prompts</div><div class="metric">{{.StatsRounds}} / {{.FlaggedCount}}</div></div>{{end}}
       </div>
 
       <form class="filters" method="get">
@@ -393,14 +575,57 @@ var reviewPageTemplate = template.Must(template.New("review").Funcs(template.Fun
           <option value="20" {{if eq .Filters.Rating "20"}}selected{{end}}>Polite (20)</option>
           <option value="30" {{if eq .Filters.Rating "30"}}selected{{end}}>Adults (30)</option>
         </select>
+        <select name="authoring">
+          <option value="">All authoring</option>
+          {{range .AuthoringModes}}<option value="{{.}}" {{if eq $.Filters.Authoring .}}selected{{end}}>{{.}}</option>{{end}}
+        </select>
+        {{if .RunOptions}}
+        <select name="run">
+          <option value="">All generator runs</option>
+          {{range .RunOptions}}<option value="{{.}}" {{if eq $.Filters.Run .}}selected{{end}}>{{.}}</option>{{end}}
+        </select>
+        {{end}}
         <select name="sort">
           <option value="slug" {{if eq .Filters.Sort "slug"}}selected{{end}}>Sort: Slug</option>
           <option value="rating" {{if eq .Filters.Sort "rating"}}selected{{end}}>Sort: Rating</option>
           <option value="status" {{if eq .Filters.Sort "status"}}selected{{end}}>Sort: Status</option>
           <option value="axes" {{if eq .Filters.Sort "axes"}}selected{{end}}>Sort: Axis Count</option>
+          {{if .StatsEnabled}}
+          <option value="skip" {{if eq .Filters.Sort "skip"}}selected{{end}}>Sort: Skip Rate</option>
+          <option value="rounds" {{if eq .Filters.Sort "rounds"}}selected{{end}}>Sort: Rounds Played</option>
+          {{end}}
         </select>
       </form>
 
+      <datalist id="theme-options">{{range .ThemeOptions}}<option value="{{.}}">{{end}}</datalist>
+      <datalist id="axis-options">{{range .AxisOptions}}<option value="{{.}}">{{end}}</datalist>
+
+      <details class="edit new-prompt card">
+        <summary>New prompt</summary>
+        <form class="edit" method="post" action="{{.SaveAction}}">
+          <input type="hidden" name="file_hash" value="{{.FileHash}}">
+          <label>Slug<input name="slug" required></label>
+          <label>Theme<input name="theme" list="theme-options"></label>
+          <label class="wide">Prompt<textarea name="text" required></textarea></label>
+          <label>Status
+            <select name="status">
+              <option value="draft" selected>draft</option>
+              <option value="ready">ready</option>
+            </select>
+          </label>
+          <label>Rating
+            <select name="min_rating">
+              <option value="10">Mild (10)</option>
+              <option value="20" selected>Polite (20)</option>
+              <option value="30">Adults (30)</option>
+            </select>
+          </label>
+          <label class="wide">Axis slugs (comma separated)<input name="axis_slugs" list="axis-options" required></label>
+          <label class="wide">Notes<textarea name="notes"></textarea></label>
+          <div class="wide"><button class="primary" type="submit">Create prompt</button></div>
+        </form>
+      </details>
+
       <table>
         <thead>
           <tr>
@@ -411,24 +636,89 @@ var reviewPageTemplate = template.Must(template.New("review").Funcs(template.Fun
             <th>Theme</th>
             <th>Axes</th>
             <th>Notes</th>
+            {{if .StatsEnabled}}<th>Gameplay</th>{{end}}
+            <th>Edit</th>
           </tr>
         </thead>
         <tbody>
           {{range .PromptRows}}
-          <tr>
-            <td><span class="pill status-{{.Status}}">{{.Status}}</span></td>
+          <tr id="prompt-{{.Slug}}"{{if eq .Slug $.SavedSlug}} class="saved"{{end}}>
+            <td>
+              <span class="pill status-{{.Status}}">{{.Status}}</span>
+              <div class="axis-list">{{.Authoring}}{{with .AuthorLabel}} · {{.}}{{end}}</div>
+              {{with .Generator}}<div class="axis-list" title="{{.PromptVersion}}">{{.Model}} · {{.RunID}}</div>{{end}}
+            </td>
             <td>{{.RatingLabel}} ({{.Rating}})</td>
             <td><code>{{.Slug}}</code></td>
-            <td>{{.Text}}</td>
+            <td>{{.Text}}{{if .SimilarTo}}<div class="axis-list"><span class="pill flag">near-duplicate</span> {{join .SimilarTo ", "}}</div>{{end}}</td>
             <td>{{if .Theme}}{{.Theme}}{{else}}<span class="muted">-</span>{{end}}</td>
             <td><div>{{.AxisCount}}</div><div class="axis-list">{{join .AxisSlugs ", "}}</div></td>
             <td>{{if .Notes}}{{.Notes}}{{else}}<span class="muted">-</span>{{end}}</td>
+            {{if $.StatsEnabled}}
+            <td class="stats">
+              {{if .Played}}
+              <div>{{.Stats.Rounds}} rounds · {{percent .Stats.SkipRate}} skipped</div>
+              <div class="muted">spread {{fixed2 .Stats.AvgSpread}} · extremity {{fixed2 .Stats.AvgExtremity}}</div>
+              {{range .Stats.Flags}}<span class="pill flag">{{.}}</span> {{end}}
+              {{else}}<span class="muted">not played</span>{{end}}
+            </td>
+            {{end}}
+            <td>
+              <details class="edit">
+                <summary>Edit</summary>
+                <form class="edit" method="post" action="{{$.SaveAction}}">
+                  <input type="hidden" name="file_hash" value="{{$.FileHash}}">
+                  <input type="hidden" name="original_slug" value="{{.Slug}}">
+                  <label>Slug<input name="slug" value="{{.Slug}}" required></label>
+                  <label>Theme<input name="theme" value="{{.Theme}}" list="theme-options"></label>
+                  <label class="wide">Prompt<textarea name="text" required>{{.Text}}</textarea></label>
+                  <label>Status
+                    <select name="status">
+                      <option value="ready" {{if eq .Status "ready"}}selected{{end}}>ready</option>
+                      <option value="draft" {{if eq .Status "draft"}}selected{{end}}>draft</option>
+                      <option value="archived" {{if eq .Status "archived"}}selected{{end}}>archived</option>
+                    </select>
+                  </label>
+                  <label>Rating
+                    <select name="min_rating">
+                      <option value="10" {{if eq .Rating 10}}selected{{end}}>Mild (10)</option>
+                      <option value="20" {{if eq .Rating 20}}selected{{end}}>Polite (20)</option>
+                      <option value="30" {{if eq .Rating 30}}selected{{end}}>Adults (30)</option>
+                    </select>
+                  </label>
+                  <label class="wide">Axis slugs (comma separated)<input name="axis_slugs" value="{{join .AxisSlugs ", "}}" list="axis-options" required></label>
+                  <label class="wide">Notes<textarea name="notes">{{.Notes}}</textarea></label>
+                  <div class="wide"><button class="primary" type="submit">Save</button></div>
+                </form>
+              </details>
+              {{if ne .Status "archived"}}
+              <form class="archive" method="post" action="{{$.ArchiveAction}}">
+                <input type="hidden" name="file_hash" value="{{$.FileHash}}">
+                <input type="hidden" name="slug" value="{{.Slug}}">
+                <button type="submit">Archive</button>
+              </form>
+              {{end}}
+            </td>
           </tr>
           {{else}}
-          <tr><td colspan="7" class="muted">No prompts matched the current filters.</td></tr>
+          <tr><td colspan="9" class="muted">No prompts matched the current filters.</td></tr>
           {{end}}
         </tbody>
       </table>
+
+      {{if .AxisUsage}}
+      <h2>Axis Set Usage</h2>
+      <table>
+        <thead>
+          <tr><th>Axis Set</th><th>Rounds</th><th>Revealed</th><th>Skipped</th><th>Prompts</th></tr>
+        </thead>
+        <tbody>
+          {{range .AxisUsage}}
+          <tr><td><code>{{.Slug}}</code></td><td>{{.Rounds}}</td><td>{{.Revealed}}</td><td>{{.Skipped}}</td><td>{{.Prompts}}</td></tr>
+          {{end}}
+        </tbody>
+      </table>
+      {{end}}
     {{end}}
   </div>
 </body>
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+// studioEdit applies one review form submission to the studio source and
+// returns the prompt slug the page should point at after saving.
+type studioEdit func(src *clustercontent.StudioSource, form url.Values) (string, error)
+
+// handleStudioEdit loads the studio file, applies edit, and writes it back
+// only if the file has not changed since the page that posted the form was
+// rendered. The redirect re-renders the page, so diagnostics re-run on save.
+func handleStudioEdit(w http.ResponseWriter, r *http.Request, cfg reviewConfig, edit studioEdit) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeStudioEditError(w, r, cfg, http.StatusBadRequest, err)
+		return
+	}
+
+	loadedHash := strings.TrimSpace(r.PostForm.Get("file_hash"))
+	if loadedHash == "" {
+		writeStudioEditError(w, r, cfg, http.StatusBadRequest, errors.New("missing studio file version; reload the page and try again"))
+		return
+	}
+
+	src, err := clustercontent.LoadStudio(cfg.Path)
+	if err != nil {
+		writeStudioEditError(w, r, cfg, http.StatusInternalServerError, err)
+		return
+	}
+
+	slug, err := edit(&src, r.PostForm)
+	if err != nil {
+		writeStudioEditError(w, r, cfg, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if err := clustercontent.SaveStudioIfUnchanged(cfg.Path, src, loadedHash); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, clustercontent.ErrStudioChanged) {
+			status = http.StatusConflict
+		}
+		writeStudioEditError(w, r, cfg, status, err)
+		return
+	}
+
+	query := r.URL.Query()
+	query.Set("saved", slug)
+	http.Redirect(w, r, "/?"+query.Encode(), http.StatusSeeOther)
+}
+
+func writeStudioEditError(w http.ResponseWriter, r *http.Request, cfg reviewConfig, status int, err error) {
+	page := buildStudioReviewPage(r, cfg)
+	page.SavedSlug = ""
+	page.SaveError = err.Error()
+	writeReviewPage(w, status, page)
+}
+
+func applyPromptSave(src *clustercontent.StudioSource, form url.Values) (string, error) {
+	rating, err := contentrating.ParseID(form.Get("min_rating"))
+	if err != nil {
+		return "", err
+	}
+	prompt := clustercontent.StudioPrompt{
+		Slug:      strings.TrimSpace(form.Get("slug")),
+		Text:      form.Get("text"),
+		MinRating: rating,
+		AxisSlugs: strings.Split(form.Get("axis_slugs"), ","),
+		Theme:     form.Get("theme"),
+		Status:    form.Get("status"),
+		Notes:     form.Get("notes"),
+	}
+	originalSlug := strings.TrimSpace(form.Get("original_slug"))
+	if originalSlug == "" {
+		// Prompts created in the review UI are hand-written.
+		prompt.AuthoringMode = clustercontent.AuthoringManual
+	}
+	if err := src.UpsertPrompt(originalSlug, prompt); err != nil {
+		return "", err
+	}
+	return prompt.Slug, nil
+}
+
+func applyPromptArchive(src *clustercontent.StudioSource, form url.Values) (string, error) {
+	slug := strings.TrimSpace(form.Get("slug"))
+	if err := src.ArchivePrompt(slug); err != nil {
+		return "", err
+	}
+	return slug, nil
+}
@@ -1,6 +1,15 @@
 package main
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+)
 
 func TestValidateReviewListenAddr(t *testing.T) {
 	if err := validateReviewListenAddr("127.0.0.1:8097", false); err != nil {
@@ -16,3 +25,131 @@ func TestValidateReviewListenAddr(t *testing.T) {
 		t.Fatalf("override should allow non-local bind: %v", err)
 	}
 }
+
+func TestReviewPageRendersGameplayStats(t *testing.T) {
+	page := reviewPageData{
+		StatsEnabled: true,
+		PromptRows: []reviewPromptRow{
+			{Slug: "dull", Status: "ready", Played: true, Stats: clustercontent.PromptQuality{Rounds: 4, SkipRate: 0.5, Flags: []string{clustercontent.FlagOftenSkipped}}},
+			{Slug: "fresh", Status: "ready"},
+		},
+		AxisUsage: []clustercontent.AxisSetUsage{{Slug: "plane", Rounds: 4}},
+	}
+
+	var out strings.Builder
+	if err := reviewPageTemplate.Execute(&out, page); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{"4 rounds · 50% skipped", clustercontent.FlagOftenSkipped, "not played", "Axis Set Usage"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in review page", want)
+		}
+	}
+}
+
+func TestStudioEditSavesAndRejectsStaleForms(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "studio.json")
+	src := clustercontent.StudioSource{
+		Version:        "v1",
+		CreatedByLabel: "cluster-studio",
+		AxisSets:       []clustercontent.AxisSet{{Slug: "axis-a", XMinLabel: "L", XMaxLabel: "H", YMinLabel: "S", YMaxLabel: "F", MinRating: 10}},
+		Prompts:        []clustercontent.StudioPrompt{{Slug: "p1", Text: "Original", MinRating: 20, AxisSlugs: []string{"axis-a"}, Status: "ready"}},
+	}
+	if err := clustercontent.SaveStudio(path, src); err != nil {
+		t.Fatalf("save studio: %v", err)
+	}
+	loadedHash, err := clustercontent.StudioHash(path)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+
+	post := func(target string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		edit := applyPromptSave
+		if strings.HasPrefix(target, "/prompts/archive") {
+			edit = applyPromptArchive
+		}
+		handleStudioEdit(rec, req, reviewConfig{Path: path}, edit)
+		return rec
+	}
+
+	rec := post("/prompts/save?status=ready", url.Values{
+		"file_hash":     {loadedHash},
+		"original_slug": {"p1"},
+		"slug":          {"p1"},
+		"text":          {"Edited"},
+		"status":        {"draft"},
+		"min_rating":    {"10"},
+		"axis_slugs":    {"axis-a"},
+	})
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("expected redirect after save, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if loc := rec.Header().Get("Location"); !strings.Contains(loc, "saved=p1") || !strings.Contains(loc, "status=ready") {
+		t.Fatalf("expected redirect to keep filters and mark the saved prompt, got %q", loc)
+	}
+	saved, err := clustercontent.LoadStudio(path)
+	if err != nil {
+		t.Fatalf("load studio: %v", err)
+	}
+	if saved.Prompts[0].Text != "Edited" || saved.Prompts[0].Status != "draft" || saved.Prompts[0].MinRating != 10 {
+		t.Fatalf("unexpected saved prompt: %+v", saved.Prompts[0])
+	}
+
+	// Replaying the form with the old hash must not clobber the file that was
+	// just written, even within the same second.
+	rec = post("/prompts/archive", url.Values{
+		"file_hash": {loadedHash},
+		"slug":      {"p1"},
+	})
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected conflict for stale form, got %d", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "Save failed") {
+		t.Fatal("expected save error banner on conflict")
+	}
+	saved, _ = clustercontent.LoadStudio(path)
+	if saved.Prompts[0].Status != "draft" {
+		t.Fatalf("stale archive should not have been written: %+v", saved.Prompts[0])
+	}
+}
+
+func TestReviewPageFiltersByProvenance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "studio.json")
+	gen := &clustercontent.Generator{Provider: "openai", Model: "gpt-test", PromptVersion: clustercontent.GeneratorPromptVersion, RunID: "run-1"}
+	src := clustercontent.StudioSource{
+		Version:        "v1",
+		CreatedByLabel: "cluster-studio",
+		AxisSets:       []clustercontent.AxisSet{{Slug: "axis-a", XMinLabel: "L", XMaxLabel: "H", YMinLabel: "S", YMaxLabel: "F", MinRating: 10}},
+		Prompts: []clustercontent.StudioPrompt{
+			{Slug: "legacy", Text: "Legacy prompt", MinRating: 20, AxisSlugs: []string{"axis-a"}},
+			{Slug: "drafted", Text: "Drafted prompt", MinRating: 20, AxisSlugs: []string{"axis-a"}, Provenance: clustercontent.Provenance{AuthoringMode: clustercontent.AuthoringAIGenerated, Generator: gen}},
+		},
+	}
+	if err := clustercontent.SaveStudio(path, src); err != nil {
+		t.Fatalf("save studio: %v", err)
+	}
+
+	page := buildStudioReviewPage(httptest.NewRequest(http.MethodGet, "/?run=run-1", nil), reviewConfig{Path: path})
+	if page.PromptRowCount != 1 || page.PromptRows[0].Slug != "drafted" {
+		t.Fatalf("expected run filter to keep only the generated prompt, got %+v", page.PromptRows)
+	}
+	if len(page.RunOptions) != 1 || page.RunOptions[0] != "run-1" {
+		t.Fatalf("expected run options from the studio file, got %v", page.RunOptions)
+	}
+
+	page = buildStudioReviewPage(httptest.NewRequest(http.MethodGet, "/?authoring=imported", nil), reviewConfig{Path: path})
+	if page.PromptRowCount != 1 || page.PromptRows[0].Slug != "legacy" {
+		t.Fatalf("expected prompts without a mode to count as imported, got %+v", page.PromptRows)
+	}
+
+	var out strings.Builder
+	if err := reviewPageTemplate.Execute(&out, buildStudioReviewPage(httptest.NewRequest(http.MethodGet, "/", nil), reviewConfig{Path: path})); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if !strings.Contains(out.String(), "gpt-test · run-1") {
+		t.Fatal("expected generator model and run on the prompt row")
+	}
+}
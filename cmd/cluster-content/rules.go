@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+const defaultRulesFile = "content/cluster/rules.json"
+
+// Exit codes for validate, so a pre-commit hook can tell bad content from a
+// bad invocation.
+const (
+	exitInvalidContent = 1
+	exitUsage          = 2
+)
+
+// errRulesFailed reports that content broke an error-severity rule (or any
+// rule under -strict).
+var errRulesFailed = errors.New("content rules failed")
+
+// usageError marks flag and rules config problems, which exit with
+// exitUsage instead of exitInvalidContent.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
+func validateExitCode(err error) int {
+	var usage usageError
+	if errors.As(err, &usage) {
+		return exitUsage
+	}
+	return exitInvalidContent
+}
+
+// printRuleViolations lists violations one per line and returns the error
+// and warning counts.
+func printRuleViolations(violations []clustercontent.RuleViolation) (int, int) {
+	for _, v := range violations {
+		fmt.Printf("- %-7s %-20s %s\n", v.Severity, v.Rule, v)
+	}
+	errCount, warnCount := clustercontent.CountBySeverity(violations)
+	fmt.Printf("Rule findings: errors=%d, warnings=%d\n", errCount, warnCount)
+	return errCount, warnCount
+}
+
+// printAIReview asks reviewer to rate every item and lists the ones it rates
+// stricter than assigned as warnings. It returns the warning count.
+func printAIReview(ctx context.Context, reviewer contentrating.Reviewer, items []contentrating.Item) (int, error) {
+	findings, err := contentrating.Review(ctx, reviewer, items)
+	if err != nil {
+		return 0, fmt.Errorf("ai review: %w", err)
+	}
+	for _, f := range findings {
+		fmt.Printf("- %-7s %-20s %s\n", clustercontent.SeverityWarning, "ai-review", f)
+	}
+	fmt.Printf("AI review findings: warnings=%d of %d items\n", len(findings), len(items))
+	return len(findings), nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+	"github.com/jgoodhcg/mindmeld/internal/importsafety"
+)
+
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	databaseURLFlag := fs.String("database-url", "", "Explicit database URL (fallback: DATABASE_URL)")
+	minRounds := fs.Int("min-rounds", 1, "Only list prompts played at least this many rounds")
+	flaggedOnly := fs.Bool("flagged", false, "Only list prompts with quality flags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	databaseURL, err := importsafety.ResolveDatabaseURL(*databaseURLFlag)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	report, err := loadQualityReport(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Rounds analyzed: %d\n", report.Rounds)
+	fmt.Printf("Prompts played: %d\n", len(report.Prompts))
+	fmt.Printf("Flags need %d finished rounds: %s at %.0f%%+ skips, %s under %.2f mean distance from the centroid\n",
+		clustercontent.QualityMinRounds,
+		clustercontent.FlagOftenSkipped, clustercontent.OftenSkippedRate*100,
+		clustercontent.FlagLowSpread, clustercontent.LowSpreadDistance,
+	)
+	fmt.Println()
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PROMPT\tROUNDS\tREVEALED\tSKIPPED\tSKIP RATE\tSPREAD\tEXTREMITY\tFLAGS")
+	for _, prompt := range report.Prompts {
+		if prompt.Rounds < *minRounds || (*flaggedOnly && len(prompt.Flags) == 0) {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.0f%%\t%.2f\t%.2f\t%s\n",
+			prompt.Slug, prompt.Rounds, prompt.Revealed, prompt.Skipped,
+			prompt.SkipRate*100, prompt.AvgSpread, prompt.AvgExtremity,
+			strings.Join(prompt.Flags, ","),
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	tw = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "AXIS SET\tROUNDS\tREVEALED\tSKIPPED\tPROMPTS")
+	for _, axis := range report.AxisSets {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\n", axis.Slug, axis.Rounds, axis.Revealed, axis.Skipped, axis.Prompts)
+	}
+	return tw.Flush()
+}
+
+// loadQualityReport only reads gameplay tables, so unlike import it does not
+// need the production guard.
+func loadQualityReport(ctx context.Context, databaseURL string) (clustercontent.QualityReport, error) {
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		return clustercontent.QualityReport{}, err
+	}
+	defer pool.Close()
+
+	return clustercontent.LoadQualityReport(ctx, pool)
+}
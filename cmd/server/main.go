@@ -16,6 +16,7 @@ import (
 
 	"github.com/jgoodhcg/mindmeld/internal/assets"
 	"github.com/jgoodhcg/mindmeld/internal/server"
+	"github.com/jgoodhcg/mindmeld/internal/triviacontent"
 	"github.com/jgoodhcg/mindmeld/templates"
 )
 
@@ -58,6 +59,20 @@ func main() {
 	}
 	log.Println("Connected to database")
 
+	// Trivia templates live in the database; seed a fresh one from the
+	// curated library.
+	triviaLibrary := os.Getenv("TRIVIA_LIBRARY_FILE")
+	if triviaLibrary == "" {
+		triviaLibrary = triviacontent.DefaultLibraryFile
+	}
+	// The catalog is optional content: if seeding fails the server still
+	// starts and trivia reports the empty catalog when it is used.
+	if seeded, err := triviacontent.SeedIfEmpty(ctx, pool, triviaLibrary); err != nil {
+		log.Printf("Unable to seed trivia template catalog from %s: %v", triviaLibrary, err)
+	} else if seeded {
+		log.Printf("Seeded empty trivia template catalog from %s", triviaLibrary)
+	}
+
 	// Create server
 	srvInstance := server.NewServer(pool)
 	if rawGracePeriod := os.Getenv("DISCONNECT_GRACE_PERIOD"); rawGracePeriod != "" {
@@ -0,0 +1,398 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+	"github.com/jgoodhcg/mindmeld/internal/importsafety"
+	"github.com/jgoodhcg/mindmeld/internal/triviacontent"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "build":
+		if err := runBuild(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "validate":
+		if err := runValidate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "import":
+		if err := runImport(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "ingest":
+		if err := runIngest(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	case "export":
+		if err := runExport(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Println("Trivia Content Tool")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  go run ./cmd/trivia-content build [-source-dir content/trivia/source] [-file content/trivia/library.v1.json]")
+	fmt.Println("  go run ./cmd/trivia-content validate [-source-dir content/trivia/source | -file content/trivia/library.v1.json]")
+	fmt.Println("  go run ./cmd/trivia-content import [-source-dir content/trivia/source | -file content/trivia/library.v1.json] [flags]")
+	fmt.Println("  go run ./cmd/trivia-content ingest -in bank.csv -pack team-bank -pack-name \"Team Bank\" [flags]")
+	fmt.Println("  go run ./cmd/trivia-content export [-source-dir content/trivia/source | -file content/trivia/library.v1.json] [flags]")
+	fmt.Println()
+	fmt.Println("Build Flags:")
+	fmt.Println("  -file string           Output library JSON path (default content/trivia/library.v1.json)")
+	fmt.Println("  -source-dir string     Canonical source directory (meta.json, packs.tsv, templates.tsv)")
+	fmt.Println()
+	fmt.Println("Validate Flags:")
+	fmt.Println("  -strict                Fail when the rating check flags a pack or template as under-rated")
+	fmt.Println()
+	fmt.Println("Import Flags:")
+	fmt.Println("  -database-url string   Explicit DB URL (fallback: DATABASE_URL env)")
+	fmt.Println("  -env string            Target environment: dev|prod (default dev)")
+	fmt.Println("  -dry-run               Preview DB changes without writes")
+	fmt.Println("  -allow-production      Required for production-like DB URLs")
+	fmt.Println()
+	fmt.Println("Ingest Flags:")
+	fmt.Println("  -in string             CSV or Open Trivia DB JSON file to read")
+	fmt.Println("  -format string         csv|opentdb (default: inferred from -in extension)")
+	fmt.Println("  -pack string           Pack slug to add templates to")
+	fmt.Println("  -pack-name string      Pack name, required when the pack is new")
+	fmt.Println("  -pack-description      Pack description for a new pack")
+	fmt.Println("  -category string       Category for rows without one (default General)")
+	fmt.Println("  -min-rating string     Rating for rows without one: mild|polite|adults or 10|20|30 (default polite)")
+	fmt.Println("  -source-dir string     Source directory to append to (default content/trivia/source)")
+	fmt.Println("  -dry-run               Validate and report without writing")
+	fmt.Println()
+	fmt.Println("Export Flags:")
+	fmt.Println("  -format string         csv|opentdb (default csv)")
+	fmt.Println("  -out string            Output path (default stdout)")
+	fmt.Println("  -pack string           Only export templates from this pack slug")
+}
+
+func runBuild(args []string) error {
+	fs := flag.NewFlagSet("build", flag.ContinueOnError)
+	file := fs.String("file", "content/trivia/library.v1.json", "Output trivia library JSON path")
+	sourceDir := fs.String("source-dir", "content/trivia/source", "Source directory containing meta.json, packs.tsv, and templates.tsv")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	lib, loadReport, err := triviacontent.LoadSourceDir(strings.TrimSpace(*sourceDir))
+	if err != nil {
+		return err
+	}
+
+	report, err := triviacontent.Validate(lib)
+	if err != nil {
+		return err
+	}
+
+	if err := triviacontent.SaveLibrary(strings.TrimSpace(*file), lib); err != nil {
+		return err
+	}
+
+	fmt.Printf("Source dir: %s\n", strings.TrimSpace(*sourceDir))
+	fmt.Printf("Pack rows read: %d\n", loadReport.PackRows)
+	fmt.Printf("Template rows read: %d (ready=%d, draft=%d)\n", loadReport.TemplateRows, loadReport.TemplateReady, loadReport.TemplateDraft)
+	fmt.Printf("Wrote: %s\n", strings.TrimSpace(*file))
+	printReport(report)
+	fmt.Println("Build: OK")
+	return nil
+}
+
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	file := fs.String("file", "content/trivia/library.v1.json", "Path to trivia library JSON")
+	sourceDir := fs.String("source-dir", "", "Source directory containing meta.json, packs.tsv, and templates.tsv")
+	strict := fs.Bool("strict", false, "Fail when the rating check flags anything")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	lib, report, err := loadAndValidate(*file, *sourceDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Library version: %s\n", lib.Version)
+	fmt.Printf("Created by label: %s\n", lib.CreatedByLabel)
+	printReport(report)
+
+	findings := contentrating.Check(triviacontent.RatingItems(lib))
+	for _, f := range findings {
+		fmt.Printf("- %s\n", f)
+	}
+	fmt.Printf("Rating check: %d possibly under-rated\n", len(findings))
+	if *strict && len(findings) > 0 {
+		return fmt.Errorf("rating check flagged %d items", len(findings))
+	}
+	fmt.Println("Validation: OK")
+	return nil
+}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := fs.String("file", "content/trivia/library.v1.json", "Path to trivia library JSON")
+	sourceDir := fs.String("source-dir", "", "Source directory containing meta.json, packs.tsv, and templates.tsv")
+	databaseURLFlag := fs.String("database-url", "", "Explicit database URL (fallback: DATABASE_URL)")
+	targetEnv := fs.String("env", "dev", "Target environment: dev|prod")
+	dryRun := fs.Bool("dry-run", false, "Preview change plan without DB writes")
+	allowProduction := fs.Bool("allow-production", false, "Required for production-like DB URLs")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	databaseURL, err := importsafety.ResolveDatabaseURL(*databaseURLFlag)
+	if err != nil {
+		return err
+	}
+	if err := importsafety.Validate(strings.TrimSpace(*targetEnv), databaseURL, *allowProduction); err != nil {
+		return err
+	}
+
+	lib, report, err := loadAndValidate(*file, *sourceDir)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	if err := pool.Ping(ctx); err != nil {
+		return err
+	}
+
+	plan, err := triviacontent.Analyze(ctx, pool, lib)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Target env: %s\n", importsafety.NormalizeEnv(*targetEnv))
+	printReport(report)
+	printPlan(plan)
+
+	if *dryRun {
+		fmt.Println("Dry-run: no writes applied.")
+		return nil
+	}
+
+	if err := triviacontent.Import(ctx, pool, lib); err != nil {
+		return err
+	}
+	fmt.Println("Import: OK")
+	return nil
+}
+
+func runIngest(args []string) error {
+	fs := flag.NewFlagSet("ingest", flag.ContinueOnError)
+	in := fs.String("in", "", "CSV or Open Trivia DB JSON file to read")
+	format := fs.String("format", "", "csv|opentdb (default: inferred from -in extension)")
+	packSlug := fs.String("pack", "", "Pack slug to add templates to")
+	packName := fs.String("pack-name", "", "Pack name, required when the pack is new")
+	packDescription := fs.String("pack-description", "", "Pack description for a new pack")
+	category := fs.String("category", "General", "Category for rows without one")
+	minRatingFlag := fs.String("min-rating", "polite", "Rating for rows without one")
+	sourceDir := fs.String("source-dir", "content/trivia/source", "Source directory containing meta.json, packs.tsv, and templates.tsv")
+	dryRun := fs.Bool("dry-run", false, "Validate and report without writing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	inPath := strings.TrimSpace(*in)
+	if inPath == "" {
+		return fmt.Errorf("-in is required")
+	}
+	slug := strings.TrimSpace(*packSlug)
+	if slug == "" {
+		return fmt.Errorf("-pack is required")
+	}
+	if strings.TrimSpace(*format) == "" {
+		inferred, err := triviacontent.FormatFromPath(inPath)
+		if err != nil {
+			return err
+		}
+		*format = inferred
+	}
+	minRating, err := triviacontent.ParseMinRating(*minRatingFlag)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(inPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	templates, err := triviacontent.ParseTemplates(*format, f, triviacontent.ImportOptions{
+		PackSlug:  slug,
+		Category:  strings.TrimSpace(*category),
+		MinRating: minRating,
+	})
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(*packName)
+	if name == "" {
+		name = slug
+	}
+	pack := triviacontent.Pack{
+		Slug:        slug,
+		Name:        name,
+		Description: strings.TrimSpace(*packDescription),
+		MinRating:   minRating,
+	}
+
+	dir := strings.TrimSpace(*sourceDir)
+	report, appendReport, err := triviacontent.AppendSourceDir(dir, pack, templates, triviacontent.SourceAppendOptions{
+		Notes:  "imported from " + filepath.Base(inPath),
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Source dir: %s\n", dir)
+	fmt.Printf("Rows parsed (%s): %d\n", *format, len(templates))
+	fmt.Printf("Pack %q: created=%t\n", slug, appendReport.PackCreated)
+	fmt.Printf("Templates appended: %d (skipped existing=%d)\n", appendReport.Appended, appendReport.SkippedExisting)
+	printReport(report)
+	if *dryRun {
+		fmt.Println("Dry-run: no files written.")
+		return nil
+	}
+	fmt.Println("Ingest: OK (run build to refresh the library JSON)")
+	return nil
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := fs.String("file", "content/trivia/library.v1.json", "Path to trivia library JSON")
+	sourceDir := fs.String("source-dir", "", "Source directory containing meta.json, packs.tsv, and templates.tsv")
+	format := fs.String("format", triviacontent.FormatCSV, "csv|opentdb")
+	out := fs.String("out", "", "Output path (default stdout)")
+	packSlug := fs.String("pack", "", "Only export templates from this pack slug")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	lib, _, err := loadAndValidate(*file, *sourceDir)
+	if err != nil {
+		return err
+	}
+
+	templates := lib.Templates
+	if slug := strings.TrimSpace(*packSlug); slug != "" {
+		templates = make([]triviacontent.Template, 0, len(lib.Templates))
+		for _, tpl := range lib.Templates {
+			if tpl.PackSlug == slug {
+				templates = append(templates, tpl)
+			}
+		}
+		if len(templates) == 0 {
+			return fmt.Errorf("no templates found for pack %q", slug)
+		}
+	}
+
+	if strings.TrimSpace(*out) == "" {
+		return triviacontent.WriteTemplates(*format, os.Stdout, templates)
+	}
+
+	f, err := os.Create(strings.TrimSpace(*out))
+	if err != nil {
+		return err
+	}
+	if err := triviacontent.WriteTemplates(*format, f, templates); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d templates to %s\n", len(templates), strings.TrimSpace(*out))
+	return nil
+}
+
+func loadAndValidate(path string, sourceDir string) (triviacontent.Library, triviacontent.Report, error) {
+	var (
+		lib triviacontent.Library
+		err error
+	)
+	if strings.TrimSpace(sourceDir) != "" {
+		lib, _, err = triviacontent.LoadSourceDir(strings.TrimSpace(sourceDir))
+	} else {
+		lib, err = triviacontent.Load(path)
+	}
+	if err != nil {
+		return triviacontent.Library{}, triviacontent.Report{}, err
+	}
+
+	report, err := triviacontent.Validate(lib)
+	if err != nil {
+		return triviacontent.Library{}, triviacontent.Report{}, err
+	}
+	return lib, report, nil
+}
+
+func printReport(report triviacontent.Report) {
+	fmt.Printf("Packs: %d\n", report.PackCount)
+	fmt.Printf("Templates: %d\n", report.TemplateCount)
+	fmt.Printf("Templates available for Mild (%d): %d\n", contentrating.Kids, report.TemplateCountByRating[contentrating.Kids])
+	fmt.Printf("Templates available for Polite (%d): %d\n", contentrating.Work, report.TemplateCountByRating[contentrating.Work])
+	fmt.Printf("Templates available for Adults (%d): %d\n", contentrating.Adults, report.TemplateCountByRating[contentrating.Adults])
+
+	categories := make([]string, 0, len(report.TemplateCountByCategory))
+	for category := range report.TemplateCountByCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		fmt.Printf("- %s: %d\n", category, report.TemplateCountByCategory[category])
+	}
+}
+
+func printPlan(plan triviacontent.ImportPlan) {
+	fmt.Println("Planned DB changes:")
+	printEntityPlan("Packs", plan.Packs)
+	printEntityPlan("Templates", plan.Templates)
+}
+
+func printEntityPlan(label string, plan triviacontent.EntityPlan) {
+	fmt.Printf("- %s: desired=%d, managed_existing=%d, create=%d, upsert=%d, reactivate=%d, deactivate=%d\n",
+		label,
+		plan.DesiredCount,
+		plan.ManagedExistingCount,
+		plan.CreateCount,
+		plan.UpsertCount,
+		plan.ReactivateCount,
+		plan.DeactivateCount,
+	)
+}
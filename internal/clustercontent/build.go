@@ -99,6 +99,7 @@ func BuildLibraryFromPromptRows(axisTemplate Library, rows []PromptSourceRow) Li
 			Text:      row.Text,
 			MinRating: row.MinRating,
 			AxisSlugs: slices.Clone(row.AxisSlugs),
+			Theme:     strings.TrimSpace(row.Theme),
 		})
 	}
 
@@ -82,7 +82,7 @@ func TestBuildLibraryFromPromptRowsSkipsDrafts(t *testing.T) {
 		Prompts: []Prompt{{Slug: "old", Text: "old", MinRating: 10, AxisSlugs: []string{"axis-a"}}},
 	}
 	rows := []PromptSourceRow{
-		{Slug: "ready-one", Text: "Ready one", MinRating: 20, AxisSlugs: []string{"axis-a"}, Status: "ready"},
+		{Slug: "ready-one", Text: "Ready one", MinRating: 20, AxisSlugs: []string{"axis-a"}, Theme: " work ", Status: "ready"},
 		{Slug: "draft-one", Text: "Draft one", MinRating: 20, AxisSlugs: []string{"axis-a"}, Status: "draft"},
 		{Slug: "ready-two", Text: "Ready two", MinRating: 10, AxisSlugs: []string{"axis-a"}, Status: "ready"},
 	}
@@ -101,4 +101,7 @@ func TestBuildLibraryFromPromptRowsSkipsDrafts(t *testing.T) {
 	if lib.Prompts[0].Slug != "ready-one" || lib.Prompts[1].Slug != "ready-two" {
 		t.Fatalf("unexpected prompt order: %+v", lib.Prompts)
 	}
+	if lib.Prompts[0].Theme != "work" || lib.Prompts[1].Theme != "" {
+		t.Fatalf("expected themes to carry over trimmed, got %+v", lib.Prompts)
+	}
 }
@@ -0,0 +1,469 @@
+package clustercontent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Diff actions, in the order they are reported.
+const (
+	DiffCreate     = "create"
+	DiffReactivate = "reactivate"
+	DiffUpdate     = "update"
+	DiffDeactivate = "deactivate"
+)
+
+// LivePrompt is a prompt row as stored in the database.
+type LivePrompt struct {
+	ID             uuid.UUID
+	Slug           string
+	Text           string
+	Theme          string
+	MinRating      int16
+	Active         bool
+	CreatedByLabel string
+	Translations   map[string]PromptTranslation
+	Provenance
+}
+
+// LiveAxisSet is an axis set row as stored in the database.
+type LiveAxisSet struct {
+	ID             uuid.UUID
+	Slug           string
+	Mode           string
+	XMinLabel      string
+	XMaxLabel      string
+	YMinLabel      string
+	YMaxLabel      string
+	Buckets        []string
+	MinRating      int16
+	Active         bool
+	CreatedByLabel string
+	Translations   map[string]AxisTranslation
+	Provenance
+}
+
+// LivePair links a prompt row to an axis set row.
+type LivePair struct {
+	ID        uuid.UUID
+	PromptID  uuid.UUID
+	AxisSetID uuid.UUID
+	Active    bool
+}
+
+// LiveContent is a snapshot of the cluster content tables.
+type LiveContent struct {
+	Prompts  []LivePrompt
+	AxisSets []LiveAxisSet
+	Pairs    []LivePair
+}
+
+// FieldChange is one column that Import would rewrite. From is empty for
+// rows that do not exist yet.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// EntityChange is everything Import would do to one prompt or axis set.
+// AxesAdded and AxesRemoved are the axis slugs whose pairs Import would
+// activate or deactivate; they are only set for prompts.
+type EntityChange struct {
+	Slug        string        `json:"slug"`
+	Action      string        `json:"action"`
+	Fields      []FieldChange `json:"fields,omitempty"`
+	AxesAdded   []string      `json:"axes_added,omitempty"`
+	AxesRemoved []string      `json:"axes_removed,omitempty"`
+}
+
+// EntityDiffSummary counts changes by action.
+type EntityDiffSummary struct {
+	Create     int `json:"create"`
+	Reactivate int `json:"reactivate"`
+	Update     int `json:"update"`
+	Deactivate int `json:"deactivate"`
+	Unchanged  int `json:"unchanged"`
+}
+
+// ContentDiff is the field-level counterpart of ImportPlan: it lists each
+// slug Import would touch and what would change.
+type ContentDiff struct {
+	CreatedByLabel string            `json:"created_by_label"`
+	Prompts        []EntityChange    `json:"prompts"`
+	AxisSets       []EntityChange    `json:"axis_sets"`
+	PromptSummary  EntityDiffSummary `json:"prompt_summary"`
+	AxisSetSummary EntityDiffSummary `json:"axis_set_summary"`
+	PairsAdded     int               `json:"pairs_added"`
+	PairsRemoved   int               `json:"pairs_removed"`
+	PairsUnchanged int               `json:"pairs_unchanged"`
+}
+
+// Empty reports whether Import would change nothing.
+func (d ContentDiff) Empty() bool {
+	return len(d.Prompts) == 0 && len(d.AxisSets) == 0
+}
+
+// DiffLive loads the rows owned by lib.CreatedByLabel and diffs them against
+// lib. It only reads.
+func DiffLive(ctx context.Context, pool *pgxpool.Pool, lib Library, pairs []Pair) (ContentDiff, error) {
+	live, err := LoadLiveContent(ctx, pool, lib.CreatedByLabel)
+	if err != nil {
+		return ContentDiff{}, err
+	}
+	return DiffContent(lib, pairs, live), nil
+}
+
+// LoadLiveContent reads prompts, axis sets, and pairs. A non-empty label
+// limits the snapshot to rows Import manages for that label; pairs are kept
+// only when both ends are in the snapshot.
+func LoadLiveContent(ctx context.Context, pool *pgxpool.Pool, label string) (LiveContent, error) {
+	var live LiveContent
+
+	rows, err := pool.Query(ctx, `
+		SELECT id, prompt_text, min_rating, is_active, COALESCE(created_by_label, ''), authoring_mode,
+			generator_provider, generator_model, generator_prompt_version, generator_run_id, provenance,
+			translations
+		FROM coordinates_prompts
+		WHERE $1 = '' OR created_by_label = $1
+		ORDER BY created_at, id
+	`, label)
+	if err != nil {
+		return LiveContent{}, err
+	}
+	for rows.Next() {
+		var (
+			p                                     LivePrompt
+			mode                                  string
+			provider, model, promptVersion, runID *string
+			rawProvenance, rawTranslations        []byte
+		)
+		if err := rows.Scan(&p.ID, &p.Text, &p.MinRating, &p.Active, &p.CreatedByLabel, &mode,
+			&provider, &model, &promptVersion, &runID, &rawProvenance, &rawTranslations); err != nil {
+			rows.Close()
+			return LiveContent{}, err
+		}
+		if p.Translations, err = decodeTranslations[PromptTranslation](rawTranslations); err != nil {
+			rows.Close()
+			return LiveContent{}, fmt.Errorf("prompt %s: %w", p.ID, err)
+		}
+		p.Provenance, p.Slug, p.Theme = provenanceFromColumns(mode, provider, model, promptVersion, runID, rawProvenance)
+		live.Prompts = append(live.Prompts, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return LiveContent{}, err
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT id, mode, x_min_label, x_max_label, y_min_label, y_max_label, bucket_labels,
+			min_rating, is_active, COALESCE(created_by_label, ''), authoring_mode,
+			generator_provider, generator_model, generator_prompt_version, generator_run_id, provenance,
+			translations
+		FROM coordinates_axis_sets
+		WHERE $1 = '' OR created_by_label = $1
+		ORDER BY created_at, id
+	`, label)
+	if err != nil {
+		return LiveContent{}, err
+	}
+	for rows.Next() {
+		var (
+			a                                     LiveAxisSet
+			mode                                  string
+			provider, model, promptVersion, runID *string
+			rawProvenance, rawTranslations        []byte
+		)
+		if err := rows.Scan(&a.ID, &a.Mode, &a.XMinLabel, &a.XMaxLabel, &a.YMinLabel, &a.YMaxLabel, &a.Buckets,
+			&a.MinRating, &a.Active, &a.CreatedByLabel, &mode,
+			&provider, &model, &promptVersion, &runID, &rawProvenance, &rawTranslations); err != nil {
+			rows.Close()
+			return LiveContent{}, err
+		}
+		if a.Translations, err = decodeTranslations[AxisTranslation](rawTranslations); err != nil {
+			rows.Close()
+			return LiveContent{}, fmt.Errorf("axis set %s: %w", a.ID, err)
+		}
+		a.Provenance, a.Slug, _ = provenanceFromColumns(mode, provider, model, promptVersion, runID, rawProvenance)
+		live.AxisSets = append(live.AxisSets, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return LiveContent{}, err
+	}
+
+	rows, err = pool.Query(ctx, `
+		SELECT cpas.id, cpas.prompt_id, cpas.axis_set_id, cpas.is_active
+		FROM coordinates_prompt_axis_sets cpas
+		JOIN coordinates_prompts cp ON cp.id = cpas.prompt_id
+		JOIN coordinates_axis_sets cas ON cas.id = cpas.axis_set_id
+		WHERE $1 = '' OR (cp.created_by_label = $1 AND cas.created_by_label = $1)
+		ORDER BY cpas.id
+	`, label)
+	if err != nil {
+		return LiveContent{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var pair LivePair
+		if err := rows.Scan(&pair.ID, &pair.PromptID, &pair.AxisSetID, &pair.Active); err != nil {
+			return LiveContent{}, err
+		}
+		live.Pairs = append(live.Pairs, pair)
+	}
+	if err := rows.Err(); err != nil {
+		return LiveContent{}, err
+	}
+	return live, nil
+}
+
+// DiffContent compares lib against a snapshot of the rows Import manages.
+// Rows are matched by the same deterministic ids Import uses, so the diff
+// lists exactly the writes Import would make. Live rows without a recorded
+// slug are reported by id.
+func DiffContent(lib Library, pairs []Pair, live LiveContent) ContentDiff {
+	diff := ContentDiff{CreatedByLabel: lib.CreatedByLabel}
+
+	livePrompts := make(map[uuid.UUID]LivePrompt, len(live.Prompts))
+	for _, p := range live.Prompts {
+		livePrompts[p.ID] = p
+	}
+	liveAxes := make(map[uuid.UUID]LiveAxisSet, len(live.AxisSets))
+	for _, a := range live.AxisSets {
+		liveAxes[a.ID] = a
+	}
+
+	axisSlugByID := make(map[uuid.UUID]string, len(lib.AxisSets)+len(live.AxisSets))
+	for _, a := range live.AxisSets {
+		axisSlugByID[a.ID] = liveSlug(a.Slug, a.ID)
+	}
+	for _, a := range lib.AxisSets {
+		axisSlugByID[AxisSetUUID(a.Slug)] = a.Slug
+	}
+
+	// Active live axis slugs per prompt id, and the desired ones per slug.
+	liveActivePairs := map[uuid.UUID]map[string]bool{}
+	for _, pair := range live.Pairs {
+		if !pair.Active {
+			continue
+		}
+		if liveActivePairs[pair.PromptID] == nil {
+			liveActivePairs[pair.PromptID] = map[string]bool{}
+		}
+		liveActivePairs[pair.PromptID][axisSlugByID[pair.AxisSetID]] = true
+	}
+	desiredPairs := map[string]map[string]bool{}
+	for _, pair := range pairs {
+		if desiredPairs[pair.PromptSlug] == nil {
+			desiredPairs[pair.PromptSlug] = map[string]bool{}
+		}
+		desiredPairs[pair.PromptSlug][pair.AxisSlug] = true
+	}
+
+	var axisChanges, promptChanges entityChanges
+	desiredAxisIDs := make(map[uuid.UUID]bool, len(lib.AxisSets))
+	for _, axis := range lib.AxisSets {
+		id := AxisSetUUID(axis.Slug)
+		desiredAxisIDs[id] = true
+		existing, ok := liveAxes[id]
+		change := EntityChange{Slug: axis.Slug}
+		switch {
+		case !ok:
+			change.Action = DiffCreate
+			change.Fields = axisFieldChanges(LiveAxisSet{}, axis, true)
+		case !existing.Active:
+			change.Action = DiffReactivate
+			change.Fields = axisFieldChanges(existing, axis, false)
+		default:
+			change.Action = DiffUpdate
+			change.Fields = axisFieldChanges(existing, axis, false)
+		}
+		axisChanges.add(change)
+	}
+	for _, a := range live.AxisSets {
+		if a.Active && !desiredAxisIDs[a.ID] {
+			axisChanges.add(EntityChange{Slug: liveSlug(a.Slug, a.ID), Action: DiffDeactivate})
+		}
+	}
+
+	desiredPromptIDs := make(map[uuid.UUID]bool, len(lib.Prompts))
+	for _, prompt := range lib.Prompts {
+		id := PromptUUID(prompt.Slug)
+		desiredPromptIDs[id] = true
+		existing, ok := livePrompts[id]
+		change := EntityChange{Slug: prompt.Slug}
+		switch {
+		case !ok:
+			change.Action = DiffCreate
+			change.Fields = promptFieldChanges(LivePrompt{}, prompt, true)
+		case !existing.Active:
+			change.Action = DiffReactivate
+			change.Fields = promptFieldChanges(existing, prompt, false)
+		default:
+			change.Action = DiffUpdate
+			change.Fields = promptFieldChanges(existing, prompt, false)
+		}
+		change.AxesAdded, change.AxesRemoved = diffSlugSets(liveActivePairs[id], desiredPairs[prompt.Slug])
+		diff.PairsAdded += len(change.AxesAdded)
+		diff.PairsRemoved += len(change.AxesRemoved)
+		diff.PairsUnchanged += len(desiredPairs[prompt.Slug]) - len(change.AxesAdded)
+		promptChanges.add(change)
+	}
+	for _, p := range live.Prompts {
+		if desiredPromptIDs[p.ID] {
+			continue
+		}
+		_, removed := diffSlugSets(liveActivePairs[p.ID], nil)
+		if !p.Active && len(removed) == 0 {
+			continue
+		}
+		diff.PairsRemoved += len(removed)
+		promptChanges.add(EntityChange{Slug: liveSlug(p.Slug, p.ID), Action: DiffDeactivate, AxesRemoved: removed})
+	}
+
+	diff.Prompts, diff.PromptSummary = promptChanges.sorted()
+	diff.AxisSets, diff.AxisSetSummary = axisChanges.sorted()
+	return diff
+}
+
+func promptFieldChanges(live LivePrompt, p Prompt, creating bool) []FieldChange {
+	var fields fieldChanges
+	fields.compare("text", live.Text, p.Text, creating)
+	fields.compare("min_rating", ratingString(live.MinRating, creating), ratingString(p.MinRating, false), creating)
+	fields.compare("theme", live.Theme, strings.TrimSpace(p.Theme), creating)
+	fields.compare("translations", translationsString(live.Translations), translationsString(p.Translations), creating)
+	fields.compareProvenance(live.Provenance, p.Provenance, creating)
+	return fields
+}
+
+func axisFieldChanges(live LiveAxisSet, a AxisSet, creating bool) []FieldChange {
+	var fields fieldChanges
+	fields.compare("mode", live.Mode, a.EffectiveMode(), creating)
+	fields.compare("x_min_label", live.XMinLabel, a.XMinLabel, creating)
+	fields.compare("x_max_label", live.XMaxLabel, a.XMaxLabel, creating)
+	fields.compare("y_min_label", live.YMinLabel, a.YMinLabel, creating)
+	fields.compare("y_max_label", live.YMaxLabel, a.YMaxLabel, creating)
+	fields.compare("buckets", strings.Join(live.Buckets, " | "), strings.Join(a.Buckets, " | "), creating)
+	fields.compare("min_rating", ratingString(live.MinRating, creating), ratingString(a.MinRating, false), creating)
+	fields.compare("translations", translationsString(live.Translations), translationsString(a.Translations), creating)
+	fields.compareProvenance(live.Provenance, a.Provenance, creating)
+	return fields
+}
+
+type fieldChanges []FieldChange
+
+// compare records field when the values differ. New rows list every
+// non-empty field so reviewers see what is being inserted.
+func (f *fieldChanges) compare(field, from, to string, creating bool) {
+	if creating {
+		if to != "" {
+			*f = append(*f, FieldChange{Field: field, To: to})
+		}
+		return
+	}
+	if from != to {
+		*f = append(*f, FieldChange{Field: field, From: from, To: to})
+	}
+}
+
+func (f *fieldChanges) compareProvenance(live, want Provenance, creating bool) {
+	from := live.EffectiveAuthoringMode()
+	if creating {
+		from = ""
+	}
+	f.compare("authoring_mode", from, want.EffectiveAuthoringMode(), creating)
+	f.compare("generator", generatorString(live.Generator), generatorString(want.Generator), creating)
+	f.compare("author_label", live.AuthorLabel, want.AuthorLabel, creating)
+}
+
+// translationsString renders translations for the diff, empty when there are
+// none so untranslated rows compare equal to an empty column.
+func translationsString[T any](translations map[string]T) string {
+	if len(translations) == 0 {
+		return ""
+	}
+	return translationsJSON(translations)
+}
+
+func generatorString(g *Generator) string {
+	if g == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s %s %s", g.Provider, g.Model, g.PromptVersion, g.RunID)
+}
+
+func ratingString(rating int16, missing bool) string {
+	if missing {
+		return ""
+	}
+	return strconv.Itoa(int(rating))
+}
+
+type entityChanges struct {
+	changes []EntityChange
+	summary EntityDiffSummary
+}
+
+// add files a change under its action, dropping updates that change nothing.
+func (c *entityChanges) add(change EntityChange) {
+	switch change.Action {
+	case DiffCreate:
+		c.summary.Create++
+	case DiffReactivate:
+		c.summary.Reactivate++
+	case DiffUpdate:
+		if len(change.Fields) == 0 && len(change.AxesAdded) == 0 && len(change.AxesRemoved) == 0 {
+			c.summary.Unchanged++
+			return
+		}
+		c.summary.Update++
+	case DiffDeactivate:
+		c.summary.Deactivate++
+	}
+	c.changes = append(c.changes, change)
+}
+
+// sorted orders changes by action, then slug. It never returns nil so the
+// JSON form always has arrays.
+func (c entityChanges) sorted() ([]EntityChange, EntityDiffSummary) {
+	changes := append([]EntityChange{}, c.changes...)
+	slices.SortStableFunc(changes, func(a, b EntityChange) int {
+		if a.Action != b.Action {
+			return diffActionOrder[a.Action] - diffActionOrder[b.Action]
+		}
+		return strings.Compare(a.Slug, b.Slug)
+	})
+	return changes, c.summary
+}
+
+func diffSlugSets(live, desired map[string]bool) ([]string, []string) {
+	var added, removed []string
+	for slug := range desired {
+		if !live[slug] {
+			added = append(added, slug)
+		}
+	}
+	for slug := range live {
+		if !desired[slug] {
+			removed = append(removed, slug)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
+}
+
+var diffActionOrder = map[string]int{DiffCreate: 0, DiffReactivate: 1, DiffUpdate: 2, DiffDeactivate: 3}
+
+func liveSlug(slug string, id uuid.UUID) string {
+	if slug != "" {
+		return slug
+	}
+	return id.String()
+}
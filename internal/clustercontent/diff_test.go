@@ -0,0 +1,125 @@
+package clustercontent
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffContentReportsFieldChanges(t *testing.T) {
+	lib := Library{
+		Version:        "v1",
+		CreatedByLabel: "cluster-studio",
+		AxisSets: []AxisSet{
+			{Slug: "axis-a", XMinLabel: "Low", XMaxLabel: "High", YMinLabel: "Slow", YMaxLabel: "Fast", MinRating: 10},
+			{Slug: "axis-b", XMinLabel: "Cold", XMaxLabel: "Hot", YMinLabel: "Dry", YMaxLabel: "Wet", MinRating: 10},
+		},
+		Prompts: []Prompt{
+			{Slug: "same", Text: "Same", MinRating: 20, AxisSlugs: []string{"axis-a"}},
+			{Slug: "edited", Text: "New text", MinRating: 30, AxisSlugs: []string{"axis-b"}},
+			{Slug: "back", Text: "Back", MinRating: 20, AxisSlugs: []string{"axis-a"}},
+			{Slug: "fresh", Text: "Fresh", MinRating: 10, AxisSlugs: []string{"axis-a"}},
+		},
+	}
+	_, pairs, err := Validate(lib)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	axisA := LiveAxisSet{ID: AxisSetUUID("axis-a"), Slug: "axis-a", Mode: ModePlane, XMinLabel: "Low", XMaxLabel: "High", YMinLabel: "Slow", YMaxLabel: "Fast", MinRating: 10, Active: true}
+	axisB := LiveAxisSet{ID: AxisSetUUID("axis-b"), Slug: "axis-b", Mode: ModePlane, XMinLabel: "Cold", XMaxLabel: "Hot", YMinLabel: "Dry", YMaxLabel: "Wet", MinRating: 10, Active: true}
+	live := LiveContent{
+		AxisSets: []LiveAxisSet{axisA, axisB},
+		Prompts: []LivePrompt{
+			{ID: PromptUUID("same"), Slug: "same", Text: "Same", MinRating: 20, Active: true},
+			{ID: PromptUUID("edited"), Slug: "edited", Text: "Old text", MinRating: 20, Active: true},
+			{ID: PromptUUID("back"), Slug: "back", Text: "Back", MinRating: 20, Active: false},
+			{ID: PromptUUID("gone"), Slug: "gone", Text: "Gone", MinRating: 20, Active: true},
+		},
+		Pairs: []LivePair{
+			{PromptID: PromptUUID("same"), AxisSetID: axisA.ID, Active: true},
+			{PromptID: PromptUUID("edited"), AxisSetID: axisA.ID, Active: true},
+			{PromptID: PromptUUID("gone"), AxisSetID: axisA.ID, Active: true},
+		},
+	}
+
+	diff := DiffContent(lib, pairs, live)
+	if len(diff.AxisSets) != 0 || diff.AxisSetSummary.Unchanged != 2 {
+		t.Fatalf("expected axis sets unchanged, got %+v", diff.AxisSets)
+	}
+	want := EntityDiffSummary{Create: 1, Reactivate: 1, Update: 1, Deactivate: 1, Unchanged: 1}
+	if diff.PromptSummary != want {
+		t.Fatalf("prompt summary = %+v, want %+v", diff.PromptSummary, want)
+	}
+
+	byAction := map[string]EntityChange{}
+	for _, change := range diff.Prompts {
+		byAction[change.Action] = change
+	}
+	if got := []string{diff.Prompts[0].Action, diff.Prompts[3].Action}; !slices.Equal(got, []string{DiffCreate, DiffDeactivate}) {
+		t.Fatalf("expected changes ordered by action, got %v", got)
+	}
+
+	edited := byAction[DiffUpdate]
+	if edited.Slug != "edited" {
+		t.Fatalf("unexpected update %+v", edited)
+	}
+	wantFields := []FieldChange{
+		{Field: "text", From: "Old text", To: "New text"},
+		{Field: "min_rating", From: "20", To: "30"},
+	}
+	if !slices.Equal(edited.Fields, wantFields) {
+		t.Fatalf("fields = %+v, want %+v", edited.Fields, wantFields)
+	}
+	if !slices.Equal(edited.AxesAdded, []string{"axis-b"}) || !slices.Equal(edited.AxesRemoved, []string{"axis-a"}) {
+		t.Fatalf("unexpected pair changes %+v", edited)
+	}
+
+	if back := byAction[DiffReactivate]; back.Slug != "back" || len(back.Fields) != 0 || !slices.Equal(back.AxesAdded, []string{"axis-a"}) {
+		t.Fatalf("unexpected reactivation %+v", back)
+	}
+	if gone := byAction[DiffDeactivate]; gone.Slug != "gone" || !slices.Equal(gone.AxesRemoved, []string{"axis-a"}) {
+		t.Fatalf("unexpected deactivation %+v", gone)
+	}
+	if fresh := byAction[DiffCreate]; fresh.Slug != "fresh" || fresh.Fields[0] != (FieldChange{Field: "text", To: "Fresh"}) {
+		t.Fatalf("unexpected create %+v", fresh)
+	}
+	if diff.PairsAdded != 3 || diff.PairsRemoved != 2 || diff.PairsUnchanged != 1 {
+		t.Fatalf("pairs added=%d removed=%d unchanged=%d", diff.PairsAdded, diff.PairsRemoved, diff.PairsUnchanged)
+	}
+}
+
+func TestDiffContentReadsBackImportedProvenance(t *testing.T) {
+	prompt := Prompt{
+		Slug: "lunch", Text: "Best lunch?", MinRating: 20, AxisSlugs: []string{"axis-a"}, Theme: "food",
+		Provenance: Provenance{
+			AuthoringMode: AuthoringAIGenerated,
+			Generator:     &Generator{Provider: "openai", Model: "m", PromptVersion: GeneratorPromptVersion, RunID: "run-1"},
+			AuthorLabel:   "justin",
+			Extra:         map[string]string{"batch": "fall"},
+		},
+	}
+	provider, model, version, runID := prompt.generatorColumns()
+	prov, slug, theme := provenanceFromColumns(prompt.EffectiveAuthoringMode(), provider, model, version, runID, []byte(prompt.provenanceJSON(prompt.Slug, prompt.Theme)))
+	if slug != "lunch" || theme != "food" {
+		t.Fatalf("slug=%q theme=%q", slug, theme)
+	}
+
+	lib := Library{
+		Version:        "v1",
+		CreatedByLabel: "cluster-studio",
+		AxisSets:       []AxisSet{{Slug: "axis-a", XMinLabel: "Low", XMaxLabel: "High", YMinLabel: "Slow", YMaxLabel: "Fast", MinRating: 10}},
+		Prompts:        []Prompt{prompt},
+	}
+	_, pairs, err := Validate(lib)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	live := LiveContent{
+		AxisSets: []LiveAxisSet{{ID: AxisSetUUID("axis-a"), Slug: "axis-a", Mode: ModePlane, XMinLabel: "Low", XMaxLabel: "High", YMinLabel: "Slow", YMaxLabel: "Fast", MinRating: 10, Active: true}},
+		Prompts:  []LivePrompt{{ID: PromptUUID("lunch"), Slug: slug, Theme: theme, Text: prompt.Text, MinRating: 20, Active: true, Provenance: prov}},
+		Pairs:    []LivePair{{PromptID: PromptUUID("lunch"), AxisSetID: AxisSetUUID("axis-a"), Active: true}},
+	}
+	if diff := DiffContent(lib, pairs, live); !diff.Empty() {
+		t.Fatalf("expected a freshly imported library to diff clean, got %+v", diff)
+	}
+}
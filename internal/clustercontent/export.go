@@ -0,0 +1,202 @@
+package clustercontent
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ExportReport summarizes what ExportStudio did with the live rows.
+type ExportReport struct {
+	AxisSets        int
+	SkippedAxisSets int
+	ReadyPrompts    int
+	DraftPrompts    int
+	ArchivedPrompts int
+	// GeneratedSlugs counts rows whose provenance had no usable slug.
+	GeneratedSlugs int
+}
+
+// ExportStudio turns a database snapshot back into a studio source that
+// build and bootstrap-studio accept.
+//
+// Slugs come from the provenance Import records and are generated from the
+// text otherwise. Active prompts with active pairs are ready, active prompts
+// without one are drafts, and inactive prompts are archived. Inactive axis
+// sets are kept only when an exported prompt still points at them. Rows that
+// Import did not create keep their database id in provenance as db_id, since
+// re-importing them creates new rows under the studio's label. Locales lists
+// every locale the exported rows carry translations for.
+func ExportStudio(live LiveContent, version string, label string) (StudioSource, ExportReport) {
+	var report ExportReport
+	src := StudioSource{
+		Version:        version,
+		CreatedByLabel: label,
+		AxisSets:       []AxisSet{},
+		Prompts:        []StudioPrompt{},
+	}
+
+	pairsByPrompt := map[uuid.UUID][]LivePair{}
+	referencedAxes := map[uuid.UUID]bool{}
+	for _, pair := range live.Pairs {
+		pairsByPrompt[pair.PromptID] = append(pairsByPrompt[pair.PromptID], pair)
+		if pair.Active {
+			referencedAxes[pair.AxisSetID] = true
+		}
+	}
+
+	axisSlugs := map[string]bool{}
+	axisSlugByID := map[uuid.UUID]string{}
+	for _, a := range live.AxisSets {
+		if !a.Active && !referencedAxes[a.ID] {
+			report.SkippedAxisSets++
+			continue
+		}
+		slug, generated := exportSlug(a.Slug, axisSlugSource(a), "axis", axisSlugs)
+		if generated {
+			report.GeneratedSlugs++
+		}
+		axisSlugByID[a.ID] = slug
+
+		axis := AxisSet{
+			Slug:         slug,
+			XMinLabel:    a.XMinLabel,
+			XMaxLabel:    a.XMaxLabel,
+			YMinLabel:    a.YMinLabel,
+			YMaxLabel:    a.YMaxLabel,
+			MinRating:    a.MinRating,
+			Translations: a.Translations,
+			Provenance:   exportProvenance(a.Provenance, a.ID, AxisSetUUID(slug)),
+		}
+		if a.Mode != ModePlane {
+			axis.Mode = a.Mode
+		}
+		if len(a.Buckets) > 0 {
+			axis.Buckets = slices.Clone(a.Buckets)
+		}
+		src.AxisSets = append(src.AxisSets, axis)
+	}
+	report.AxisSets = len(src.AxisSets)
+
+	promptSlugs := map[string]bool{}
+	for _, p := range live.Prompts {
+		slug, generated := exportSlug(p.Slug, p.Text, "prompt", promptSlugs)
+		if generated {
+			report.GeneratedSlugs++
+		}
+
+		var active, inactive []string
+		for _, pair := range pairsByPrompt[p.ID] {
+			axisSlug, ok := axisSlugByID[pair.AxisSetID]
+			if !ok {
+				continue
+			}
+			if pair.Active {
+				active = append(active, axisSlug)
+			} else {
+				inactive = append(inactive, axisSlug)
+			}
+		}
+
+		prompt := StudioPrompt{
+			Slug:         slug,
+			Text:         p.Text,
+			MinRating:    p.MinRating,
+			AxisSlugs:    active,
+			Theme:        p.Theme,
+			Status:       "ready",
+			Translations: p.Translations,
+			Provenance:   exportProvenance(p.Provenance, p.ID, PromptUUID(slug)),
+		}
+		switch {
+		case !p.Active:
+			prompt.Status = "archived"
+			prompt.AxisSlugs = append(active, inactive...)
+			report.ArchivedPrompts++
+		case len(active) == 0:
+			prompt.Status = "draft"
+			prompt.AxisSlugs = inactive
+			prompt.Notes = "Exported without an active axis set."
+			report.DraftPrompts++
+		default:
+			report.ReadyPrompts++
+		}
+		if prompt.AxisSlugs == nil {
+			prompt.AxisSlugs = []string{}
+		}
+		slices.Sort(prompt.AxisSlugs)
+		prompt.AxisSlugs = slices.Compact(prompt.AxisSlugs)
+		src.Prompts = append(src.Prompts, prompt)
+	}
+	src.Locales = exportLocales(src)
+
+	return src, report
+}
+
+// exportLocales collects the translation locales used across src, sorted.
+func exportLocales(src StudioSource) []string {
+	seen := map[string]bool{}
+	for _, a := range src.AxisSets {
+		for locale := range a.Translations {
+			seen[locale] = true
+		}
+	}
+	for _, p := range src.Prompts {
+		for locale := range p.Translations {
+			seen[locale] = true
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+	return sortedKeys(seen)
+}
+
+// exportSlug keeps a recorded slug when it is valid and unused, otherwise it
+// derives one from fallback. Collisions get a numeric suffix.
+func exportSlug(recorded string, fallback string, kind string, used map[string]bool) (string, bool) {
+	slug := strings.TrimSpace(recorded)
+	generated := false
+	if !isSlug(slug) || used[slug] {
+		generated = true
+		slug = GeneratedSlug(fallback)
+		if slug == "" {
+			slug = kind
+		}
+	}
+	base := slug
+	for n := 2; used[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	used[slug] = true
+	return slug, generated
+}
+
+func axisSlugSource(a LiveAxisSet) string {
+	switch a.Mode {
+	case ModeBuckets:
+		return strings.Join(a.Buckets, " ")
+	case ModeSpectrum:
+		return a.XMinLabel + " " + a.XMaxLabel
+	default:
+		return a.XMinLabel + " " + a.XMaxLabel + " " + a.YMinLabel + " " + a.YMaxLabel
+	}
+}
+
+// exportProvenance drops the implicit "imported" mode so exported files look
+// like hand-kept ones, and records the row id when Import would not reuse it.
+func exportProvenance(p Provenance, id uuid.UUID, importID uuid.UUID) Provenance {
+	p = p.clone()
+	if p.AuthoringMode == AuthoringImported {
+		p.AuthoringMode = ""
+	}
+	if id != importID {
+		if p.Extra == nil {
+			p.Extra = map[string]string{}
+		}
+		p.Extra["db_id"] = id.String()
+	}
+	return p
+}
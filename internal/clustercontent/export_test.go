@@ -0,0 +1,87 @@
+package clustercontent
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestExportStudioRoundTripsImportedRows(t *testing.T) {
+	axis := LiveAxisSet{ID: AxisSetUUID("axis-a"), Slug: "axis-a", Mode: ModePlane, XMinLabel: "Low", XMaxLabel: "High", YMinLabel: "Slow", YMaxLabel: "Fast", MinRating: 10, Active: true, Provenance: Provenance{AuthoringMode: AuthoringImported}}
+	live := LiveContent{
+		AxisSets: []LiveAxisSet{axis},
+		Prompts: []LivePrompt{
+			{ID: PromptUUID("lunch"), Slug: "lunch", Text: "Best lunch?", Theme: "food", MinRating: 20, Active: true, Provenance: Provenance{AuthoringMode: AuthoringImported}},
+			{ID: PromptUUID("old"), Slug: "old", Text: "Retired", MinRating: 20, Active: false, Provenance: Provenance{AuthoringMode: AuthoringImported}},
+		},
+		Pairs: []LivePair{
+			{PromptID: PromptUUID("lunch"), AxisSetID: axis.ID, Active: true},
+			{PromptID: PromptUUID("old"), AxisSetID: axis.ID, Active: false},
+		},
+	}
+
+	src, report := ExportStudio(live, "v1", "cluster-studio")
+	if report.ReadyPrompts != 1 || report.ArchivedPrompts != 1 || report.GeneratedSlugs != 0 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if src.Prompts[1].Status != "archived" || !slices.Equal(src.Prompts[1].AxisSlugs, []string{"axis-a"}) {
+		t.Fatalf("expected inactive prompt archived with its axis, got %+v", src.Prompts[1])
+	}
+	if src.Prompts[0].AuthoringMode != "" || src.AxisSets[0].Mode != "" {
+		t.Fatalf("expected defaults left implicit, got %+v / %+v", src.Prompts[0], src.AxisSets[0])
+	}
+
+	diag, err := ValidateStudio(src)
+	if err != nil {
+		t.Fatalf("exported studio should validate: %v", err)
+	}
+	if diag.Summary.ReadyPrompts != 1 {
+		t.Fatalf("expected one ready prompt, got %+v", diag.Summary)
+	}
+
+	lib := src.ToLibrary()
+	_, pairs, err := Validate(lib)
+	if err != nil {
+		t.Fatalf("validate library: %v", err)
+	}
+	diff := DiffContent(lib, pairs, live)
+	if len(diff.AxisSets) != 0 || len(diff.Prompts) != 0 {
+		t.Fatalf("re-importing an export should change nothing, got %+v", diff)
+	}
+}
+
+func TestExportStudioGeneratesSlugsForUserRows(t *testing.T) {
+	axisID := uuid.New()
+	promptID := uuid.New()
+	orphanID := uuid.New()
+	live := LiveContent{
+		AxisSets: []LiveAxisSet{
+			{ID: axisID, Mode: ModeBuckets, Buckets: []string{"Tea", "Coffee"}, MinRating: 10, Active: true, Provenance: Provenance{AuthoringMode: AuthoringManual}},
+			{ID: uuid.New(), Mode: ModePlane, XMinLabel: "A", XMaxLabel: "B", YMinLabel: "C", YMaxLabel: "D", MinRating: 10, Active: false},
+		},
+		Prompts: []LivePrompt{
+			{ID: promptID, Text: "Morning drink?", MinRating: 10, Active: true, Provenance: Provenance{AuthoringMode: AuthoringManual}},
+			{ID: orphanID, Text: "Morning drink?", MinRating: 10, Active: true, Provenance: Provenance{AuthoringMode: AuthoringManual}},
+		},
+		Pairs: []LivePair{{PromptID: promptID, AxisSetID: axisID, Active: true}},
+	}
+
+	src, report := ExportStudio(live, "v1", "cluster-studio")
+	if report.SkippedAxisSets != 1 || report.AxisSets != 1 || report.GeneratedSlugs != 3 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	axis := src.AxisSets[0]
+	if axis.Slug != "tea-coffee" || axis.Mode != ModeBuckets || axis.Extra["db_id"] != axisID.String() {
+		t.Fatalf("unexpected axis export %+v", axis)
+	}
+	if src.Prompts[0].Slug != "morning-drink" || src.Prompts[1].Slug != "morning-drink-2" {
+		t.Fatalf("expected generated, de-duplicated slugs, got %q and %q", src.Prompts[0].Slug, src.Prompts[1].Slug)
+	}
+	if src.Prompts[1].Status != "draft" || len(src.Prompts[1].AxisSlugs) != 0 {
+		t.Fatalf("expected prompt without active pairs to be a draft, got %+v", src.Prompts[1])
+	}
+	if _, err := ValidateStudio(src); err != nil {
+		t.Fatalf("exported studio should validate: %v", err)
+	}
+}
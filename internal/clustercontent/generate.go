@@ -0,0 +1,329 @@
+package clustercontent
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+const (
+	// GeneratorPromptVersion identifies the instructions sent to the model.
+	// Bump it whenever buildGeneratePrompts changes meaningfully.
+	GeneratorPromptVersion = "cluster-prompts-v1"
+
+	defaultGenerateTimeout = 60 * time.Second
+	maxGeneratedTextLen    = 160
+)
+
+var slugUnsafePattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// GeneratorConfig points at an OpenAI-compatible chat completions endpoint.
+type GeneratorConfig struct {
+	Provider   string
+	Endpoint   string
+	Model      string
+	APIKey     string
+	HTTPClient *http.Client
+	Timeout    time.Duration
+}
+
+// GenerateRequest describes one batch of draft prompts.
+type GenerateRequest struct {
+	Theme     string
+	MinRating int16
+	AxisSet   AxisSet
+	Count     int
+	// Avoid lists existing prompt texts the model should not repeat.
+	Avoid []string
+	RunID string
+}
+
+type generateChatRequest struct {
+	Model          string         `json:"model"`
+	Messages       []generateChat `json:"messages"`
+	Temperature    float64        `json:"temperature"`
+	ResponseFormat map[string]any `json:"response_format,omitempty"`
+}
+
+type generateChat struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type generateChatResponse struct {
+	Choices []struct {
+		Message generateChat `json:"message"`
+	} `json:"choices"`
+}
+
+type generatedPromptPayload struct {
+	Prompts []struct {
+		Slug string `json:"slug"`
+		Text string `json:"text"`
+	} `json:"prompts"`
+}
+
+// NewGeneratorRunID returns a sortable, unique id for one generate run.
+func NewGeneratorRunID(now time.Time) string {
+	var suffix [3]byte
+	_, _ = rand.Read(suffix[:])
+	return fmt.Sprintf("gen-%s-%s", now.UTC().Format("20060102T150405Z"), hex.EncodeToString(suffix[:]))
+}
+
+// GeneratePrompts asks the configured endpoint for draft prompts and returns
+// them as draft studio prompts carrying generator provenance. Slugs are
+// normalized but not yet checked against the studio file; see
+// AppendGeneratedPrompts.
+func GeneratePrompts(ctx context.Context, cfg GeneratorConfig, req GenerateRequest) ([]StudioPrompt, error) {
+	if strings.TrimSpace(cfg.Endpoint) == "" {
+		return nil, errors.New("generator endpoint is required")
+	}
+	if strings.TrimSpace(cfg.Model) == "" {
+		return nil, errors.New("generator model is required")
+	}
+	if req.Count <= 0 {
+		return nil, errors.New("count must be positive")
+	}
+	if !contentrating.IsValid(req.MinRating) {
+		return nil, fmt.Errorf("invalid min_rating %d", req.MinRating)
+	}
+	if strings.TrimSpace(req.AxisSet.Slug) == "" {
+		return nil, errors.New("axis set is required")
+	}
+
+	systemPrompt, userPrompt := buildGeneratePrompts(req)
+	payload, err := json.Marshal(generateChatRequest{
+		Model: cfg.Model,
+		Messages: []generateChat{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: userPrompt},
+		},
+		Temperature:    0.9,
+		ResponseFormat: generateResponseFormat(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultGenerateTimeout
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(reqCtx, http.MethodPost, cfg.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if key := strings.TrimSpace(cfg.APIKey); key != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("%s status %d: %s", cfg.Provider, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var completion generateChatResponse
+	if err := json.Unmarshal(body, &completion); err != nil {
+		return nil, fmt.Errorf("decode completion: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("%s returned no choices", cfg.Provider)
+	}
+
+	var parsed generatedPromptPayload
+	if err := json.Unmarshal([]byte(stripCodeFence(completion.Choices[0].Message.Content)), &parsed); err != nil {
+		return nil, fmt.Errorf("decode generated prompts: %w", err)
+	}
+
+	generator := Generator{
+		Provider:      cfg.Provider,
+		Model:         cfg.Model,
+		PromptVersion: GeneratorPromptVersion,
+		RunID:         req.RunID,
+	}
+	prompts := make([]StudioPrompt, 0, len(parsed.Prompts))
+	for _, item := range parsed.Prompts {
+		text := strings.Join(strings.Fields(item.Text), " ")
+		if text == "" || len(text) > maxGeneratedTextLen {
+			continue
+		}
+		slug := GeneratedSlug(item.Slug)
+		if slug == "" {
+			slug = GeneratedSlug(text)
+		}
+		g := generator
+		prompts = append(prompts, StudioPrompt{
+			Slug:       slug,
+			Text:       text,
+			MinRating:  req.MinRating,
+			AxisSlugs:  []string{req.AxisSet.Slug},
+			Theme:      strings.TrimSpace(req.Theme),
+			Status:     "draft",
+			Provenance: Provenance{AuthoringMode: AuthoringAIGenerated, Generator: &g},
+		})
+	}
+	if len(prompts) == 0 {
+		return nil, fmt.Errorf("%s returned no usable prompts", cfg.Provider)
+	}
+	return prompts, nil
+}
+
+// AppendGeneratedPrompts adds generated drafts to src, renaming slugs that
+// collide and skipping text the studio already has. It returns the prompts
+// that were added and the texts that were skipped.
+func AppendGeneratedPrompts(src *StudioSource, prompts []StudioPrompt) ([]StudioPrompt, []string) {
+	slugs := make(map[string]bool, len(src.Prompts))
+	texts := make(map[string]bool, len(src.Prompts))
+	for _, p := range src.Prompts {
+		slugs[p.Slug] = true
+		texts[normalizeDuplicateTextKey(p.Text)] = true
+	}
+
+	var (
+		added   []StudioPrompt
+		skipped []string
+	)
+	for _, p := range prompts {
+		key := normalizeDuplicateTextKey(p.Text)
+		if texts[key] {
+			skipped = append(skipped, p.Text)
+			continue
+		}
+		base := p.Slug
+		for n := 2; slugs[p.Slug]; n++ {
+			p.Slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		slugs[p.Slug] = true
+		texts[key] = true
+		src.Prompts = append(src.Prompts, p)
+		added = append(added, p)
+	}
+	return added, skipped
+}
+
+// GeneratedSlug turns free text into a short kebab-case slug.
+func GeneratedSlug(value string) string {
+	slug := strings.Trim(slugUnsafePattern.ReplaceAllString(strings.ToLower(value), "-"), "-")
+	parts := strings.Split(slug, "-")
+	if len(parts) > 6 {
+		parts = parts[:6]
+	}
+	return strings.Join(parts, "-")
+}
+
+func buildGeneratePrompts(req GenerateRequest) (string, string) {
+	systemPrompt := strings.Join([]string{
+		"You write prompts for Cluster, a party game where every player places an answer on a shared chart and the group tries to land near each other.",
+		"Each prompt is a short question or situation that people answer from their own taste or experience.",
+		"Good prompts split a room: reasonable people land in different places on the chart.",
+		"Avoid trivia with a single correct answer, yes/no questions, and anything that needs specialist knowledge.",
+		"Keep each prompt under 120 characters.",
+		"Audience: " + generateAudiencePolicy(req.MinRating),
+		"Return strict JSON only: an object with a prompts array of {slug, text}. Slugs are 2-5 word kebab-case summaries.",
+		"Do not add commentary, markdown, or code fences.",
+	}, " ")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Write %d new prompts.\n", req.Count)
+	if theme := strings.TrimSpace(req.Theme); theme != "" {
+		fmt.Fprintf(&b, "Theme: %s\n", theme)
+	}
+	axis := req.AxisSet
+	switch axis.EffectiveMode() {
+	case ModeBuckets:
+		fmt.Fprintf(&b, "Players answer by picking one of: %s\n", strings.Join(axis.Buckets, ", "))
+	case ModeSpectrum:
+		fmt.Fprintf(&b, "Players answer on one line from %q to %q.\n", axis.XMinLabel, axis.XMaxLabel)
+	default:
+		fmt.Fprintf(&b, "Players answer on a chart. Horizontal: %q to %q. Vertical: %q to %q.\n", axis.XMinLabel, axis.XMaxLabel, axis.YMinLabel, axis.YMaxLabel)
+	}
+	if len(req.Avoid) > 0 {
+		b.WriteString("Do not repeat or lightly reword these existing prompts:\n")
+		for _, text := range req.Avoid {
+			fmt.Fprintf(&b, "- %s\n", text)
+		}
+	}
+	return systemPrompt, b.String()
+}
+
+func generateAudiencePolicy(rating int16) string {
+	switch rating {
+	case contentrating.Kids:
+		return "Mild. Family-friendly, no mature themes."
+	case contentrating.Adults:
+		return "Adults. Edgier topics are fine, but nothing hateful, harassing, or unsafe."
+	default:
+		return "Polite. Workplace-safe and fine for mixed company."
+	}
+}
+
+func generateResponseFormat() map[string]any {
+	return map[string]any{
+		"type": "json_schema",
+		"json_schema": map[string]any{
+			"name":   "cluster_prompts",
+			"strict": true,
+			"schema": map[string]any{
+				"type":                 "object",
+				"additionalProperties": false,
+				"properties": map[string]any{
+					"prompts": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type":                 "object",
+							"additionalProperties": false,
+							"properties": map[string]any{
+								"slug": map[string]any{"type": "string"},
+								"text": map[string]any{"type": "string"},
+							},
+							"required": []string{"slug", "text"},
+						},
+					},
+				},
+				"required": []string{"prompts"},
+			},
+		},
+	}
+}
+
+// stripCodeFence tolerates endpoints that ignore response_format and wrap
+// their JSON in a markdown fence.
+func stripCodeFence(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "```") {
+		return content
+	}
+	content = strings.TrimPrefix(content, "```")
+	if newline := strings.IndexByte(content, '\n'); newline >= 0 {
+		content = content[newline+1:]
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(content), "```"))
+}
@@ -0,0 +1,120 @@
+package clustercontent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGeneratePromptsAgainstStubServer(t *testing.T) {
+	var seen generateChatRequest
+	var seenAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenAuth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&seen); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		content := "```json\n" + `{"prompts":[{"slug":"Ideal Standup Length","text":"How long should a  standup last?"},{"slug":"","text":"Best day for a team lunch?"},{"slug":"blank","text":"  "}]}` + "\n```"
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": content}}},
+		})
+	}))
+	defer server.Close()
+
+	prompts, err := GeneratePrompts(context.Background(), GeneratorConfig{
+		Provider: "stub",
+		Endpoint: server.URL,
+		Model:    "stub-model",
+		APIKey:   "secret",
+	}, GenerateRequest{
+		Theme:     "work",
+		MinRating: 20,
+		AxisSet:   AxisSet{Slug: "axis-a", XMinLabel: "Short", XMaxLabel: "Long", YMinLabel: "Casual", YMaxLabel: "Formal"},
+		Count:     3,
+		Avoid:     []string{"Existing prompt"},
+		RunID:     "run-1",
+	})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	if seenAuth != "Bearer secret" || seen.Model != "stub-model" || len(seen.Messages) != 2 {
+		t.Fatalf("unexpected request: auth=%q %+v", seenAuth, seen)
+	}
+	if !strings.Contains(seen.Messages[1].Content, "Theme: work") || !strings.Contains(seen.Messages[1].Content, "Existing prompt") {
+		t.Fatalf("expected theme and avoid list in user prompt: %q", seen.Messages[1].Content)
+	}
+
+	if len(prompts) != 2 {
+		t.Fatalf("expected blank text to be dropped, got %+v", prompts)
+	}
+	first := prompts[0]
+	if first.Slug != "ideal-standup-length" || first.Text != "How long should a standup last?" {
+		t.Fatalf("unexpected normalized prompt: %+v", first)
+	}
+	if first.Status != "draft" || first.AuthoringMode != AuthoringAIGenerated || first.MinRating != 20 || first.AxisSlugs[0] != "axis-a" || first.Theme != "work" {
+		t.Fatalf("unexpected draft fields: %+v", first)
+	}
+	if g := first.Generator; g == nil || g.Provider != "stub" || g.Model != "stub-model" || g.PromptVersion != GeneratorPromptVersion || g.RunID != "run-1" {
+		t.Fatalf("unexpected provenance: %+v", first.Generator)
+	}
+	if prompts[1].Slug != "best-day-for-a-team-lunch" {
+		t.Fatalf("expected slug derived from text, got %q", prompts[1].Slug)
+	}
+}
+
+func TestGeneratePromptsReportsProviderErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := GeneratePrompts(context.Background(), GeneratorConfig{Provider: "stub", Endpoint: server.URL, Model: "m"}, GenerateRequest{
+		MinRating: 20,
+		AxisSet:   AxisSet{Slug: "axis-a"},
+		Count:     1,
+	})
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Fatalf("expected status error, got %v", err)
+	}
+}
+
+func TestAppendGeneratedPromptsDedupes(t *testing.T) {
+	src := StudioSource{Prompts: []StudioPrompt{{Slug: "lunch", Text: "Best day for lunch?"}}}
+	added, skipped := AppendGeneratedPrompts(&src, []StudioPrompt{
+		{Slug: "lunch", Text: "Best time for lunch?"},
+		{Slug: "other", Text: " best day for  lunch? "},
+		{Slug: "lunch", Text: "Best lunch spot?"},
+	})
+	if len(skipped) != 1 {
+		t.Fatalf("expected one duplicate skipped, got %v", skipped)
+	}
+	if len(added) != 2 || added[0].Slug != "lunch-2" || added[1].Slug != "lunch-3" {
+		t.Fatalf("expected colliding slugs to be renamed, got %+v", added)
+	}
+	if len(src.Prompts) != 3 {
+		t.Fatalf("expected prompts appended to source, got %d", len(src.Prompts))
+	}
+}
+
+func TestUpsertPromptKeepsProvenance(t *testing.T) {
+	gen := &Generator{Provider: "stub", Model: "m", PromptVersion: GeneratorPromptVersion, RunID: "run-1"}
+	src := StudioSource{Prompts: []StudioPrompt{{Slug: "p1", Text: "Draft", MinRating: 20, AxisSlugs: []string{"axis-a"}, Status: "draft", Provenance: Provenance{AuthoringMode: AuthoringAIGenerated, Generator: gen}}}}
+
+	if err := src.UpsertPrompt("p1", StudioPrompt{Slug: "p1", Text: "Draft", MinRating: 20, AxisSlugs: []string{"axis-a"}, Status: "ready"}); err != nil {
+		t.Fatalf("promote: %v", err)
+	}
+	if src.Prompts[0].AuthoringMode != AuthoringAIGenerated || src.Prompts[0].Generator != gen {
+		t.Fatalf("expected provenance kept on status change: %+v", src.Prompts[0])
+	}
+
+	if err := src.UpsertPrompt("p1", StudioPrompt{Slug: "p1", Text: "Reworded", MinRating: 20, AxisSlugs: []string{"axis-a"}, Status: "ready"}); err != nil {
+		t.Fatalf("reword: %v", err)
+	}
+	if src.Prompts[0].AuthoringMode != AuthoringAIAssisted || src.Prompts[0].Generator != gen {
+		t.Fatalf("expected rewording to mark the prompt AI-assisted: %+v", src.Prompts[0])
+	}
+}
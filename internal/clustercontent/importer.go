@@ -2,7 +2,6 @@ package clustercontent
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -63,63 +62,85 @@ func Analyze(ctx context.Context, pool *pgxpool.Pool, lib Library, pairs []Pair)
 	}, nil
 }
 
-func Import(ctx context.Context, pool *pgxpool.Pool, lib Library, pairs []Pair) error {
+// Import upserts lib, deactivates the label's stale rows, and records the
+// result as a release that Rollback can return to.
+func Import(ctx context.Context, pool *pgxpool.Pool, lib Library, pairs []Pair) (Release, error) {
 	tx, err := pool.Begin(ctx)
 	if err != nil {
-		return err
+		return Release{}, err
 	}
 	defer tx.Rollback(ctx)
 
 	promptIDs, axisIDs, pairIDs := desiredIDs(lib, pairs)
 
+	parentID, err := latestReleaseID(ctx, tx, lib.CreatedByLabel)
+	if err != nil {
+		return Release{}, err
+	}
+	before, err := fetchContentState(ctx, tx, lib.CreatedByLabel)
+	if err != nil {
+		return Release{}, err
+	}
+
 	for i, prompt := range lib.Prompts {
 		id := promptIDs[i]
-		provenance, _ := json.Marshal(map[string]string{
-			"source": "cluster-content-import",
-			"slug":   prompt.Slug,
-		})
+		genProvider, genModel, genPromptVersion, genRunID := prompt.generatorColumns()
 		if _, err := tx.Exec(ctx, `
 			INSERT INTO coordinates_prompts (
 				id, prompt_text, created_by_kind, created_by_label, authoring_mode,
-				provenance, min_rating, is_active
-			) VALUES ($1, $2, 'developer', $3, 'imported', $4::jsonb, $5, TRUE)
+				generator_provider, generator_model, generator_prompt_version, generator_run_id,
+				provenance, translations, min_rating, is_active
+			) VALUES ($1, $2, 'developer', $3, $6, $7, $8, $9, $10, $4::jsonb, $11::jsonb, $5, TRUE)
 			ON CONFLICT (id) DO UPDATE SET
 				prompt_text = EXCLUDED.prompt_text,
 				created_by_kind = EXCLUDED.created_by_kind,
 				created_by_label = EXCLUDED.created_by_label,
 				authoring_mode = EXCLUDED.authoring_mode,
+				generator_provider = EXCLUDED.generator_provider,
+				generator_model = EXCLUDED.generator_model,
+				generator_prompt_version = EXCLUDED.generator_prompt_version,
+				generator_run_id = EXCLUDED.generator_run_id,
 				provenance = EXCLUDED.provenance,
+				translations = EXCLUDED.translations,
 				min_rating = EXCLUDED.min_rating,
 				is_active = TRUE
-		`, id, prompt.Text, lib.CreatedByLabel, string(provenance), prompt.MinRating); err != nil {
-			return fmt.Errorf("upsert prompt %s: %w", prompt.Slug, err)
+		`, id, prompt.Text, lib.CreatedByLabel, prompt.provenanceJSON(prompt.Slug, prompt.Theme), prompt.MinRating,
+			prompt.EffectiveAuthoringMode(), genProvider, genModel, genPromptVersion, genRunID, translationsJSON(prompt.Translations)); err != nil {
+			return Release{}, fmt.Errorf("upsert prompt %s: %w", prompt.Slug, err)
 		}
 	}
 
 	for i, axis := range lib.AxisSets {
 		id := axisIDs[i]
-		provenance, _ := json.Marshal(map[string]string{
-			"source": "cluster-content-import",
-			"slug":   axis.Slug,
-		})
+		genProvider, genModel, genPromptVersion, genRunID := axis.generatorColumns()
 		if _, err := tx.Exec(ctx, `
 			INSERT INTO coordinates_axis_sets (
-				id, x_min_label, x_max_label, y_min_label, y_max_label,
-				created_by_kind, created_by_label, authoring_mode, provenance, min_rating, is_active
-			) VALUES ($1, $2, $3, $4, $5, 'developer', $6, 'imported', $7::jsonb, $8, TRUE)
+				id, x_min_label, x_max_label, y_min_label, y_max_label, mode, bucket_labels,
+				created_by_kind, created_by_label, authoring_mode,
+				generator_provider, generator_model, generator_prompt_version, generator_run_id,
+				provenance, translations, min_rating, is_active
+			) VALUES ($1, $2, $3, $4, $5, $9, $10, 'developer', $6, $11, $12, $13, $14, $15, $7::jsonb, $16::jsonb, $8, TRUE)
 			ON CONFLICT (id) DO UPDATE SET
 				x_min_label = EXCLUDED.x_min_label,
 				x_max_label = EXCLUDED.x_max_label,
 				y_min_label = EXCLUDED.y_min_label,
 				y_max_label = EXCLUDED.y_max_label,
+				mode = EXCLUDED.mode,
+				bucket_labels = EXCLUDED.bucket_labels,
 				created_by_kind = EXCLUDED.created_by_kind,
 				created_by_label = EXCLUDED.created_by_label,
 				authoring_mode = EXCLUDED.authoring_mode,
+				generator_provider = EXCLUDED.generator_provider,
+				generator_model = EXCLUDED.generator_model,
+				generator_prompt_version = EXCLUDED.generator_prompt_version,
+				generator_run_id = EXCLUDED.generator_run_id,
 				provenance = EXCLUDED.provenance,
+				translations = EXCLUDED.translations,
 				min_rating = EXCLUDED.min_rating,
 				is_active = TRUE
-		`, id, axis.XMinLabel, axis.XMaxLabel, axis.YMinLabel, axis.YMaxLabel, lib.CreatedByLabel, string(provenance), axis.MinRating); err != nil {
-			return fmt.Errorf("upsert axis %s: %w", axis.Slug, err)
+		`, id, axis.XMinLabel, axis.XMaxLabel, axis.YMinLabel, axis.YMaxLabel, lib.CreatedByLabel, axis.provenanceJSON(axis.Slug, ""), axis.MinRating, axis.EffectiveMode(), bucketLabels(axis),
+			axis.EffectiveAuthoringMode(), genProvider, genModel, genPromptVersion, genRunID, translationsJSON(axis.Translations)); err != nil {
+			return Release{}, fmt.Errorf("upsert axis %s: %w", axis.Slug, err)
 		}
 	}
 
@@ -136,21 +157,37 @@ func Import(ctx context.Context, pool *pgxpool.Pool, lib Library, pairs []Pair)
 				axis_set_id = EXCLUDED.axis_set_id,
 				is_active = TRUE
 		`, pairID, promptID, axisID); err != nil {
-			return fmt.Errorf("upsert pair %s|%s: %w", pair.PromptSlug, pair.AxisSlug, err)
+			return Release{}, fmt.Errorf("upsert pair %s|%s: %w", pair.PromptSlug, pair.AxisSlug, err)
 		}
 	}
 
 	if err := deactivateStalePrompts(ctx, tx, lib.CreatedByLabel, promptIDs); err != nil {
-		return err
+		return Release{}, err
 	}
 	if err := deactivateStaleAxisSets(ctx, tx, lib.CreatedByLabel, axisIDs); err != nil {
-		return err
+		return Release{}, err
 	}
 	if err := deactivateStalePairs(ctx, tx, lib.CreatedByLabel, pairIDs); err != nil {
-		return err
+		return Release{}, err
 	}
 
-	return tx.Commit(ctx)
+	after, err := fetchContentState(ctx, tx, lib.CreatedByLabel)
+	if err != nil {
+		return Release{}, err
+	}
+	rel := Release{
+		ID:             uuid.New(),
+		CreatedByLabel: lib.CreatedByLabel,
+		Kind:           ReleaseImport,
+		LibraryVersion: lib.Version,
+		ContentHash:    ContentHash(lib),
+		ParentID:       parentID,
+	}
+	if err := recordRelease(ctx, tx, &rel, before, after); err != nil {
+		return Release{}, err
+	}
+
+	return rel, tx.Commit(ctx)
 }
 
 func deactivateStalePrompts(ctx context.Context, tx pgx.Tx, label string, keepIDs []uuid.UUID) error {
@@ -226,8 +263,8 @@ func desiredIDs(lib Library, pairs []Pair) ([]uuid.UUID, []uuid.UUID, []uuid.UUI
 	return promptIDs, axisIDs, pairIDs
 }
 
-func fetchPromptState(ctx context.Context, pool *pgxpool.Pool, label string) (map[uuid.UUID]bool, error) {
-	rows, err := pool.Query(ctx, `
+func fetchPromptState(ctx context.Context, q querier, label string) (map[uuid.UUID]bool, error) {
+	rows, err := q.Query(ctx, `
 		SELECT id, is_active
 		FROM coordinates_prompts
 		WHERE created_by_label = $1
@@ -239,8 +276,8 @@ func fetchPromptState(ctx context.Context, pool *pgxpool.Pool, label string) (ma
 	return collectState(rows)
 }
 
-func fetchAxisState(ctx context.Context, pool *pgxpool.Pool, label string) (map[uuid.UUID]bool, error) {
-	rows, err := pool.Query(ctx, `
+func fetchAxisState(ctx context.Context, q querier, label string) (map[uuid.UUID]bool, error) {
+	rows, err := q.Query(ctx, `
 		SELECT id, is_active
 		FROM coordinates_axis_sets
 		WHERE created_by_label = $1
@@ -252,8 +289,8 @@ func fetchAxisState(ctx context.Context, pool *pgxpool.Pool, label string) (map[
 	return collectState(rows)
 }
 
-func fetchPairState(ctx context.Context, pool *pgxpool.Pool, label string) (map[uuid.UUID]bool, error) {
-	rows, err := pool.Query(ctx, `
+func fetchPairState(ctx context.Context, q querier, label string) (map[uuid.UUID]bool, error) {
+	rows, err := q.Query(ctx, `
 		SELECT cpas.id, cpas.is_active
 		FROM coordinates_prompt_axis_sets cpas
 		JOIN coordinates_prompts cp ON cp.id = cpas.prompt_id
@@ -320,3 +357,12 @@ func deterministicUUID(value string) uuid.UUID {
 	normalized := strings.TrimSpace(strings.ToLower(value))
 	return uuid.NewSHA1(ns, []byte(normalized))
 }
+
+// bucketLabels returns a non-nil slice so the column stores an empty array
+// rather than NULL.
+func bucketLabels(axis AxisSet) []string {
+	if axis.Buckets == nil {
+		return []string{}
+	}
+	return axis.Buckets
+}
@@ -14,24 +14,55 @@ import (
 type Library struct {
 	Version        string    `json:"version"`
 	CreatedByLabel string    `json:"created_by_label"`
+	Locales        []string  `json:"locales,omitempty"`
 	AxisSets       []AxisSet `json:"axis_sets"`
 	Prompts        []Prompt  `json:"prompts"`
 }
 
+// Axis set modes. An empty mode means ModePlane.
+const (
+	// ModePlane places a point on the 2D x/y plane.
+	ModePlane = "plane"
+	// ModeSpectrum places a point on the x axis only; y labels are unused.
+	ModeSpectrum = "spectrum"
+	// ModeBuckets picks one of the labeled buckets; axis labels are unused.
+	ModeBuckets = "buckets"
+)
+
+const (
+	minBuckets = 2
+	maxBuckets = 6
+)
+
 type AxisSet struct {
-	Slug      string `json:"slug"`
-	XMinLabel string `json:"x_min_label"`
-	XMaxLabel string `json:"x_max_label"`
-	YMinLabel string `json:"y_min_label"`
-	YMaxLabel string `json:"y_max_label"`
-	MinRating int16  `json:"min_rating"`
+	Slug         string                     `json:"slug"`
+	Mode         string                     `json:"mode,omitempty"`
+	XMinLabel    string                     `json:"x_min_label"`
+	XMaxLabel    string                     `json:"x_max_label"`
+	YMinLabel    string                     `json:"y_min_label"`
+	YMaxLabel    string                     `json:"y_max_label"`
+	Buckets      []string                   `json:"buckets,omitempty"`
+	MinRating    int16                      `json:"min_rating"`
+	Translations map[string]AxisTranslation `json:"translations,omitempty"`
+	Provenance
+}
+
+// EffectiveMode returns the axis set's mode with the plane default applied.
+func (a AxisSet) EffectiveMode() string {
+	if strings.TrimSpace(a.Mode) == "" {
+		return ModePlane
+	}
+	return a.Mode
 }
 
 type Prompt struct {
-	Slug      string   `json:"slug"`
-	Text      string   `json:"text"`
-	MinRating int16    `json:"min_rating"`
-	AxisSlugs []string `json:"axis_slugs"`
+	Slug         string                       `json:"slug"`
+	Text         string                       `json:"text"`
+	MinRating    int16                        `json:"min_rating"`
+	AxisSlugs    []string                     `json:"axis_slugs"`
+	Theme        string                       `json:"theme,omitempty"`
+	Translations map[string]PromptTranslation `json:"translations,omitempty"`
+	Provenance
 }
 
 type Pair struct {
@@ -79,6 +110,7 @@ func Validate(lib Library) (Report, []Pair, error) {
 	if strings.TrimSpace(lib.CreatedByLabel) == "" {
 		errs = append(errs, errors.New("created_by_label is required"))
 	}
+	errs = append(errs, validateLocales(lib.Locales)...)
 	if len(lib.AxisSets) == 0 {
 		errs = append(errs, errors.New("at least one axis set is required"))
 	}
@@ -95,12 +127,18 @@ func Validate(lib Library) (Report, []Pair, error) {
 		if _, exists := axisBySlug[axis.Slug]; exists {
 			errs = append(errs, fmt.Errorf("duplicate axis slug %q", axis.Slug))
 		}
-		if strings.TrimSpace(axis.XMinLabel) == "" || strings.TrimSpace(axis.XMaxLabel) == "" || strings.TrimSpace(axis.YMinLabel) == "" || strings.TrimSpace(axis.YMaxLabel) == "" {
-			errs = append(errs, fmt.Errorf("%s has empty axis labels", prefix))
+		if err := validateAxisMode(axis); err != nil {
+			errs = append(errs, fmt.Errorf("%s %w", prefix, err))
 		}
 		if !contentrating.IsValid(axis.MinRating) {
 			errs = append(errs, fmt.Errorf("%s has invalid min_rating %d", prefix, axis.MinRating))
 		}
+		if err := validateAxisTranslations(axis, lib.Locales); err != nil {
+			errs = append(errs, fmt.Errorf("%s %w", prefix, err))
+		}
+		if err := axis.Provenance.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s %w", prefix, err))
+		}
 		axisBySlug[axis.Slug] = axis
 	}
 
@@ -127,6 +165,12 @@ func Validate(lib Library) (Report, []Pair, error) {
 		if hasDuplicateStrings(prompt.AxisSlugs) {
 			errs = append(errs, fmt.Errorf("%s contains duplicate axis slugs", prefix))
 		}
+		if err := validatePromptTranslations(prompt.Translations, lib.Locales); err != nil {
+			errs = append(errs, fmt.Errorf("%s %w", prefix, err))
+		}
+		if err := prompt.Provenance.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s %w", prefix, err))
+		}
 
 		for _, axisSlug := range prompt.AxisSlugs {
 			axis, ok := axisBySlug[axisSlug]
@@ -164,6 +208,45 @@ func Validate(lib Library) (Report, []Pair, error) {
 	return report, pairs, nil
 }
 
+// validateAxisMode checks the labels each mode needs. Error text continues
+// the caller's "axis_sets[i]" prefix.
+func validateAxisMode(axis AxisSet) error {
+	hasX := strings.TrimSpace(axis.XMinLabel) != "" && strings.TrimSpace(axis.XMaxLabel) != ""
+	hasY := strings.TrimSpace(axis.YMinLabel) != "" && strings.TrimSpace(axis.YMaxLabel) != ""
+
+	switch axis.EffectiveMode() {
+	case ModePlane:
+		if !hasX || !hasY {
+			return errors.New("has empty axis labels")
+		}
+		if len(axis.Buckets) > 0 {
+			return errors.New("lists buckets but is not in buckets mode")
+		}
+	case ModeSpectrum:
+		if !hasX {
+			return errors.New("needs x axis labels in spectrum mode")
+		}
+		if len(axis.Buckets) > 0 {
+			return errors.New("lists buckets but is not in buckets mode")
+		}
+	case ModeBuckets:
+		if len(axis.Buckets) < minBuckets || len(axis.Buckets) > maxBuckets {
+			return fmt.Errorf("needs %d-%d buckets, got %d", minBuckets, maxBuckets, len(axis.Buckets))
+		}
+		for _, label := range axis.Buckets {
+			if strings.TrimSpace(label) == "" {
+				return errors.New("has an empty bucket label")
+			}
+		}
+		if hasDuplicateStrings(axis.Buckets) {
+			return errors.New("contains duplicate bucket labels")
+		}
+	default:
+		return fmt.Errorf("has unknown mode %q", axis.Mode)
+	}
+	return nil
+}
+
 func hasDuplicateStrings(values []string) bool {
 	seen := make(map[string]bool, len(values))
 	for _, value := range values {
@@ -78,3 +78,31 @@ func TestPairUUIDDeterministic(t *testing.T) {
 		t.Fatalf("expected deterministic UUIDs to match: %s vs %s", a, b)
 	}
 }
+
+func TestValidateAxisModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		axis    AxisSet
+		wantErr bool
+	}{
+		{name: "plane", axis: AxisSet{XMinLabel: "a", XMaxLabel: "b", YMinLabel: "c", YMaxLabel: "d"}},
+		{name: "plane missing y", axis: AxisSet{XMinLabel: "a", XMaxLabel: "b"}, wantErr: true},
+		{name: "spectrum", axis: AxisSet{Mode: ModeSpectrum, XMinLabel: "a", XMaxLabel: "b"}},
+		{name: "spectrum missing x", axis: AxisSet{Mode: ModeSpectrum, YMinLabel: "c", YMaxLabel: "d"}, wantErr: true},
+		{name: "buckets", axis: AxisSet{Mode: ModeBuckets, Buckets: []string{"Plan", "Improvise", "Delegate"}}},
+		{name: "one bucket", axis: AxisSet{Mode: ModeBuckets, Buckets: []string{"Plan"}}, wantErr: true},
+		{name: "too many buckets", axis: AxisSet{Mode: ModeBuckets, Buckets: []string{"a", "b", "c", "d", "e", "f", "g"}}, wantErr: true},
+		{name: "duplicate buckets", axis: AxisSet{Mode: ModeBuckets, Buckets: []string{"a", "a"}}, wantErr: true},
+		{name: "buckets outside bucket mode", axis: AxisSet{Mode: ModeSpectrum, XMinLabel: "a", XMaxLabel: "b", Buckets: []string{"a", "b"}}, wantErr: true},
+		{name: "unknown mode", axis: AxisSet{Mode: "cube", XMinLabel: "a", XMaxLabel: "b", YMinLabel: "c", YMaxLabel: "d"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAxisMode(tt.axis)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateAxisMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package clustercontent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Authoring modes, matching the authoring_mode column check constraint.
+const (
+	AuthoringManual      = "manual"
+	AuthoringAIAssisted  = "ai_assisted"
+	AuthoringAIGenerated = "ai_generated"
+	AuthoringImported    = "imported"
+)
+
+// AuthoringModes lists the valid authoring modes in display order.
+var AuthoringModes = []string{AuthoringManual, AuthoringAIAssisted, AuthoringAIGenerated, AuthoringImported}
+
+// importSource is recorded in every imported row's provenance.
+const importSource = "cluster-content-import"
+
+// Generator records which model drafted a content entry. It maps onto the
+// generator_* columns of the content tables.
+type Generator struct {
+	Provider      string `json:"provider"`
+	Model         string `json:"model"`
+	PromptVersion string `json:"prompt_version"`
+	RunID         string `json:"run_id"`
+}
+
+// Provenance describes how a prompt or axis set was authored. It is embedded
+// in the studio, library, and axis set types so the fields sit at the top
+// level of each JSON entry. A zero Provenance imports as "imported".
+type Provenance struct {
+	AuthoringMode string     `json:"authoring_mode,omitempty"`
+	Generator     *Generator `json:"generator,omitempty"`
+	// AuthorLabel names the person or tool that wrote the entry. It is stored
+	// in the provenance column; created_by_label stays the library label so
+	// imports can find and deactivate their own rows.
+	AuthorLabel string `json:"author_label,omitempty"`
+	// Extra is free-form provenance merged into the provenance column.
+	Extra map[string]string `json:"provenance,omitempty"`
+}
+
+// EffectiveAuthoringMode returns the mode with the import default applied.
+func (p Provenance) EffectiveAuthoringMode() string {
+	if mode := strings.TrimSpace(p.AuthoringMode); mode != "" {
+		return mode
+	}
+	return AuthoringImported
+}
+
+func (p Provenance) isZero() bool {
+	return p.AuthoringMode == "" && p.Generator == nil && p.AuthorLabel == "" && len(p.Extra) == 0
+}
+
+func (p Provenance) validate() error {
+	mode := p.EffectiveAuthoringMode()
+	valid := false
+	for _, m := range AuthoringModes {
+		if mode == m {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("has unknown authoring_mode %q", p.AuthoringMode)
+	}
+	if p.Generator != nil && (mode == AuthoringManual || mode == AuthoringImported) {
+		return fmt.Errorf("has generator metadata but authoring_mode %q", mode)
+	}
+	return nil
+}
+
+// provenanceJSON builds the provenance column value. The importer's own keys
+// (source, slug, theme) win over Extra so rows stay traceable to the library.
+func (p Provenance) provenanceJSON(slug string, theme string) string {
+	out := make(map[string]string, len(p.Extra)+4)
+	for key, value := range p.Extra {
+		out[key] = value
+	}
+	if p.AuthorLabel != "" {
+		out["author"] = p.AuthorLabel
+	}
+	out["source"] = importSource
+	out["slug"] = slug
+	if theme != "" {
+		out["theme"] = theme
+	}
+	raw, _ := json.Marshal(out)
+	return string(raw)
+}
+
+// generatorColumns returns generator_* values, nil when not generated so the
+// columns stay NULL.
+func (p Provenance) generatorColumns() (provider, model, promptVersion, runID *string) {
+	if p.Generator == nil {
+		return nil, nil, nil, nil
+	}
+	return nullableString(p.Generator.Provider), nullableString(p.Generator.Model), nullableString(p.Generator.PromptVersion), nullableString(p.Generator.RunID)
+}
+
+// clone copies the pointer and map fields so library and studio values do
+// not share mutable state.
+func (p Provenance) clone() Provenance {
+	if p.Generator != nil {
+		g := *p.Generator
+		p.Generator = &g
+	}
+	if p.Extra != nil {
+		extra := make(map[string]string, len(p.Extra))
+		for key, value := range p.Extra {
+			extra[key] = value
+		}
+		p.Extra = extra
+	}
+	return p
+}
+
+func nullableString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// provenanceFromColumns reverses what Import writes: it rebuilds Provenance
+// from the content columns and returns the slug and theme recorded in the
+// provenance column, if any.
+func provenanceFromColumns(mode string, provider, model, promptVersion, runID *string, raw []byte) (Provenance, string, string) {
+	p := Provenance{AuthoringMode: strings.TrimSpace(mode)}
+	if provider != nil || model != nil || promptVersion != nil || runID != nil {
+		p.Generator = &Generator{
+			Provider:      derefString(provider),
+			Model:         derefString(model),
+			PromptVersion: derefString(promptVersion),
+			RunID:         derefString(runID),
+		}
+	}
+
+	var fields map[string]any
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &fields)
+	}
+	var slug, theme string
+	for key, value := range fields {
+		text, ok := value.(string)
+		if !ok {
+			encoded, _ := json.Marshal(value)
+			text = string(encoded)
+		}
+		switch key {
+		case "slug":
+			slug = text
+		case "theme":
+			theme = text
+		case "author":
+			p.AuthorLabel = text
+		case "source":
+			if text == importSource {
+				continue
+			}
+			fallthrough
+		default:
+			if p.Extra == nil {
+				p.Extra = map[string]string{}
+			}
+			p.Extra[key] = text
+		}
+	}
+	return p, slug, theme
+}
+
+func derefString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
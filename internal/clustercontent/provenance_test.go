@@ -0,0 +1,116 @@
+package clustercontent
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProvenanceValidate(t *testing.T) {
+	gen := &Generator{Provider: "openai", Model: "m", PromptVersion: GeneratorPromptVersion, RunID: "run-1"}
+	cases := []struct {
+		name    string
+		p       Provenance
+		wantErr string
+	}{
+		{name: "zero defaults to imported", p: Provenance{}},
+		{name: "generated with generator", p: Provenance{AuthoringMode: AuthoringAIGenerated, Generator: gen}},
+		{name: "unknown mode", p: Provenance{AuthoringMode: "vibes"}, wantErr: "unknown authoring_mode"},
+		{name: "manual with generator", p: Provenance{AuthoringMode: AuthoringManual, Generator: gen}, wantErr: "has generator metadata"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.p.validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected valid, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestProvenanceJSONMergesExtraAndKeepsImportKeys(t *testing.T) {
+	p := Provenance{
+		AuthorLabel: "justin",
+		Extra:       map[string]string{"ticket": "CL-12", "source": "spreadsheet"},
+	}
+	var got map[string]string
+	if err := json.Unmarshal([]byte(p.provenanceJSON("lunch", "food")), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := map[string]string{
+		"ticket": "CL-12",
+		"author": "justin",
+		"source": importSource,
+		"slug":   "lunch",
+		"theme":  "food",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected provenance %v", got)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Fatalf("provenance[%q] = %q, want %q", key, got[key], value)
+		}
+	}
+
+	provider, model, version, runID := p.generatorColumns()
+	if provider != nil || model != nil || version != nil || runID != nil {
+		t.Fatal("expected NULL generator columns without a generator")
+	}
+}
+
+func TestStudioProvenanceRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "studio.json")
+	src := StudioSource{
+		Version:        "v1",
+		CreatedByLabel: "cluster-studio",
+		AxisSets: []AxisSet{{
+			Slug: "axis-a", XMinLabel: "Low", XMaxLabel: "High", YMinLabel: "Slow", YMaxLabel: "Fast", MinRating: 10,
+			Provenance: Provenance{AuthoringMode: AuthoringManual, AuthorLabel: "justin"},
+		}},
+		Prompts: []StudioPrompt{{
+			Slug: "lunch", Text: "Best lunch spot?", MinRating: 20, AxisSlugs: []string{"axis-a"}, Status: "ready",
+			Provenance: Provenance{
+				AuthoringMode: AuthoringAIGenerated,
+				Generator:     &Generator{Provider: "openai", Model: "m", PromptVersion: GeneratorPromptVersion, RunID: "run-1"},
+				Extra:         map[string]string{"batch": "fall"},
+			},
+		}},
+	}
+	if err := SaveStudio(path, src); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	loaded, err := LoadStudio(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	lib := loaded.ToLibrary()
+	if _, _, err := Validate(lib); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	prompt := lib.Prompts[0]
+	if prompt.EffectiveAuthoringMode() != AuthoringAIGenerated || prompt.Generator == nil || prompt.Generator.RunID != "run-1" || prompt.Extra["batch"] != "fall" {
+		t.Fatalf("prompt provenance lost: %+v", prompt.Provenance)
+	}
+	if lib.AxisSets[0].AuthorLabel != "justin" || lib.AxisSets[0].EffectiveAuthoringMode() != AuthoringManual {
+		t.Fatalf("axis provenance lost: %+v", lib.AxisSets[0].Provenance)
+	}
+
+	lib.Prompts[0].Generator.RunID = "changed"
+	if loaded.Prompts[0].Generator.RunID != "run-1" {
+		t.Fatal("library prompt shares generator with studio prompt")
+	}
+
+	back := StudioFromLibrary(lib)
+	if back.Prompts[0].Generator == nil || back.Prompts[0].Generator.Model != "m" {
+		t.Fatalf("expected provenance back in studio: %+v", back.Prompts[0].Provenance)
+	}
+}
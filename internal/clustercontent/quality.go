@@ -0,0 +1,233 @@
+package clustercontent
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// QualityMinRounds is how many finished rounds a prompt needs before it
+	// is flagged, so one bad night does not condemn it.
+	QualityMinRounds = 3
+	// OftenSkippedRate flags prompts groups skip at least this often.
+	OftenSkippedRate = 0.4
+	// LowSpreadDistance flags prompts where placements average this close to
+	// the centroid, meaning everyone answered the same way.
+	LowSpreadDistance = 0.1
+)
+
+const (
+	FlagOftenSkipped = "often-skipped"
+	FlagLowSpread    = "low-spread"
+)
+
+// RoundSample is one played round of an imported prompt-axis pair. A round
+// is skipped when the lobby moved on before revealing it; rounds still in
+// progress are neither revealed nor skipped.
+type RoundSample struct {
+	PromptSlug  string
+	AxisSlug    string
+	Revealed    bool
+	Skipped     bool
+	Submissions int
+	// Spread is the mean distance of placements from the centroid.
+	Spread float64
+	// CentroidX and CentroidY are only meaningful for revealed rounds.
+	CentroidX float64
+	CentroidY float64
+}
+
+type PromptQuality struct {
+	Slug        string
+	Rounds      int
+	Revealed    int
+	Skipped     int
+	Submissions int
+	// SkipRate is skipped over finished (revealed or skipped) rounds.
+	SkipRate float64
+	// AvgSpread and AvgExtremity average over revealed rounds. Extremity is
+	// how far the centroid sits from the middle of the plane, from 0 to 1.
+	AvgSpread    float64
+	AvgExtremity float64
+	Flags        []string
+}
+
+type AxisSetUsage struct {
+	Slug     string
+	Rounds   int
+	Revealed int
+	Skipped  int
+	Prompts  int
+}
+
+type QualityReport struct {
+	Rounds   int
+	Prompts  []PromptQuality
+	AxisSets []AxisSetUsage
+}
+
+// Prompt returns the stats for slug, if it has been played.
+func (r QualityReport) Prompt(slug string) (PromptQuality, bool) {
+	for _, prompt := range r.Prompts {
+		if prompt.Slug == slug {
+			return prompt, true
+		}
+	}
+	return PromptQuality{}, false
+}
+
+// CentroidExtremity maps a centroid to its distance from the plane's center,
+// scaled so a corner is 1.
+func CentroidExtremity(x float64, y float64) float64 {
+	return math.Hypot(x-0.5, y-0.5) / math.Sqrt(0.5)
+}
+
+// BuildQualityReport aggregates round samples per prompt and per axis set.
+// Prompts sort by skip rate, then by rounds played; axis sets by rounds.
+func BuildQualityReport(samples []RoundSample) QualityReport {
+	type promptTally struct {
+		quality   PromptQuality
+		spread    float64
+		extremity float64
+	}
+	type axisTally struct {
+		usage   AxisSetUsage
+		prompts map[string]bool
+	}
+
+	prompts := make(map[string]*promptTally)
+	axes := make(map[string]*axisTally)
+	for _, sample := range samples {
+		prompt := prompts[sample.PromptSlug]
+		if prompt == nil {
+			prompt = &promptTally{quality: PromptQuality{Slug: sample.PromptSlug}}
+			prompts[sample.PromptSlug] = prompt
+		}
+		axis := axes[sample.AxisSlug]
+		if axis == nil {
+			axis = &axisTally{usage: AxisSetUsage{Slug: sample.AxisSlug}, prompts: make(map[string]bool)}
+			axes[sample.AxisSlug] = axis
+		}
+
+		prompt.quality.Rounds++
+		prompt.quality.Submissions += sample.Submissions
+		axis.usage.Rounds++
+		axis.prompts[sample.PromptSlug] = true
+		switch {
+		case sample.Revealed:
+			prompt.quality.Revealed++
+			prompt.spread += sample.Spread
+			prompt.extremity += CentroidExtremity(sample.CentroidX, sample.CentroidY)
+			axis.usage.Revealed++
+		case sample.Skipped:
+			prompt.quality.Skipped++
+			axis.usage.Skipped++
+		}
+	}
+
+	report := QualityReport{Rounds: len(samples)}
+	for _, prompt := range prompts {
+		quality := prompt.quality
+		if finished := quality.Revealed + quality.Skipped; finished > 0 {
+			quality.SkipRate = float64(quality.Skipped) / float64(finished)
+		}
+		if quality.Revealed > 0 {
+			quality.AvgSpread = prompt.spread / float64(quality.Revealed)
+			quality.AvgExtremity = prompt.extremity / float64(quality.Revealed)
+		}
+		quality.Flags = qualityFlags(quality)
+		report.Prompts = append(report.Prompts, quality)
+	}
+	for _, axis := range axes {
+		usage := axis.usage
+		usage.Prompts = len(axis.prompts)
+		report.AxisSets = append(report.AxisSets, usage)
+	}
+
+	sort.Slice(report.Prompts, func(i, j int) bool {
+		a, b := report.Prompts[i], report.Prompts[j]
+		if a.SkipRate != b.SkipRate {
+			return a.SkipRate > b.SkipRate
+		}
+		if a.Rounds != b.Rounds {
+			return a.Rounds > b.Rounds
+		}
+		return a.Slug < b.Slug
+	})
+	sort.Slice(report.AxisSets, func(i, j int) bool {
+		a, b := report.AxisSets[i], report.AxisSets[j]
+		if a.Rounds != b.Rounds {
+			return a.Rounds > b.Rounds
+		}
+		return a.Slug < b.Slug
+	})
+	return report
+}
+
+func qualityFlags(quality PromptQuality) []string {
+	var flags []string
+	if quality.Revealed+quality.Skipped >= QualityMinRounds && quality.SkipRate >= OftenSkippedRate {
+		flags = append(flags, FlagOftenSkipped)
+	}
+	if quality.Revealed >= QualityMinRounds && quality.AvgSpread < LowSpreadDistance {
+		flags = append(flags, FlagLowSpread)
+	}
+	return flags
+}
+
+// LoadQualityReport reads gameplay history for imported prompts. Host-written
+// prompts have no slug and are left out.
+func LoadQualityReport(ctx context.Context, pool *pgxpool.Pool) (QualityReport, error) {
+	samples, err := fetchRoundSamples(ctx, pool)
+	if err != nil {
+		return QualityReport{}, err
+	}
+	return BuildQualityReport(samples), nil
+}
+
+func fetchRoundSamples(ctx context.Context, pool *pgxpool.Pool) ([]RoundSample, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT cp.provenance->>'slug', cas.provenance->>'slug',
+			o.revealed,
+			o.skipped,
+			COUNT(s.round_id),
+			COALESCE(AVG(SQRT(POWER(s.x - cr.centroid_x, 2) + POWER(s.y - cr.centroid_y, 2))), 0)::float8,
+			COALESCE(cr.centroid_x, 0.5)::float8,
+			COALESCE(cr.centroid_y, 0.5)::float8
+		FROM coordinates_round_outcomes o
+		JOIN coordinates_rounds cr ON cr.id = o.round_id
+		JOIN coordinates_prompt_axis_sets cpas ON cpas.id = cr.prompt_axis_set_id
+		JOIN coordinates_prompts cp ON cp.id = cpas.prompt_id
+		JOIN coordinates_axis_sets cas ON cas.id = cpas.axis_set_id
+		LEFT JOIN coordinates_submissions s ON s.round_id = cr.id
+		WHERE cp.provenance ? 'slug'
+		  AND cas.provenance ? 'slug'
+		GROUP BY cr.id, cp.id, cas.id, o.revealed, o.skipped
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	samples := make([]RoundSample, 0)
+	for rows.Next() {
+		var sample RoundSample
+		if err := rows.Scan(
+			&sample.PromptSlug,
+			&sample.AxisSlug,
+			&sample.Revealed,
+			&sample.Skipped,
+			&sample.Submissions,
+			&sample.Spread,
+			&sample.CentroidX,
+			&sample.CentroidY,
+		); err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	return samples, rows.Err()
+}
@@ -0,0 +1,60 @@
+package clustercontent
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestBuildQualityReportAggregatesPromptsAndAxes(t *testing.T) {
+	revealed := func(prompt string, axis string, spread float64, x float64, y float64) RoundSample {
+		return RoundSample{PromptSlug: prompt, AxisSlug: axis, Revealed: true, Submissions: 4, Spread: spread, CentroidX: x, CentroidY: y}
+	}
+	samples := []RoundSample{
+		revealed("loud", "plane", 0.3, 1, 1),
+		revealed("loud", "plane", 0.2, 0.5, 0.5),
+		{PromptSlug: "loud", AxisSlug: "plane"},
+		{PromptSlug: "dull", AxisSlug: "plane", Skipped: true},
+		{PromptSlug: "dull", AxisSlug: "plane", Skipped: true},
+		revealed("dull", "buckets", 0.02, 0.5, 0.5),
+	}
+
+	report := BuildQualityReport(samples)
+	if report.Rounds != 6 || len(report.Prompts) != 2 || len(report.AxisSets) != 2 {
+		t.Fatalf("unexpected report shape: %+v", report)
+	}
+
+	dull := report.Prompts[0]
+	if dull.Slug != "dull" || dull.Skipped != 2 || math.Abs(dull.SkipRate-2.0/3) > 1e-9 {
+		t.Fatalf("expected the skipped prompt first, got %+v", dull)
+	}
+	if !slices.Equal(dull.Flags, []string{FlagOftenSkipped}) {
+		t.Fatalf("expected an often skipped flag, got %v", dull.Flags)
+	}
+
+	loud, ok := report.Prompt("loud")
+	if !ok || loud.Rounds != 3 || loud.Revealed != 2 || loud.Skipped != 0 || loud.Submissions != 8 {
+		t.Fatalf("unexpected loud stats: %+v", loud)
+	}
+	if math.Abs(loud.AvgSpread-0.25) > 1e-9 || math.Abs(loud.AvgExtremity-0.5) > 1e-9 {
+		t.Fatalf("unexpected loud averages: %+v", loud)
+	}
+
+	plane := report.AxisSets[0]
+	if plane.Slug != "plane" || plane.Rounds != 5 || plane.Revealed != 2 || plane.Skipped != 2 || plane.Prompts != 2 {
+		t.Fatalf("unexpected plane usage: %+v", plane)
+	}
+}
+
+func TestQualityFlagsNeedEnoughRounds(t *testing.T) {
+	agreed := RoundSample{PromptSlug: "obvious", AxisSlug: "plane", Revealed: true, Spread: 0.01, CentroidX: 0.9, CentroidY: 0.9}
+	report := BuildQualityReport([]RoundSample{agreed, agreed})
+	if flags := report.Prompts[0].Flags; len(flags) != 0 {
+		t.Fatalf("expected no flags before %d rounds, got %v", QualityMinRounds, flags)
+	}
+
+	report = BuildQualityReport([]RoundSample{agreed, agreed, agreed})
+	if flags := report.Prompts[0].Flags; !slices.Equal(flags, []string{FlagLowSpread}) {
+		t.Fatalf("expected a low spread flag, got %v", flags)
+	}
+}
@@ -0,0 +1,359 @@
+package clustercontent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Release kinds, matching coordinates_content_releases.kind.
+const (
+	ReleaseImport   = "import"
+	ReleaseRollback = "rollback"
+)
+
+// Release entity names, matching coordinates_content_release_rows.entity.
+const (
+	releaseEntityPrompt  = "prompt"
+	releaseEntityAxisSet = "axis_set"
+	releaseEntityPair    = "pair"
+)
+
+// ErrNoPreviousRelease is returned by Rollback when there is nothing earlier
+// to restore.
+var ErrNoPreviousRelease = errors.New("no earlier release to roll back to")
+
+// ReleaseCounts describes one entity's rows after a release. Active includes
+// the rows the release activated.
+type ReleaseCounts struct {
+	Active      int
+	Activated   int
+	Deactivated int
+}
+
+// Release is one recorded import or rollback.
+type Release struct {
+	ID             uuid.UUID
+	CreatedByLabel string
+	Kind           string
+	LibraryVersion string
+	ContentHash    string
+	// ParentID is the release whose active set this one replaced.
+	ParentID uuid.NullUUID
+	// RestoredFromID is the release a rollback restored.
+	RestoredFromID uuid.NullUUID
+	CreatedAt      time.Time
+	Prompts        ReleaseCounts
+	AxisSets       ReleaseCounts
+	Pairs          ReleaseCounts
+}
+
+// RollbackOptions selects the release to restore. A zero To restores the
+// parent of the latest release.
+type RollbackOptions struct {
+	To     uuid.UUID
+	DryRun bool
+}
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// contentState maps every managed row id to its is_active flag.
+type contentState struct {
+	prompts  map[uuid.UUID]bool
+	axisSets map[uuid.UUID]bool
+	pairs    map[uuid.UUID]bool
+}
+
+// ContentHash fingerprints a library so a release can be matched to the file
+// it came from.
+func ContentHash(lib Library) string {
+	raw, _ := json.Marshal(lib)
+	return hashBytes(raw)
+}
+
+// hashBytes is the hex SHA-256 used for content and studio file hashes.
+func hashBytes(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// ListReleases returns the newest releases for label first.
+func ListReleases(ctx context.Context, pool *pgxpool.Pool, label string, limit int) ([]Release, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT r.id, r.created_by_label, r.kind, r.library_version, r.content_hash,
+			r.parent_id, r.restored_from_id, r.created_at,
+			COUNT(*) FILTER (WHERE rr.entity = 'prompt' AND rr.change <> 'deactivated'),
+			COUNT(*) FILTER (WHERE rr.entity = 'prompt' AND rr.change = 'activated'),
+			COUNT(*) FILTER (WHERE rr.entity = 'prompt' AND rr.change = 'deactivated'),
+			COUNT(*) FILTER (WHERE rr.entity = 'axis_set' AND rr.change <> 'deactivated'),
+			COUNT(*) FILTER (WHERE rr.entity = 'axis_set' AND rr.change = 'activated'),
+			COUNT(*) FILTER (WHERE rr.entity = 'axis_set' AND rr.change = 'deactivated'),
+			COUNT(*) FILTER (WHERE rr.entity = 'pair' AND rr.change <> 'deactivated'),
+			COUNT(*) FILTER (WHERE rr.entity = 'pair' AND rr.change = 'activated'),
+			COUNT(*) FILTER (WHERE rr.entity = 'pair' AND rr.change = 'deactivated')
+		FROM coordinates_content_releases r
+		LEFT JOIN coordinates_content_release_rows rr ON rr.release_id = r.id
+		WHERE r.created_by_label = $1
+		GROUP BY r.id
+		ORDER BY r.created_at DESC, r.id
+		LIMIT $2
+	`, label, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var releases []Release
+	for rows.Next() {
+		var rel Release
+		if err := rows.Scan(&rel.ID, &rel.CreatedByLabel, &rel.Kind, &rel.LibraryVersion, &rel.ContentHash,
+			&rel.ParentID, &rel.RestoredFromID, &rel.CreatedAt,
+			&rel.Prompts.Active, &rel.Prompts.Activated, &rel.Prompts.Deactivated,
+			&rel.AxisSets.Active, &rel.AxisSets.Activated, &rel.AxisSets.Deactivated,
+			&rel.Pairs.Active, &rel.Pairs.Activated, &rel.Pairs.Deactivated,
+		); err != nil {
+			return nil, err
+		}
+		releases = append(releases, rel)
+	}
+	return releases, rows.Err()
+}
+
+// Rollback restores the active set of an earlier release in one
+// transaction and records the rollback as a release of its own. Only
+// is_active flags change: text and labels keep their latest imported values,
+// so re-import the matching library file to restore those.
+func Rollback(ctx context.Context, pool *pgxpool.Pool, label string, opts RollbackOptions) (Release, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return Release{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	targetID := opts.To
+	if targetID == uuid.Nil {
+		var parent uuid.NullUUID
+		err := tx.QueryRow(ctx, `
+			SELECT parent_id
+			FROM coordinates_content_releases
+			WHERE created_by_label = $1
+			ORDER BY created_at DESC, id
+			LIMIT 1
+		`, label).Scan(&parent)
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && !parent.Valid) {
+			return Release{}, ErrNoPreviousRelease
+		}
+		if err != nil {
+			return Release{}, err
+		}
+		targetID = parent.UUID
+	}
+
+	var target Release
+	err = tx.QueryRow(ctx, `
+		SELECT id, created_by_label, library_version, content_hash, parent_id
+		FROM coordinates_content_releases
+		WHERE id = $1
+	`, targetID).Scan(&target.ID, &target.CreatedByLabel, &target.LibraryVersion, &target.ContentHash, &target.ParentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Release{}, fmt.Errorf("release %s not found", targetID)
+	}
+	if err != nil {
+		return Release{}, err
+	}
+	if target.CreatedByLabel != label {
+		return Release{}, fmt.Errorf("release %s belongs to %q, not %q", targetID, target.CreatedByLabel, label)
+	}
+
+	keep, err := fetchReleaseActiveSet(ctx, tx, target.ID)
+	if err != nil {
+		return Release{}, err
+	}
+
+	before, err := fetchContentState(ctx, tx, label)
+	if err != nil {
+		return Release{}, err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE coordinates_prompts
+		SET is_active = (id = ANY($2::uuid[]))
+		WHERE created_by_label = $1
+		  AND is_active <> (id = ANY($2::uuid[]))
+	`, label, keep[releaseEntityPrompt]); err != nil {
+		return Release{}, fmt.Errorf("restore prompts: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE coordinates_axis_sets
+		SET is_active = (id = ANY($2::uuid[]))
+		WHERE created_by_label = $1
+		  AND is_active <> (id = ANY($2::uuid[]))
+	`, label, keep[releaseEntityAxisSet]); err != nil {
+		return Release{}, fmt.Errorf("restore axis sets: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE coordinates_prompt_axis_sets cpas
+		SET is_active = (cpas.id = ANY($2::uuid[]))
+		FROM coordinates_prompts cp, coordinates_axis_sets cas
+		WHERE cpas.prompt_id = cp.id
+		  AND cpas.axis_set_id = cas.id
+		  AND cp.created_by_label = $1
+		  AND cas.created_by_label = $1
+		  AND cpas.is_active <> (cpas.id = ANY($2::uuid[]))
+	`, label, keep[releaseEntityPair]); err != nil {
+		return Release{}, fmt.Errorf("restore pairs: %w", err)
+	}
+	after, err := fetchContentState(ctx, tx, label)
+	if err != nil {
+		return Release{}, err
+	}
+
+	rel := Release{
+		ID:             uuid.New(),
+		CreatedByLabel: label,
+		Kind:           ReleaseRollback,
+		LibraryVersion: target.LibraryVersion,
+		ContentHash:    target.ContentHash,
+		ParentID:       target.ParentID,
+		RestoredFromID: uuid.NullUUID{UUID: target.ID, Valid: true},
+	}
+	if err := recordRelease(ctx, tx, &rel, before, after); err != nil {
+		return Release{}, err
+	}
+	if opts.DryRun {
+		return rel, nil
+	}
+	return rel, tx.Commit(ctx)
+}
+
+func fetchContentState(ctx context.Context, q querier, label string) (contentState, error) {
+	prompts, err := fetchPromptState(ctx, q, label)
+	if err != nil {
+		return contentState{}, err
+	}
+	axisSets, err := fetchAxisState(ctx, q, label)
+	if err != nil {
+		return contentState{}, err
+	}
+	pairs, err := fetchPairState(ctx, q, label)
+	if err != nil {
+		return contentState{}, err
+	}
+	return contentState{prompts: prompts, axisSets: axisSets, pairs: pairs}, nil
+}
+
+func fetchReleaseActiveSet(ctx context.Context, q querier, releaseID uuid.UUID) (map[string][]uuid.UUID, error) {
+	rows, err := q.Query(ctx, `
+		SELECT entity, row_id
+		FROM coordinates_content_release_rows
+		WHERE release_id = $1
+		  AND change <> 'deactivated'
+	`, releaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	active := map[string][]uuid.UUID{
+		releaseEntityPrompt:  {},
+		releaseEntityAxisSet: {},
+		releaseEntityPair:    {},
+	}
+	for rows.Next() {
+		var (
+			entity string
+			id     uuid.UUID
+		)
+		if err := rows.Scan(&entity, &id); err != nil {
+			return nil, err
+		}
+		active[entity] = append(active[entity], id)
+	}
+	return active, rows.Err()
+}
+
+// latestReleaseID returns the newest release for label, if any.
+func latestReleaseID(ctx context.Context, tx pgx.Tx, label string) (uuid.NullUUID, error) {
+	var id uuid.NullUUID
+	err := tx.QueryRow(ctx, `
+		SELECT id
+		FROM coordinates_content_releases
+		WHERE created_by_label = $1
+		ORDER BY created_at DESC, id
+		LIMIT 1
+	`, label).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.NullUUID{}, nil
+	}
+	return id, err
+}
+
+// recordRelease inserts rel and its row changes, filling in rel's counts.
+func recordRelease(ctx context.Context, tx pgx.Tx, rel *Release, before, after contentState) error {
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO coordinates_content_releases (
+			id, created_by_label, kind, library_version, content_hash, parent_id, restored_from_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`, rel.ID, rel.CreatedByLabel, rel.Kind, rel.LibraryVersion, rel.ContentHash, rel.ParentID, rel.RestoredFromID).Scan(&rel.CreatedAt); err != nil {
+		return fmt.Errorf("record release: %w", err)
+	}
+
+	for _, entity := range []struct {
+		name          string
+		before, after map[uuid.UUID]bool
+		counts        *ReleaseCounts
+	}{
+		{releaseEntityPrompt, before.prompts, after.prompts, &rel.Prompts},
+		{releaseEntityAxisSet, before.axisSets, after.axisSets, &rel.AxisSets},
+		{releaseEntityPair, before.pairs, after.pairs, &rel.Pairs},
+	} {
+		ids, changes, counts := releaseRowChanges(entity.before, entity.after)
+		*entity.counts = counts
+		if len(ids) == 0 {
+			continue
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO coordinates_content_release_rows (release_id, entity, row_id, change)
+			SELECT $1, $2, row_id, change
+			FROM unnest($3::uuid[], $4::text[]) AS changes(row_id, change)
+		`, rel.ID, entity.name, ids, changes); err != nil {
+			return fmt.Errorf("record release %s rows: %w", entity.name, err)
+		}
+	}
+	return nil
+}
+
+// releaseRowChanges classifies every row that is active after the release,
+// or was active before it, as activated, kept, or deactivated.
+func releaseRowChanges(before, after map[uuid.UUID]bool) ([]uuid.UUID, []string, ReleaseCounts) {
+	var (
+		ids     []uuid.UUID
+		changes []string
+		counts  ReleaseCounts
+	)
+	for id, active := range after {
+		switch {
+		case active && before[id]:
+			ids, changes = append(ids, id), append(changes, "kept")
+			counts.Active++
+		case active:
+			ids, changes = append(ids, id), append(changes, "activated")
+			counts.Active++
+			counts.Activated++
+		case before[id]:
+			ids, changes = append(ids, id), append(changes, "deactivated")
+			counts.Deactivated++
+		}
+	}
+	return ids, changes, counts
+}
@@ -0,0 +1,48 @@
+package clustercontent
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestReleaseRowChanges(t *testing.T) {
+	kept, added, dropped, stillOff := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	before := map[uuid.UUID]bool{kept: true, added: false, dropped: true, stillOff: false}
+	after := map[uuid.UUID]bool{kept: true, added: true, dropped: false, stillOff: false}
+
+	ids, changes, counts := releaseRowChanges(before, after)
+	if counts != (ReleaseCounts{Active: 2, Activated: 1, Deactivated: 1}) {
+		t.Fatalf("unexpected counts %+v", counts)
+	}
+	got := map[uuid.UUID]string{}
+	for i, id := range ids {
+		got[id] = changes[i]
+	}
+	want := map[uuid.UUID]string{kept: "kept", added: "activated", dropped: "deactivated"}
+	if len(got) != len(want) {
+		t.Fatalf("expected rows that were never active to be left out, got %v", got)
+	}
+	for id, change := range want {
+		if got[id] != change {
+			t.Fatalf("row %s: got %q, want %q", id, got[id], change)
+		}
+	}
+}
+
+func TestContentHashTracksContent(t *testing.T) {
+	lib := Library{
+		Version:        "v1",
+		CreatedByLabel: "cluster-studio",
+		AxisSets:       []AxisSet{{Slug: "axis-a", XMinLabel: "Low", XMaxLabel: "High", YMinLabel: "Slow", YMaxLabel: "Fast", MinRating: 10}},
+		Prompts:        []Prompt{{Slug: "p1", Text: "One", MinRating: 20, AxisSlugs: []string{"axis-a"}}},
+	}
+	hash := ContentHash(lib)
+	if len(hash) != 64 || ContentHash(lib) != hash {
+		t.Fatalf("expected a stable sha256 hex digest, got %q", hash)
+	}
+	lib.Prompts[0].Text = "Two"
+	if ContentHash(lib) == hash {
+		t.Fatal("expected the hash to change with prompt text")
+	}
+}
@@ -0,0 +1,331 @@
+package clustercontent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+// Rule severities. SeverityOff keeps a rule in the config without running it.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityOff     = "off"
+)
+
+// Rule names, as reported in violations.
+const (
+	RuleMaxPromptLength  = "max-prompt-length"
+	RuleMinAxesPerPrompt = "min-axes-per-prompt"
+	RuleBannedWords      = "banned-words"
+	RuleAxisLabelBalance = "axis-label-balance"
+	RuleRatingClassifier = "rating-classifier"
+)
+
+// RuleSet is the content rules config. Rules left out of the file do not run.
+type RuleSet struct {
+	MaxPromptLength  *MaxPromptLengthRule  `json:"max_prompt_length,omitempty"`
+	MinAxesPerPrompt *MinAxesPerPromptRule `json:"min_axes_per_prompt,omitempty"`
+	BannedWords      *BannedWordsRule      `json:"banned_words,omitempty"`
+	AxisLabelBalance *AxisLabelBalanceRule `json:"axis_label_balance,omitempty"`
+	RatingClassifier *RatingClassifierRule `json:"rating_classifier,omitempty"`
+}
+
+// MaxPromptLengthRule caps prompt text, in characters.
+type MaxPromptLengthRule struct {
+	Severity string `json:"severity"`
+	Max      int    `json:"max"`
+}
+
+// MinAxesPerPromptRule asks for enough axis sets per prompt that a lobby
+// does not see the same pairing twice.
+type MinAxesPerPromptRule struct {
+	Severity string `json:"severity"`
+	Min      int    `json:"min"`
+}
+
+// BannedWordsRule lists words and phrases by rating tier. A tier's words are
+// banned from anything playable at that tier, so a Kids prompt is checked
+// against every tier's list and an Adults prompt only against the Adults
+// list. Matching is case-insensitive on whole words.
+type BannedWordsRule struct {
+	Severity string             `json:"severity"`
+	ByRating map[int16][]string `json:"by_rating"`
+}
+
+// AxisLabelBalanceRule keeps axis labels short enough for the mobile layout
+// and keeps opposite labels (or the buckets of one axis set) of similar
+// length. A zero MaxLength or MaxRatio skips that half of the check.
+type AxisLabelBalanceRule struct {
+	Severity  string  `json:"severity"`
+	MaxLength int     `json:"max_length"`
+	MaxRatio  float64 `json:"max_ratio"`
+}
+
+// RatingClassifierRule flags prompts and axis sets whose text looks too
+// permissive for their min_rating, using the built-in contentrating word
+// lists.
+type RatingClassifierRule struct {
+	Severity string `json:"severity"`
+}
+
+// RuleViolation is one finding from RuleSet.Check. Item is the prompt or
+// axis set slug, prefixed with "axis " for axis sets.
+type RuleViolation struct {
+	Rule     string
+	Severity string
+	Item     string
+	Message  string
+}
+
+func (v RuleViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Item, v.Message)
+}
+
+// LoadRuleSet reads and checks a rules config file.
+func LoadRuleSet(path string) (RuleSet, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return RuleSet{}, err
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.DisallowUnknownFields()
+
+	var rules RuleSet
+	if err := dec.Decode(&rules); err != nil {
+		return RuleSet{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := rules.Validate(); err != nil {
+		return RuleSet{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Validate checks the config itself: known severities and usable limits.
+func (rs RuleSet) Validate() error {
+	var errs []error
+	if r := rs.MaxPromptLength; r != nil {
+		errs = append(errs, validateSeverity("max_prompt_length", r.Severity))
+		if r.Max <= 0 {
+			errs = append(errs, fmt.Errorf("max_prompt_length.max must be positive, got %d", r.Max))
+		}
+	}
+	if r := rs.MinAxesPerPrompt; r != nil {
+		errs = append(errs, validateSeverity("min_axes_per_prompt", r.Severity))
+		if r.Min <= 0 {
+			errs = append(errs, fmt.Errorf("min_axes_per_prompt.min must be positive, got %d", r.Min))
+		}
+	}
+	if r := rs.BannedWords; r != nil {
+		errs = append(errs, validateSeverity("banned_words", r.Severity))
+		for rating, words := range r.ByRating {
+			if !contentrating.IsValid(rating) {
+				errs = append(errs, fmt.Errorf("banned_words.by_rating has invalid rating %d", rating))
+			}
+			for _, word := range words {
+				if len(wordTokens(word)) == 0 {
+					errs = append(errs, fmt.Errorf("banned_words.by_rating[%d] has an empty word", rating))
+				}
+			}
+		}
+	}
+	if r := rs.AxisLabelBalance; r != nil {
+		errs = append(errs, validateSeverity("axis_label_balance", r.Severity))
+		if r.MaxLength < 0 || r.MaxRatio < 0 || (r.MaxLength == 0 && r.MaxRatio == 0) {
+			errs = append(errs, errors.New("axis_label_balance needs a positive max_length or max_ratio"))
+		}
+		if r.MaxRatio > 0 && r.MaxRatio < 1 {
+			errs = append(errs, fmt.Errorf("axis_label_balance.max_ratio must be at least 1, got %g", r.MaxRatio))
+		}
+	}
+	if r := rs.RatingClassifier; r != nil {
+		errs = append(errs, validateSeverity("rating_classifier", r.Severity))
+	}
+	return errors.Join(errs...)
+}
+
+func validateSeverity(rule string, severity string) error {
+	switch severity {
+	case SeverityError, SeverityWarning, SeverityOff:
+		return nil
+	default:
+		return fmt.Errorf("%s.severity must be error, warning, or off, got %q", rule, severity)
+	}
+}
+
+// Check runs the enabled rules against lib. Violations are grouped by rule,
+// in the order the rules are declared on RuleSet, then by library order.
+func (rs RuleSet) Check(lib Library) []RuleViolation {
+	var out []RuleViolation
+	add := func(rule, severity, item, format string, args ...any) {
+		out = append(out, RuleViolation{Rule: rule, Severity: severity, Item: item, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if r := rs.MaxPromptLength; r != nil && r.Severity != SeverityOff {
+		for _, p := range lib.Prompts {
+			if n := utf8.RuneCountInString(p.Text); n > r.Max {
+				add(RuleMaxPromptLength, r.Severity, p.Slug, "text is %d characters (max %d)", n, r.Max)
+			}
+		}
+	}
+
+	if r := rs.MinAxesPerPrompt; r != nil && r.Severity != SeverityOff {
+		for _, p := range lib.Prompts {
+			if n := len(p.AxisSlugs); n < r.Min {
+				add(RuleMinAxesPerPrompt, r.Severity, p.Slug, "has %d axis sets (min %d)", n, r.Min)
+			}
+		}
+	}
+
+	if r := rs.BannedWords; r != nil && r.Severity != SeverityOff {
+		for _, a := range lib.AxisSets {
+			labels := append([]string{a.XMinLabel, a.XMaxLabel, a.YMinLabel, a.YMaxLabel}, a.Buckets...)
+			if hits := r.matches(strings.Join(labels, " "), a.MinRating); len(hits) > 0 {
+				add(RuleBannedWords, r.Severity, "axis "+a.Slug, "labels use %s, not allowed at rating %d", quoteList(hits), a.MinRating)
+			}
+		}
+		for _, p := range lib.Prompts {
+			if hits := r.matches(p.Text, p.MinRating); len(hits) > 0 {
+				add(RuleBannedWords, r.Severity, p.Slug, "text uses %s, not allowed at rating %d", quoteList(hits), p.MinRating)
+			}
+		}
+	}
+
+	if r := rs.AxisLabelBalance; r != nil && r.Severity != SeverityOff {
+		for _, a := range lib.AxisSets {
+			for _, msg := range r.check(a) {
+				add(RuleAxisLabelBalance, r.Severity, "axis "+a.Slug, "%s", msg)
+			}
+		}
+	}
+
+	if r := rs.RatingClassifier; r != nil && r.Severity != SeverityOff {
+		for _, f := range contentrating.Check(RatingItems(lib)) {
+			add(RuleRatingClassifier, r.Severity, f.ID, "%s", f.Summary())
+		}
+	}
+
+	return out
+}
+
+// RatingItems lists the axis sets and prompts in lib for the content rating
+// checks, with the same item names RuleSet.Check reports.
+func RatingItems(lib Library) []contentrating.Item {
+	items := make([]contentrating.Item, 0, len(lib.AxisSets)+len(lib.Prompts))
+	for _, a := range lib.AxisSets {
+		labels := append([]string{a.XMinLabel, a.XMaxLabel, a.YMinLabel, a.YMaxLabel}, a.Buckets...)
+		items = append(items, contentrating.Item{ID: "axis " + a.Slug, Rating: a.MinRating, Text: strings.Join(strings.Fields(strings.Join(labels, " ")), " ")})
+	}
+	for _, p := range lib.Prompts {
+		items = append(items, contentrating.Item{ID: p.Slug, Rating: p.MinRating, Text: p.Text})
+	}
+	return items
+}
+
+// matches returns the banned words in text for content rated minRating.
+func (r BannedWordsRule) matches(text string, minRating int16) []string {
+	padded := " " + strings.Join(wordTokens(text), " ") + " "
+	var hits []string
+	for _, rating := range sortedRatings(r.ByRating) {
+		if rating < minRating {
+			continue
+		}
+		for _, word := range r.ByRating[rating] {
+			phrase := strings.Join(wordTokens(word), " ")
+			if strings.Contains(padded, " "+phrase+" ") && !slices.Contains(hits, phrase) {
+				hits = append(hits, phrase)
+			}
+		}
+	}
+	return hits
+}
+
+// labelGroup is a set of labels shown side by side: an axis's two ends, or
+// an axis set's buckets.
+type labelGroup struct {
+	name   string
+	labels []string
+}
+
+// check returns one message per label that is too long and per group that
+// is lopsided.
+func (r AxisLabelBalanceRule) check(a AxisSet) []string {
+	var groups []labelGroup
+	switch a.EffectiveMode() {
+	case ModePlane:
+		groups = []labelGroup{{"x labels", []string{a.XMinLabel, a.XMaxLabel}}, {"y labels", []string{a.YMinLabel, a.YMaxLabel}}}
+	case ModeSpectrum:
+		groups = []labelGroup{{"x labels", []string{a.XMinLabel, a.XMaxLabel}}}
+	case ModeBuckets:
+		groups = []labelGroup{{"buckets", a.Buckets}}
+	}
+
+	var msgs []string
+	for _, g := range groups {
+		shortest, longest := -1, 0
+		for _, label := range g.labels {
+			n := utf8.RuneCountInString(strings.TrimSpace(label))
+			if r.MaxLength > 0 && n > r.MaxLength {
+				msgs = append(msgs, fmt.Sprintf("%s: %q is %d characters (max %d)", g.name, label, n, r.MaxLength))
+			}
+			if shortest < 0 || n < shortest {
+				shortest = n
+			}
+			if n > longest {
+				longest = n
+			}
+		}
+		if r.MaxRatio > 0 && shortest > 0 {
+			if ratio := float64(longest) / float64(shortest); ratio > r.MaxRatio {
+				msgs = append(msgs, fmt.Sprintf("%s: longest label is %.1fx the shortest (max %.1fx)", g.name, ratio, r.MaxRatio))
+			}
+		}
+	}
+	return msgs
+}
+
+// wordTokens lower-cases text and splits it into words, keeping inner
+// apostrophes so "don't" stays one word.
+func wordTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+}
+
+func sortedRatings(m map[int16][]string) []int16 {
+	ratings := make([]int16, 0, len(m))
+	for rating := range m {
+		ratings = append(ratings, rating)
+	}
+	slices.Sort(ratings)
+	return ratings
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// CountBySeverity tallies violations into errors and warnings.
+func CountBySeverity(violations []RuleViolation) (errs int, warnings int) {
+	for _, v := range violations {
+		switch v.Severity {
+		case SeverityError:
+			errs++
+		case SeverityWarning:
+			warnings++
+		}
+	}
+	return errs, warnings
+}
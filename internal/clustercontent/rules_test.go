@@ -0,0 +1,115 @@
+package clustercontent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func rulesLibrary() Library {
+	return Library{
+		Version:        "v1",
+		CreatedByLabel: "cluster-studio",
+		AxisSets: []AxisSet{
+			{Slug: "cheap-pricey", XMinLabel: "Cheap", XMaxLabel: "Worth every single penny", YMinLabel: "Slow", YMaxLabel: "Fast", MinRating: 10},
+			{Slug: "drinks", Mode: ModeBuckets, Buckets: []string{"Water", "Beer"}, MinRating: 20},
+		},
+		Prompts: []Prompt{
+			{Slug: "snacks", Text: "Best snack for a road trip", MinRating: 10, AxisSlugs: []string{"cheap-pricey"}},
+			{Slug: "party", Text: "How drunk is too drunk at the office party?", MinRating: 20, AxisSlugs: []string{"cheap-pricey", "drinks"}},
+			{Slug: "nightcap", Text: "Best nightcap: beer or wine?", MinRating: 30, AxisSlugs: []string{"cheap-pricey", "drinks"}},
+		},
+	}
+}
+
+func TestRuleSetCheck(t *testing.T) {
+	rules := RuleSet{
+		MaxPromptLength:  &MaxPromptLengthRule{Severity: SeverityError, Max: 30},
+		MinAxesPerPrompt: &MinAxesPerPromptRule{Severity: SeverityWarning, Min: 2},
+		BannedWords: &BannedWordsRule{Severity: SeverityError, ByRating: map[int16][]string{
+			10: {"beer"},
+			20: {"Drunk", "road trip"},
+		}},
+		AxisLabelBalance: &AxisLabelBalanceRule{Severity: SeverityWarning, MaxLength: 20, MaxRatio: 3},
+	}
+
+	var got []string
+	for _, v := range rules.Check(rulesLibrary()) {
+		got = append(got, v.Severity+" "+v.Rule+" "+v.String())
+	}
+	want := []string{
+		"error max-prompt-length party: text is 43 characters (max 30)",
+		"warning min-axes-per-prompt snacks: has 1 axis sets (min 2)",
+		`error banned-words snacks: text uses "road trip", not allowed at rating 10`,
+		`error banned-words party: text uses "drunk", not allowed at rating 20`,
+		`warning axis-label-balance axis cheap-pricey: x labels: "Worth every single penny" is 24 characters (max 20)`,
+		"warning axis-label-balance axis cheap-pricey: x labels: longest label is 4.8x the shortest (max 3.0x)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	errs, warnings := CountBySeverity(rules.Check(rulesLibrary()))
+	if errs != 3 || warnings != 3 {
+		t.Fatalf("errors=%d warnings=%d", errs, warnings)
+	}
+}
+
+func TestRuleSetCheckSkipsOffRules(t *testing.T) {
+	rules := RuleSet{MaxPromptLength: &MaxPromptLengthRule{Severity: SeverityOff, Max: 1}}
+	if v := rules.Check(rulesLibrary()); len(v) != 0 {
+		t.Fatalf("expected no violations, got %v", v)
+	}
+}
+
+func TestLoadRuleSetRejectsBadConfig(t *testing.T) {
+	cases := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "unknown rule", body: `{"max_words": {"severity": "error"}}`, wantErr: "unknown field"},
+		{name: "bad severity", body: `{"max_prompt_length": {"severity": "fatal", "max": 10}}`, wantErr: "must be error, warning, or off"},
+		{name: "zero max", body: `{"max_prompt_length": {"severity": "error"}}`, wantErr: "must be positive"},
+		{name: "bad rating", body: `{"banned_words": {"severity": "error", "by_rating": {"15": ["x"]}}}`, wantErr: "invalid rating 15"},
+		{name: "no limits", body: `{"axis_label_balance": {"severity": "warning"}}`, wantErr: "needs a positive"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+			if err := os.WriteFile(path, []byte(tc.body), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadRuleSet(path)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRepoRulesConfigLoads(t *testing.T) {
+	if _, err := LoadRuleSet("../../content/cluster/rules.json"); err != nil {
+		t.Fatalf("load repo rules: %v", err)
+	}
+}
+
+func TestRuleSetCheckRatingClassifier(t *testing.T) {
+	lib := rulesLibrary()
+	lib.Prompts[0].Text = "Best beer for a road trip"
+	lib.AxisSets[1].MinRating = 10
+
+	rules := RuleSet{RatingClassifier: &RatingClassifierRule{Severity: SeverityWarning}}
+	var got []string
+	for _, v := range rules.Check(lib) {
+		got = append(got, v.Rule+" "+v.String())
+	}
+	want := []string{
+		`rating-classifier axis drinks: rated Mild (10) but "beer" suggests Polite (20)`,
+		`rating-classifier snacks: rated Mild (10) but "beer" suggests Polite (20)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected violations:\n%s", strings.Join(got, "\n"))
+	}
+}
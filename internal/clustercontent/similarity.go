@@ -0,0 +1,239 @@
+package clustercontent
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DefaultSimilarityThreshold flags pairs that share most of their words in
+// roughly the same order, such as prompts that differ by a single word.
+const DefaultSimilarityThreshold = 0.75
+
+// StudioAnalysisOptions tunes AnalyzeStudioWithOptions. The zero value uses
+// the defaults.
+type StudioAnalysisOptions struct {
+	// SimilarityThreshold is the score (0-1) at which two prompts or axis sets
+	// count as near-duplicates. Zero means DefaultSimilarityThreshold; a
+	// negative value turns fuzzy matching off.
+	SimilarityThreshold float64
+}
+
+func (o StudioAnalysisOptions) similarityThreshold() float64 {
+	if o.SimilarityThreshold == 0 {
+		return DefaultSimilarityThreshold
+	}
+	return o.SimilarityThreshold
+}
+
+// SimilarityCluster is a group of slugs linked by pairwise similarity at or
+// above the threshold. Score is the strongest link in the group.
+type SimilarityCluster struct {
+	Slugs []string
+	Score float64
+}
+
+func (c SimilarityCluster) String() string {
+	return fmt.Sprintf("%s (%.0f%%)", strings.Join(c.Slugs, ", "), c.Score*100)
+}
+
+// TextSimilarity scores two strings from 0 to 1 by averaging the Jaccard
+// overlap of their word sets with a word-level edit distance, so a changed
+// word costs less than a reordered or rewritten sentence.
+func TextSimilarity(a, b string) float64 {
+	ta, tb := similarityTokens(a), similarityTokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	return (tokenJaccard(ta, tb) + tokenEditSimilarity(ta, tb)) / 2
+}
+
+// AxisSetSimilarity scores two axis sets from 0 to 1. Plane and spectrum sets
+// compare label pairs regardless of direction, so mirrored or swapped axes
+// still match; bucket sets compare their bucket labels.
+func AxisSetSimilarity(a, b AxisSet) float64 {
+	if a.EffectiveMode() != b.EffectiveMode() {
+		return 0
+	}
+	switch a.EffectiveMode() {
+	case ModeBuckets:
+		return bucketSimilarity(a.Buckets, b.Buckets)
+	case ModeSpectrum:
+		return labelPairSimilarity(a.XMinLabel, a.XMaxLabel, b.XMinLabel, b.XMaxLabel)
+	default:
+		xx := labelPairSimilarity(a.XMinLabel, a.XMaxLabel, b.XMinLabel, b.XMaxLabel)
+		yy := labelPairSimilarity(a.YMinLabel, a.YMaxLabel, b.YMinLabel, b.YMaxLabel)
+		xy := labelPairSimilarity(a.XMinLabel, a.XMaxLabel, b.YMinLabel, b.YMaxLabel)
+		yx := labelPairSimilarity(a.YMinLabel, a.YMaxLabel, b.XMinLabel, b.XMaxLabel)
+		return math.Max((xx+yy)/2, (xy+yx)/2)
+	}
+}
+
+// findNearDuplicatePrompts skips archived prompts and pairs that are already
+// reported as exact duplicates.
+func findNearDuplicatePrompts(prompts []StudioPrompt, threshold float64) []SimilarityCluster {
+	var live []StudioPrompt
+	for _, p := range prompts {
+		if normalizeStudioStatus(p.Status) != "archived" && strings.TrimSpace(p.Text) != "" {
+			live = append(live, p)
+		}
+	}
+
+	slugs := make([]string, len(live))
+	for i, p := range live {
+		slugs[i] = p.Slug
+	}
+	return clusterBySimilarity(slugs, threshold, func(i, j int) float64 {
+		if normalizeDuplicateTextKey(live[i].Text) == normalizeDuplicateTextKey(live[j].Text) {
+			return 0
+		}
+		return TextSimilarity(live[i].Text, live[j].Text)
+	})
+}
+
+func findNearDuplicateAxisSets(axes []AxisSet, threshold float64) []SimilarityCluster {
+	slugs := make([]string, len(axes))
+	for i, axis := range axes {
+		slugs[i] = axis.Slug
+	}
+	return clusterBySimilarity(slugs, threshold, func(i, j int) float64 {
+		return AxisSetSimilarity(axes[i], axes[j])
+	})
+}
+
+// clusterBySimilarity links every pair scoring at or above threshold and
+// returns the connected groups, sorted by their first slug.
+func clusterBySimilarity(slugs []string, threshold float64, score func(i, j int) float64) []SimilarityCluster {
+	if threshold <= 0 || len(slugs) < 2 {
+		return nil
+	}
+
+	parent := make([]int, len(slugs))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	linkScore := make([]float64, len(slugs))
+	for i := 0; i < len(slugs); i++ {
+		for j := i + 1; j < len(slugs); j++ {
+			s := score(i, j)
+			if s < threshold {
+				continue
+			}
+			ri, rj := find(i), find(j)
+			best := math.Max(s, math.Max(linkScore[ri], linkScore[rj]))
+			parent[rj] = ri
+			linkScore[ri] = best
+		}
+	}
+
+	groups := map[int][]string{}
+	for i, slug := range slugs {
+		root := find(i)
+		groups[root] = append(groups[root], slug)
+	}
+
+	var clusters []SimilarityCluster
+	for root, members := range groups {
+		if len(members) < 2 {
+			continue
+		}
+		slices.Sort(members)
+		clusters = append(clusters, SimilarityCluster{Slugs: members, Score: linkScore[root]})
+	}
+	slices.SortFunc(clusters, func(a, b SimilarityCluster) int {
+		return strings.Compare(a.Slugs[0], b.Slugs[0])
+	})
+	return clusters
+}
+
+func similarityTokens(value string) []string {
+	return strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '\''
+	})
+}
+
+func tokenJaccard(a, b []string) float64 {
+	set := make(map[string]uint8, len(a)+len(b))
+	for _, t := range a {
+		set[t] |= 1
+	}
+	for _, t := range b {
+		set[t] |= 2
+	}
+	shared := 0
+	for _, mask := range set {
+		if mask == 3 {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(set))
+}
+
+// tokenEditSimilarity is 1 minus the word-level Levenshtein distance over the
+// longer token count.
+func tokenEditSimilarity(a, b []string) float64 {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	return 1 - float64(prev[len(b)])/float64(longest)
+}
+
+// labelPairSimilarity matches two min/max label pairs in either direction.
+func labelPairSimilarity(aMin, aMax, bMin, bMax string) float64 {
+	forward := (labelSimilarity(aMin, bMin) + labelSimilarity(aMax, bMax)) / 2
+	mirrored := (labelSimilarity(aMin, bMax) + labelSimilarity(aMax, bMin)) / 2
+	return math.Max(forward, mirrored)
+}
+
+// labelSimilarity treats labels that match after normalization as identical,
+// which matters for one-word labels where the token scores are all or nothing.
+func labelSimilarity(a, b string) float64 {
+	if key := normalizeDuplicateTextKey(a); key != "" && key == normalizeDuplicateTextKey(b) {
+		return 1
+	}
+	return TextSimilarity(a, b)
+}
+
+func bucketSimilarity(a, b []string) float64 {
+	keys := func(labels []string) []string {
+		out := make([]string, 0, len(labels))
+		for _, label := range labels {
+			if key := normalizeDuplicateTextKey(label); key != "" {
+				out = append(out, key)
+			}
+		}
+		return out
+	}
+	ka, kb := keys(a), keys(b)
+	if len(ka) == 0 || len(kb) == 0 {
+		return 0
+	}
+	return tokenJaccard(ka, kb)
+}
@@ -0,0 +1,69 @@
+package clustercontent
+
+import "testing"
+
+func TestTextSimilarity(t *testing.T) {
+	oneWord := TextSimilarity("What is the best pizza topping?", "What is the worst pizza topping")
+	if oneWord < DefaultSimilarityThreshold {
+		t.Fatalf("expected one-word change to be a near-duplicate, got %.2f", oneWord)
+	}
+	unrelated := TextSimilarity("What is your favorite color?", "Which chore do you hate most?")
+	if unrelated >= DefaultSimilarityThreshold {
+		t.Fatalf("expected unrelated prompts to score low, got %.2f", unrelated)
+	}
+	if got := TextSimilarity("", "anything"); got != 0 {
+		t.Fatalf("expected empty text to score 0, got %.2f", got)
+	}
+}
+
+func TestAxisSetSimilarityMatchesMirroredLabels(t *testing.T) {
+	base := AxisSet{Slug: "a", XMinLabel: "Cheap", XMaxLabel: "Expensive", YMinLabel: "Boring", YMaxLabel: "Fun"}
+	mirrored := AxisSet{Slug: "b", XMinLabel: "Fun", XMaxLabel: "Boring", YMinLabel: "Expensive", YMaxLabel: "Cheap"}
+	if got := AxisSetSimilarity(base, mirrored); got != 1 {
+		t.Fatalf("expected swapped and mirrored axes to match exactly, got %.2f", got)
+	}
+	other := AxisSet{Slug: "c", XMinLabel: "Old", XMaxLabel: "New", YMinLabel: "Quiet", YMaxLabel: "Loud"}
+	if got := AxisSetSimilarity(base, other); got != 0 {
+		t.Fatalf("expected unrelated axes to score 0, got %.2f", got)
+	}
+	buckets := AxisSet{Slug: "d", Mode: ModeBuckets, Buckets: []string{"Cheap", "Expensive"}}
+	if got := AxisSetSimilarity(base, buckets); got != 0 {
+		t.Fatalf("expected different modes to score 0, got %.2f", got)
+	}
+}
+
+func TestAnalyzeStudioNearDuplicates(t *testing.T) {
+	src := StudioSource{
+		AxisSets: []AxisSet{
+			{Slug: "axis-a", XMinLabel: "Cheap", XMaxLabel: "Expensive", YMinLabel: "Boring", YMaxLabel: "Fun", MinRating: 10},
+			{Slug: "axis-b", XMinLabel: "Expensive", XMaxLabel: "Cheap", YMinLabel: "Boring", YMaxLabel: "Fun", MinRating: 10},
+			{Slug: "axis-c", XMinLabel: "Old", XMaxLabel: "New", YMinLabel: "Quiet", YMaxLabel: "Loud", MinRating: 10},
+		},
+		Prompts: []StudioPrompt{
+			{Slug: "best", Text: "What is the best pizza topping?", MinRating: 20, AxisSlugs: []string{"axis-a"}},
+			{Slug: "worst", Text: "What is the worst pizza topping?", MinRating: 20, AxisSlugs: []string{"axis-b"}},
+			{Slug: "tastiest", Text: "What is the tastiest pizza topping?", MinRating: 20, AxisSlugs: []string{"axis-a"}},
+			{Slug: "retired", Text: "What is the oddest pizza topping?", MinRating: 20, AxisSlugs: []string{"axis-a"}, Status: "archived"},
+			{Slug: "chore", Text: "Which chore do you hate most?", MinRating: 20, AxisSlugs: []string{"axis-c"}},
+		},
+	}
+
+	diag := AnalyzeStudio(src)
+	if len(diag.NearDuplicatePrompts) != 1 {
+		t.Fatalf("expected one near-duplicate prompt cluster, got %+v", diag.NearDuplicatePrompts)
+	}
+	if got := diag.NearDuplicatePrompts[0].Slugs; len(got) != 3 || got[0] != "best" || got[1] != "tastiest" || got[2] != "worst" {
+		t.Fatalf("unexpected prompt cluster: %v", got)
+	}
+	if len(diag.NearDuplicateAxisSets) != 1 || len(diag.NearDuplicateAxisSets[0].Slugs) != 2 {
+		t.Fatalf("expected mirrored axis sets to cluster, got %+v", diag.NearDuplicateAxisSets)
+	}
+	if diag.Summary.NearDuplicatePromptSets != 1 || diag.Summary.NearDuplicateAxisSets != 1 {
+		t.Fatalf("unexpected summary: %+v", diag.Summary)
+	}
+
+	strict := AnalyzeStudioWithOptions(src, StudioAnalysisOptions{SimilarityThreshold: -1})
+	if len(strict.NearDuplicatePrompts) != 0 || len(strict.NearDuplicateAxisSets) != 0 {
+		t.Fatalf("expected negative threshold to disable fuzzy matching, got %+v", strict)
+	}
+}
@@ -159,6 +159,8 @@ func parseAxisSourceHeader(path string, rawHeader []string) (map[string]int, err
 		"y_min_label": true,
 		"y_max_label": true,
 		"min_rating":  true,
+		"mode":        true,
+		"buckets":     true,
 	}
 	required := []string{"slug", "x_min_label", "x_max_label", "y_min_label", "y_max_label", "min_rating"}
 
@@ -198,12 +200,14 @@ func parseAxisSourceRow(path string, rowNum int, record []string, colIndex map[s
 		XMaxLabel: strings.TrimSpace(fieldValue(record, colIndex, "x_max_label")),
 		YMinLabel: strings.TrimSpace(fieldValue(record, colIndex, "y_min_label")),
 		YMaxLabel: strings.TrimSpace(fieldValue(record, colIndex, "y_max_label")),
+		Mode:      strings.ToLower(strings.TrimSpace(fieldValue(record, colIndex, "mode"))),
+		Buckets:   splitPipeList(fieldValue(record, colIndex, "buckets")),
 	}
 	if axis.Slug == "" {
 		errs = append(errs, fmt.Errorf("%s:%d: slug is required", path, rowNum))
 	}
-	if axis.XMinLabel == "" || axis.XMaxLabel == "" || axis.YMinLabel == "" || axis.YMaxLabel == "" {
-		errs = append(errs, fmt.Errorf("%s:%d: all axis labels are required", path, rowNum))
+	if err := validateAxisMode(axis); err != nil {
+		errs = append(errs, fmt.Errorf("%s:%d: axis %w", path, rowNum, err))
 	}
 
 	minRating, err := parsePromptSourceMinRating(fieldValue(record, colIndex, "min_rating"))
@@ -62,3 +62,60 @@ func TestLoadSourceDirFailsWhenAxisFileMissing(t *testing.T) {
 		t.Fatal("expected missing axes.tsv to fail")
 	}
 }
+
+func TestLoadSourceDirReadsAxisModes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "meta.json"), []byte(`{"version":"v1","created_by_label":"x"}`), 0o644); err != nil {
+		t.Fatalf("write meta: %v", err)
+	}
+
+	axes := "slug\tx_min_label\tx_max_label\ty_min_label\ty_max_label\tmin_rating\tmode\tbuckets\n" +
+		"axis-plane\tLow\tHigh\tSlow\tFast\t10\t\t\n" +
+		"axis-line\tNow\tLater\t\t\t10\tspectrum\t\n" +
+		"axis-pick\t\t\t\t\t10\tbuckets\tPlan | Improvise|Delegate\n"
+	if err := os.WriteFile(filepath.Join(dir, "axes.tsv"), []byte(axes), 0o644); err != nil {
+		t.Fatalf("write axes: %v", err)
+	}
+	prompts := "slug\ttext\tmin_rating\taxis_slugs\n" +
+		"prompt-a\tPrompt A\t10\taxis-plane|axis-line|axis-pick\n"
+	if err := os.WriteFile(filepath.Join(dir, "prompts.tsv"), []byte(prompts), 0o644); err != nil {
+		t.Fatalf("write prompts: %v", err)
+	}
+
+	lib, _, err := LoadSourceDir(dir)
+	if err != nil {
+		t.Fatalf("load source dir: %v", err)
+	}
+	if len(lib.AxisSets) != 3 {
+		t.Fatalf("expected 3 axis sets, got %d", len(lib.AxisSets))
+	}
+	if got := lib.AxisSets[0].EffectiveMode(); got != ModePlane {
+		t.Fatalf("expected plane default, got %q", got)
+	}
+	if got := lib.AxisSets[1].EffectiveMode(); got != ModeSpectrum {
+		t.Fatalf("expected spectrum, got %q", got)
+	}
+	pick := lib.AxisSets[2]
+	if pick.EffectiveMode() != ModeBuckets || len(pick.Buckets) != 3 || pick.Buckets[0] != "Plan" {
+		t.Fatalf("unexpected bucket axis: %+v", pick)
+	}
+}
+
+func TestLoadSourceDirRejectsSpectrumWithoutXLabels(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "meta.json"), []byte(`{"version":"v1","created_by_label":"x"}`), 0o644); err != nil {
+		t.Fatalf("write meta: %v", err)
+	}
+	axes := "slug\tx_min_label\tx_max_label\ty_min_label\ty_max_label\tmin_rating\tmode\n" +
+		"axis-line\t\t\tSlow\tFast\t10\tspectrum\n"
+	if err := os.WriteFile(filepath.Join(dir, "axes.tsv"), []byte(axes), 0o644); err != nil {
+		t.Fatalf("write axes: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "prompts.tsv"), []byte("slug\ttext\tmin_rating\taxis_slugs\n"), 0o644); err != nil {
+		t.Fatalf("write prompts: %v", err)
+	}
+
+	if _, _, err := LoadSourceDir(dir); err == nil {
+		t.Fatal("expected spectrum axis without x labels to fail")
+	}
+}
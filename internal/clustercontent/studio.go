@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/jgoodhcg/mindmeld/internal/contentrating"
 )
@@ -14,18 +17,21 @@ import (
 type StudioSource struct {
 	Version        string         `json:"version"`
 	CreatedByLabel string         `json:"created_by_label"`
+	Locales        []string       `json:"locales,omitempty"`
 	AxisSets       []AxisSet      `json:"axis_sets"`
 	Prompts        []StudioPrompt `json:"prompts"`
 }
 
 type StudioPrompt struct {
-	Slug      string   `json:"slug"`
-	Text      string   `json:"text"`
-	MinRating int16    `json:"min_rating"`
-	AxisSlugs []string `json:"axis_slugs"`
-	Theme     string   `json:"theme,omitempty"`
-	Status    string   `json:"status,omitempty"`
-	Notes     string   `json:"notes,omitempty"`
+	Slug         string                       `json:"slug"`
+	Text         string                       `json:"text"`
+	MinRating    int16                        `json:"min_rating"`
+	AxisSlugs    []string                     `json:"axis_slugs"`
+	Theme        string                       `json:"theme,omitempty"`
+	Status       string                       `json:"status,omitempty"`
+	Notes        string                       `json:"notes,omitempty"`
+	Translations map[string]PromptTranslation `json:"translations,omitempty"`
+	Provenance
 }
 
 type StudioReviewSummary struct {
@@ -38,6 +44,9 @@ type StudioReviewSummary struct {
 	OrphanAxisCount         int
 	MissingAxisRefCount     int
 	ExactDuplicateTextCount int
+	NearDuplicatePromptSets int
+	NearDuplicateAxisSets   int
+	MissingTranslations     int
 }
 
 type StudioWarning struct {
@@ -47,9 +56,11 @@ type StudioWarning struct {
 }
 
 type StudioDiagnostics struct {
-	Summary        StudioReviewSummary
-	Warnings       []StudioWarning
-	AxisUsageCount map[string]int
+	Summary               StudioReviewSummary
+	Warnings              []StudioWarning
+	AxisUsageCount        map[string]int
+	NearDuplicatePrompts  []SimilarityCluster
+	NearDuplicateAxisSets []SimilarityCluster
 }
 
 func LoadStudio(path string) (StudioSource, error) {
@@ -68,29 +79,168 @@ func LoadStudio(path string) (StudioSource, error) {
 	return src, nil
 }
 
+// SaveStudio writes src to a temp file next to path and renames it over
+// path, so a crash mid-write never leaves a truncated studio file.
 func SaveStudio(path string, src StudioSource) error {
 	out, err := json.MarshalIndent(src, "", "  ")
 	if err != nil {
 		return err
 	}
 	out = append(out, '\n')
-	return os.WriteFile(path, out, 0o644)
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(out); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// ErrStudioChanged reports that the studio file was written by someone else
+// after the caller loaded it.
+var ErrStudioChanged = errors.New("studio file changed on disk since it was loaded; reload and reapply your edit")
+
+// studioSaveMu makes the check and rename in SaveStudioIfUnchanged one step
+// for saves made by this process, such as concurrent review UI edits.
+var studioSaveMu sync.Mutex
+
+// StudioHash returns a hash of the studio file's bytes, which callers pass
+// back to SaveStudioIfUnchanged as an optimistic concurrency token. Unlike a
+// modification time it changes on every write, however close together.
+func StudioHash(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return hashBytes(raw), nil
+}
+
+// SaveStudioIfUnchanged writes src only if the file still has the hash the
+// caller loaded it at.
+func SaveStudioIfUnchanged(path string, src StudioSource, loadedHash string) error {
+	studioSaveMu.Lock()
+	defer studioSaveMu.Unlock()
+
+	current, err := StudioHash(path)
+	if err != nil {
+		return err
+	}
+	if current != loadedHash {
+		return ErrStudioChanged
+	}
+	return SaveStudio(path, src)
+}
+
+// UpsertPrompt replaces the prompt at originalSlug, or appends p when
+// originalSlug is empty. Field values are trimmed and slugs must stay unique.
+// Edits keep the existing provenance; rewording a generated prompt marks it
+// as AI-assisted.
+func (src *StudioSource) UpsertPrompt(originalSlug string, p StudioPrompt) error {
+	originalSlug = strings.TrimSpace(originalSlug)
+	p.Slug = strings.TrimSpace(p.Slug)
+	p.Text = strings.TrimSpace(p.Text)
+	p.Theme = strings.TrimSpace(p.Theme)
+	p.Notes = strings.TrimSpace(p.Notes)
+	p.Status = normalizeStudioStatus(p.Status)
+
+	axisSlugs := make([]string, 0, len(p.AxisSlugs))
+	for _, slug := range p.AxisSlugs {
+		slug = strings.TrimSpace(slug)
+		if slug != "" && !slices.Contains(axisSlugs, slug) {
+			axisSlugs = append(axisSlugs, slug)
+		}
+	}
+	p.AxisSlugs = axisSlugs
+
+	switch {
+	case p.Slug == "":
+		return errors.New("prompt slug is required")
+	case p.Text == "":
+		return fmt.Errorf("prompt %s: text is required", p.Slug)
+	case p.Status == "unknown":
+		return fmt.Errorf("prompt %s: status must be ready, draft, or archived", p.Slug)
+	case !contentrating.IsValid(p.MinRating):
+		return fmt.Errorf("prompt %s: invalid min_rating %d", p.Slug, p.MinRating)
+	case len(p.AxisSlugs) == 0:
+		return fmt.Errorf("prompt %s: at least one axis slug is required", p.Slug)
+	}
+
+	idx := -1
+	for i, existing := range src.Prompts {
+		if originalSlug != "" && existing.Slug == originalSlug {
+			idx = i
+			continue
+		}
+		if existing.Slug == p.Slug {
+			return fmt.Errorf("prompt slug %q already exists", p.Slug)
+		}
+	}
+	if originalSlug == "" {
+		src.Prompts = append(src.Prompts, p)
+		return nil
+	}
+	if idx < 0 {
+		return fmt.Errorf("prompt %q not found", originalSlug)
+	}
+	existing := src.Prompts[idx]
+	if p.Translations == nil {
+		p.Translations = existing.Translations
+	}
+	if p.Provenance.isZero() {
+		p.Provenance = existing.Provenance
+		if p.AuthoringMode == AuthoringAIGenerated && p.Text != existing.Text {
+			p.AuthoringMode = AuthoringAIAssisted
+		}
+	}
+	src.Prompts[idx] = p
+	return nil
+}
+
+// ArchivePrompt marks a prompt archived. Archived prompts stay in the studio
+// file but are left out of the import library.
+func (src *StudioSource) ArchivePrompt(slug string) error {
+	slug = strings.TrimSpace(slug)
+	for i := range src.Prompts {
+		if src.Prompts[i].Slug == slug {
+			src.Prompts[i].Status = "archived"
+			return nil
+		}
+	}
+	return fmt.Errorf("prompt %q not found", slug)
 }
 
 func StudioFromLibrary(lib Library) StudioSource {
 	src := StudioSource{
 		Version:        lib.Version,
 		CreatedByLabel: lib.CreatedByLabel,
+		Locales:        slices.Clone(lib.Locales),
 		AxisSets:       slices.Clone(lib.AxisSets),
 		Prompts:        make([]StudioPrompt, 0, len(lib.Prompts)),
 	}
 	for _, prompt := range lib.Prompts {
 		src.Prompts = append(src.Prompts, StudioPrompt{
-			Slug:      prompt.Slug,
-			Text:      prompt.Text,
-			MinRating: prompt.MinRating,
-			AxisSlugs: slices.Clone(prompt.AxisSlugs),
-			Status:    "ready",
+			Slug:         prompt.Slug,
+			Text:         prompt.Text,
+			MinRating:    prompt.MinRating,
+			AxisSlugs:    slices.Clone(prompt.AxisSlugs),
+			Theme:        prompt.Theme,
+			Status:       "ready",
+			Translations: maps.Clone(prompt.Translations),
+			Provenance:   prompt.Provenance.clone(),
 		})
 	}
 	return src
@@ -100,6 +250,7 @@ func (src StudioSource) ToLibrary() Library {
 	lib := Library{
 		Version:        src.Version,
 		CreatedByLabel: src.CreatedByLabel,
+		Locales:        slices.Clone(src.Locales),
 		AxisSets:       slices.Clone(src.AxisSets),
 		Prompts:        make([]Prompt, 0, len(src.Prompts)),
 	}
@@ -108,17 +259,24 @@ func (src StudioSource) ToLibrary() Library {
 			continue
 		}
 		lib.Prompts = append(lib.Prompts, Prompt{
-			Slug:      prompt.Slug,
-			Text:      prompt.Text,
-			MinRating: prompt.MinRating,
-			AxisSlugs: slices.Clone(prompt.AxisSlugs),
+			Slug:         prompt.Slug,
+			Text:         prompt.Text,
+			MinRating:    prompt.MinRating,
+			AxisSlugs:    slices.Clone(prompt.AxisSlugs),
+			Theme:        strings.TrimSpace(prompt.Theme),
+			Translations: maps.Clone(prompt.Translations),
+			Provenance:   prompt.Provenance.clone(),
 		})
 	}
 	return lib
 }
 
 func ValidateStudio(src StudioSource) (StudioDiagnostics, error) {
-	diag := AnalyzeStudio(src)
+	return ValidateStudioWithOptions(src, StudioAnalysisOptions{})
+}
+
+func ValidateStudioWithOptions(src StudioSource, opts StudioAnalysisOptions) (StudioDiagnostics, error) {
+	diag := AnalyzeStudioWithOptions(src, opts)
 
 	// Reuse existing runtime validation for the importable subset (ready prompts).
 	if _, _, err := Validate(src.ToLibrary()); err != nil {
@@ -128,6 +286,10 @@ func ValidateStudio(src StudioSource) (StudioDiagnostics, error) {
 }
 
 func AnalyzeStudio(src StudioSource) StudioDiagnostics {
+	return AnalyzeStudioWithOptions(src, StudioAnalysisOptions{})
+}
+
+func AnalyzeStudioWithOptions(src StudioSource, opts StudioAnalysisOptions) StudioDiagnostics {
 	axisBySlug := make(map[string]AxisSet, len(src.AxisSets))
 	axisUsage := make(map[string]int, len(src.AxisSets))
 	for _, axis := range src.AxisSets {
@@ -202,6 +364,26 @@ func AnalyzeStudio(src StudioSource) StudioDiagnostics {
 		})
 	}
 
+	threshold := opts.similarityThreshold()
+	nearPrompts := findNearDuplicatePrompts(src.Prompts, threshold)
+	if len(nearPrompts) > 0 {
+		summary.NearDuplicatePromptSets = len(nearPrompts)
+		warnings = append(warnings, StudioWarning{
+			Code:    "near-duplicate-text",
+			Message: fmt.Sprintf("Near-duplicate prompt text detected (similarity >= %.0f%%)", threshold*100),
+			Items:   limitStrings(similarityItems(nearPrompts), 12),
+		})
+	}
+	nearAxes := findNearDuplicateAxisSets(src.AxisSets, threshold)
+	if len(nearAxes) > 0 {
+		summary.NearDuplicateAxisSets = len(nearAxes)
+		warnings = append(warnings, StudioWarning{
+			Code:    "near-duplicate-axis",
+			Message: fmt.Sprintf("Near-duplicate axis sets detected, including mirrored or swapped labels (similarity >= %.0f%%)", threshold*100),
+			Items:   limitStrings(similarityItems(nearAxes), 12),
+		})
+	}
+
 	var orphanAxes []string
 	for slug, count := range axisUsage {
 		if count == 0 {
@@ -218,11 +400,30 @@ func AnalyzeStudio(src StudioSource) StudioDiagnostics {
 		})
 	}
 
+	if missing := findMissingTranslations(src); len(missing) > 0 {
+		summary.MissingTranslations = len(missing)
+		warnings = append(warnings, StudioWarning{
+			Code:    "missing-translation",
+			Message: "Prompts or axis sets lack text for a declared locale",
+			Items:   limitStrings(missing, 12),
+		})
+	}
+
 	return StudioDiagnostics{
-		Summary:        summary,
-		Warnings:       warnings,
-		AxisUsageCount: axisUsage,
+		Summary:               summary,
+		Warnings:              warnings,
+		AxisUsageCount:        axisUsage,
+		NearDuplicatePrompts:  nearPrompts,
+		NearDuplicateAxisSets: nearAxes,
+	}
+}
+
+func similarityItems(clusters []SimilarityCluster) []string {
+	items := make([]string, 0, len(clusters))
+	for _, c := range clusters {
+		items = append(items, c.String())
 	}
+	return items
 }
 
 func normalizeStudioStatus(value string) string {
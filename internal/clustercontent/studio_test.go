@@ -1,6 +1,7 @@
 package clustercontent
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -90,3 +91,85 @@ func TestLoadStudioOrLibraryFallsBackToLibrary(t *testing.T) {
 		t.Fatalf("expected library conversion to treat empty status as ready: %+v", src.Prompts)
 	}
 }
+
+func TestStudioUpsertAndArchivePrompt(t *testing.T) {
+	src := StudioSource{
+		Prompts: []StudioPrompt{
+			{Slug: "p1", Text: "First", MinRating: 20, AxisSlugs: []string{"axis-a"}, Status: "ready"},
+			{Slug: "p2", Text: "Second", MinRating: 20, AxisSlugs: []string{"axis-a"}, Status: "draft"},
+		},
+	}
+
+	if err := src.UpsertPrompt("p1", StudioPrompt{Slug: " p1 ", Text: " Edited ", MinRating: 10, AxisSlugs: []string{"axis-a", " axis-a ", "axis-b"}, Theme: " work "}); err != nil {
+		t.Fatalf("edit prompt: %v", err)
+	}
+	got := src.Prompts[0]
+	if got.Text != "Edited" || got.Theme != "work" || got.Status != "ready" || len(got.AxisSlugs) != 2 {
+		t.Fatalf("unexpected edited prompt: %+v", got)
+	}
+
+	if err := src.UpsertPrompt("p1", StudioPrompt{Slug: "p2", Text: "Rename", MinRating: 10, AxisSlugs: []string{"axis-a"}}); err == nil {
+		t.Fatal("expected rename onto an existing slug to fail")
+	}
+	if err := src.UpsertPrompt("", StudioPrompt{Slug: "p3", Text: "New", MinRating: 15, AxisSlugs: []string{"axis-a"}}); err == nil {
+		t.Fatal("expected invalid rating to fail")
+	}
+	if err := src.UpsertPrompt("", StudioPrompt{Slug: "p3", Text: "New", MinRating: 30, AxisSlugs: []string{"axis-a"}, Status: "draft"}); err != nil {
+		t.Fatalf("create prompt: %v", err)
+	}
+	if len(src.Prompts) != 3 || src.Prompts[2].Slug != "p3" {
+		t.Fatalf("expected created prompt to be appended: %+v", src.Prompts)
+	}
+
+	if err := src.ArchivePrompt("p2"); err != nil {
+		t.Fatalf("archive prompt: %v", err)
+	}
+	if src.Prompts[1].Status != "archived" {
+		t.Fatalf("expected p2 archived: %+v", src.Prompts[1])
+	}
+	if err := src.ArchivePrompt("missing"); err == nil {
+		t.Fatal("expected archiving a missing prompt to fail")
+	}
+}
+
+func TestSaveStudioIfUnchangedRejectsStaleWrites(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "studio.json")
+	src := StudioSource{Version: "v1", CreatedByLabel: "cluster-studio"}
+	if err := SaveStudio(path, src); err != nil {
+		t.Fatalf("save studio: %v", err)
+	}
+	loaded, err := StudioHash(path)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+
+	// A write in the same second as the load still counts as a change.
+	other := src
+	other.Version = "v2"
+	if err := SaveStudio(path, other); err != nil {
+		t.Fatalf("save other: %v", err)
+	}
+	if err := SaveStudioIfUnchanged(path, src, loaded); !errors.Is(err, ErrStudioChanged) {
+		t.Fatalf("expected ErrStudioChanged, got %v", err)
+	}
+
+	current, err := StudioHash(path)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	if err := SaveStudioIfUnchanged(path, src, current); err != nil {
+		t.Fatalf("expected save at the current hash to succeed: %v", err)
+	}
+	if got, err := LoadStudio(path); err != nil || got.Version != "v1" {
+		t.Fatalf("expected v1 on disk, got %+v (%v)", got, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0o644 {
+		t.Fatalf("expected a 0644 studio file, got %v (%v)", info, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected no temp files left behind, got %v (%v)", entries, err)
+	}
+}
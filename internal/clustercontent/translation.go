@@ -0,0 +1,171 @@
+package clustercontent
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jgoodhcg/mindmeld/internal/contentlocale"
+)
+
+// PromptTranslation is a prompt's text in one non-default locale.
+type PromptTranslation struct {
+	Text string `json:"text"`
+}
+
+// AxisTranslation holds an axis set's labels in one non-default locale. Only
+// the labels the axis mode uses need to be filled in; the game falls back to
+// the default-locale label for anything left blank.
+type AxisTranslation struct {
+	XMinLabel string   `json:"x_min_label,omitempty"`
+	XMaxLabel string   `json:"x_max_label,omitempty"`
+	YMinLabel string   `json:"y_min_label,omitempty"`
+	YMaxLabel string   `json:"y_max_label,omitempty"`
+	Buckets   []string `json:"buckets,omitempty"`
+}
+
+// validateLocales checks the locales a library declares translations for.
+// The default locale is the authored text, so it is never listed.
+func validateLocales(locales []string) []error {
+	var errs []error
+	seen := make(map[string]bool, len(locales))
+	for _, locale := range locales {
+		switch {
+		case !contentlocale.IsValid(locale):
+			errs = append(errs, fmt.Errorf("locales has unsupported locale %q", locale))
+		case locale == contentlocale.Default():
+			errs = append(errs, fmt.Errorf("locales should not list the default locale %q", locale))
+		case seen[locale]:
+			errs = append(errs, fmt.Errorf("locales lists %q twice", locale))
+		}
+		seen[locale] = true
+	}
+	return errs
+}
+
+// validatePromptTranslations checks a prompt's translations against the
+// declared locales. Error text continues the caller's "prompts[i]" prefix.
+func validatePromptTranslations(translations map[string]PromptTranslation, locales []string) error {
+	for _, locale := range sortedKeys(translations) {
+		if !slices.Contains(locales, locale) {
+			return fmt.Errorf("has a translation for undeclared locale %q", locale)
+		}
+		if strings.TrimSpace(translations[locale].Text) == "" {
+			return fmt.Errorf("has empty %s translation text", locale)
+		}
+	}
+	return nil
+}
+
+// validateAxisTranslations checks an axis set's translations against the
+// declared locales. Translated buckets must line up one-to-one with the
+// default-locale buckets because answers are stored by bucket index. Error
+// text continues the caller's "axis_sets[i]" prefix.
+func validateAxisTranslations(axis AxisSet, locales []string) error {
+	for _, locale := range sortedKeys(axis.Translations) {
+		if !slices.Contains(locales, locale) {
+			return fmt.Errorf("has a translation for undeclared locale %q", locale)
+		}
+		tr := axis.Translations[locale]
+		if len(tr.Buckets) == 0 {
+			continue
+		}
+		if axis.EffectiveMode() != ModeBuckets {
+			return fmt.Errorf("has %s bucket translations but is not in buckets mode", locale)
+		}
+		if len(tr.Buckets) != len(axis.Buckets) {
+			return fmt.Errorf("has %d %s bucket translations for %d buckets", len(tr.Buckets), locale, len(axis.Buckets))
+		}
+		for _, label := range tr.Buckets {
+			if strings.TrimSpace(label) == "" {
+				return fmt.Errorf("has an empty %s bucket translation", locale)
+			}
+		}
+	}
+	return nil
+}
+
+// missingAxisLabels names the labels the axis mode shows that tr leaves
+// blank.
+func missingAxisLabels(axis AxisSet, tr AxisTranslation) []string {
+	var missing []string
+	check := func(name, value string) {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, name)
+		}
+	}
+	switch axis.EffectiveMode() {
+	case ModePlane:
+		check("x_min_label", tr.XMinLabel)
+		check("x_max_label", tr.XMaxLabel)
+		check("y_min_label", tr.YMinLabel)
+		check("y_max_label", tr.YMaxLabel)
+	case ModeSpectrum:
+		check("x_min_label", tr.XMinLabel)
+		check("x_max_label", tr.XMaxLabel)
+	case ModeBuckets:
+		if len(tr.Buckets) == 0 {
+			missing = append(missing, "buckets")
+		}
+	}
+	return missing
+}
+
+// findMissingTranslations lists non-archived prompts and axis sets that lack
+// a declared locale, or only partly cover the labels their mode shows.
+func findMissingTranslations(src StudioSource) []string {
+	var items []string
+	for _, locale := range src.Locales {
+		for _, p := range src.Prompts {
+			if normalizeStudioStatus(p.Status) == "archived" {
+				continue
+			}
+			if tr, ok := p.Translations[locale]; !ok || strings.TrimSpace(tr.Text) == "" {
+				items = append(items, fmt.Sprintf("%s (%s)", p.Slug, locale))
+			}
+		}
+		for _, axis := range src.AxisSets {
+			tr, ok := axis.Translations[locale]
+			if !ok {
+				items = append(items, fmt.Sprintf("axis %s (%s)", axis.Slug, locale))
+				continue
+			}
+			if missing := missingAxisLabels(axis, tr); len(missing) > 0 {
+				items = append(items, fmt.Sprintf("axis %s (%s: %s)", axis.Slug, locale, strings.Join(missing, ", ")))
+			}
+		}
+	}
+	return items
+}
+
+// translationsJSON builds the translations column value, an empty object when
+// there are none.
+func translationsJSON[T any](translations map[string]T) string {
+	if len(translations) == 0 {
+		return "{}"
+	}
+	raw, _ := json.Marshal(translations)
+	return string(raw)
+}
+
+// decodeTranslations reads a translations column back into a map. An empty
+// object decodes to nil so round-tripped content compares equal.
+func decodeTranslations[T any](raw []byte) (map[string]T, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var out map[string]T
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("decode translations: %w", err)
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	return slices.Sorted(maps.Keys(m))
+}
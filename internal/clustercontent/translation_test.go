@@ -0,0 +1,133 @@
+package clustercontent
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func translatedLibrary() Library {
+	return Library{
+		Version:        "v1",
+		CreatedByLabel: "cluster-studio",
+		Locales:        []string{"es"},
+		AxisSets: []AxisSet{
+			{
+				Slug: "sweet-savory", XMinLabel: "Sweet", XMaxLabel: "Savory", YMinLabel: "Light", YMaxLabel: "Heavy", MinRating: 10,
+				Translations: map[string]AxisTranslation{"es": {XMinLabel: "Dulce", XMaxLabel: "Salado", YMinLabel: "Ligero", YMaxLabel: "Pesado"}},
+			},
+			{
+				Slug: "pets", Mode: ModeBuckets, Buckets: []string{"Cat", "Dog"}, MinRating: 10,
+				Translations: map[string]AxisTranslation{"es": {Buckets: []string{"Gato", "Perro"}}},
+			},
+		},
+		Prompts: []Prompt{{
+			Slug: "breakfast", Text: "Breakfast foods", MinRating: 10, AxisSlugs: []string{"sweet-savory", "pets"},
+			Translations: map[string]PromptTranslation{"es": {Text: "Comidas de desayuno"}},
+		}},
+	}
+}
+
+func TestValidateAcceptsTranslations(t *testing.T) {
+	if _, _, err := Validate(translatedLibrary()); err != nil {
+		t.Fatalf("expected valid library, got %v", err)
+	}
+}
+
+func TestValidateRejectsBadTranslations(t *testing.T) {
+	cases := []struct {
+		name    string
+		mutate  func(*Library)
+		wantErr string
+	}{
+		{name: "unsupported locale", mutate: func(l *Library) { l.Locales = append(l.Locales, "xx") }, wantErr: `unsupported locale "xx"`},
+		{name: "default locale listed", mutate: func(l *Library) { l.Locales = append(l.Locales, "en") }, wantErr: "should not list the default locale"},
+		{name: "undeclared locale", mutate: func(l *Library) { l.Locales = nil }, wantErr: `undeclared locale "es"`},
+		{name: "empty prompt text", mutate: func(l *Library) {
+			l.Prompts[0].Translations["es"] = PromptTranslation{Text: " "}
+		}, wantErr: "prompts[0] has empty es translation text"},
+		{name: "bucket count mismatch", mutate: func(l *Library) {
+			l.AxisSets[1].Translations["es"] = AxisTranslation{Buckets: []string{"Gato"}}
+		}, wantErr: "axis_sets[1] has 1 es bucket translations for 2 buckets"},
+		{name: "buckets outside buckets mode", mutate: func(l *Library) {
+			l.AxisSets[0].Translations["es"] = AxisTranslation{Buckets: []string{"A", "B"}}
+		}, wantErr: "not in buckets mode"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lib := translatedLibrary()
+			tc.mutate(&lib)
+			_, _, err := Validate(lib)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAnalyzeStudioWarnsOnMissingTranslations(t *testing.T) {
+	src := StudioFromLibrary(translatedLibrary())
+	if diag := AnalyzeStudio(src); diag.Summary.MissingTranslations != 0 {
+		t.Fatalf("expected a fully translated studio to pass, got %+v", diag.Warnings)
+	}
+
+	src.AxisSets[0].Translations = map[string]AxisTranslation{"es": {XMinLabel: "Dulce", XMaxLabel: "Salado"}}
+	src.Prompts = append(src.Prompts,
+		StudioPrompt{Slug: "lunch", Text: "Lunch foods", MinRating: 10, AxisSlugs: []string{"pets"}, Status: "draft"},
+		StudioPrompt{Slug: "old", Text: "Old prompt", MinRating: 10, AxisSlugs: []string{"pets"}, Status: "archived"},
+	)
+
+	diag := AnalyzeStudio(src)
+	var items []string
+	for _, w := range diag.Warnings {
+		if w.Code == "missing-translation" {
+			items = w.Items
+		}
+	}
+	want := []string{"lunch (es)", "axis sweet-savory (es: y_min_label, y_max_label)"}
+	if !slices.Equal(items, want) || diag.Summary.MissingTranslations != len(want) {
+		t.Fatalf("got %v (%d), want %v", items, diag.Summary.MissingTranslations, want)
+	}
+}
+
+func TestStudioRoundTripKeepsTranslations(t *testing.T) {
+	lib := translatedLibrary()
+	back := StudioFromLibrary(lib).ToLibrary()
+	if !slices.Equal(back.Locales, lib.Locales) || back.Prompts[0].Translations["es"].Text != "Comidas de desayuno" {
+		t.Fatalf("translations lost in round trip: %+v", back)
+	}
+}
+
+func TestDiffContentComparesTranslations(t *testing.T) {
+	lib := translatedLibrary()
+	_, pairs, err := Validate(lib)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	live := LiveContent{}
+	for _, a := range lib.AxisSets {
+		translations, err := decodeTranslations[AxisTranslation]([]byte(translationsJSON(a.Translations)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		live.AxisSets = append(live.AxisSets, LiveAxisSet{
+			ID: AxisSetUUID(a.Slug), Slug: a.Slug, Mode: a.EffectiveMode(), XMinLabel: a.XMinLabel, XMaxLabel: a.XMaxLabel,
+			YMinLabel: a.YMinLabel, YMaxLabel: a.YMaxLabel, Buckets: a.Buckets, MinRating: a.MinRating, Active: true, Translations: translations,
+		})
+	}
+	p := lib.Prompts[0]
+	live.Prompts = []LivePrompt{{ID: PromptUUID(p.Slug), Slug: p.Slug, Text: p.Text, MinRating: p.MinRating, Active: true}}
+	for _, pair := range pairs {
+		live.Pairs = append(live.Pairs, LivePair{PromptID: PromptUUID(pair.PromptSlug), AxisSetID: AxisSetUUID(pair.AxisSlug), Active: true})
+	}
+
+	diff := DiffContent(lib, pairs, live)
+	if len(diff.AxisSets) != 0 || len(diff.Prompts) != 1 {
+		t.Fatalf("expected only the untranslated prompt row to change, got %+v", diff)
+	}
+	fields := diff.Prompts[0].Fields
+	if len(fields) != 1 || fields[0].Field != "translations" || fields[0].From != "" || !strings.Contains(fields[0].To, "Comidas") {
+		t.Fatalf("unexpected fields %+v", fields)
+	}
+}
@@ -0,0 +1,56 @@
+package contentlocale
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	English = "en"
+	Spanish = "es"
+)
+
+// Default returns the locale content is authored in and falls back to.
+func Default() string {
+	return English
+}
+
+// All lists the supported locales in display order.
+func All() []string {
+	return []string{English, Spanish}
+}
+
+// IsValid reports whether code is a supported locale.
+func IsValid(code string) bool {
+	switch code {
+	case English, Spanish:
+		return true
+	default:
+		return false
+	}
+}
+
+// Parse normalizes a submitted or stored locale code. Empty input means the
+// default locale; anything else unsupported is an error.
+func Parse(raw string) (string, error) {
+	code := strings.ToLower(strings.TrimSpace(raw))
+	if code == "" {
+		return Default(), nil
+	}
+	if !IsValid(code) {
+		return "", fmt.Errorf("unsupported locale %q", raw)
+	}
+	return code, nil
+}
+
+// Label returns the locale's name in its own language, for pickers.
+func Label(code string) string {
+	switch code {
+	case English:
+		return "English"
+	case Spanish:
+		return "Español"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,153 @@
+package contentrating
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// tierTerms lists words and phrases that suggest content needs at least the
+// keyed rating. Matching is case-insensitive on whole words and also accepts
+// a plural "s" or "es". Words with common innocent meanings in trivia ("a
+// murder of crows", "Al Gore", garden weeds) are left out on purpose.
+var tierTerms = map[int16][]string{
+	Work: {
+		"alcohol", "alcoholic", "beer", "wine", "booze", "cocktail", "vodka", "whiskey", "whisky", "tequila",
+		"drunk", "hangover", "tipsy", "bar crawl", "drinking game",
+		"dating", "flirt", "flirting", "hookup", "kissing", "make out", "breakup", "first date",
+		"damn", "crap", "pissed",
+		"casino", "gamble", "gambling",
+		"serial killer", "gory",
+	},
+	Adults: {
+		"sex", "sexy", "sexual", "naked", "nude", "porn", "orgasm", "horny", "kinky", "fetish",
+		"threesome", "one night stand", "strip club", "stripper", "hooker",
+		"fuck", "fucking", "shit", "bitch", "asshole", "bastard",
+		"marijuana", "cocaine", "heroin", "meth", "stoned",
+		"nsfw", "18+",
+	},
+}
+
+// maskedProfanityPattern catches self-censored swears such as "f***" or
+// "sh*t", which only make sense in adult content.
+var maskedProfanityPattern = regexp.MustCompile(`(?i)\b[a-z]{1,3}\*+[a-z]{0,3}`)
+
+// Match is one word-list or heuristic hit and the rating it suggests.
+type Match struct {
+	Term   string
+	Rating int16
+}
+
+func (m Match) String() string {
+	return fmt.Sprintf("%q suggests %s (%d)", m.Term, Label(m.Rating), m.Rating)
+}
+
+// Classification is the rating the word lists suggest for some text, with the
+// matches that pushed it there. Rating is Kids when nothing matched.
+type Classification struct {
+	Rating  int16
+	Matches []Match
+}
+
+// Classify suggests the least permissive rating texts need, from built-in
+// word lists and a few heuristics. It is deliberately conservative: it can
+// only raise a rating, and a clean result does not prove text is Kids-safe.
+func Classify(texts ...string) Classification {
+	joined := strings.Join(texts, " ")
+	padded := " " + strings.Join(classifyTokens(joined), " ") + " "
+
+	c := Classification{Rating: Kids}
+	for _, rating := range []int16{Adults, Work} {
+		for _, term := range tierTerms[rating] {
+			if containsTerm(padded, term) {
+				c.add(Match{Term: term, Rating: rating})
+			}
+		}
+	}
+	if masked := maskedProfanityPattern.FindString(joined); masked != "" {
+		c.add(Match{Term: masked, Rating: Adults})
+	}
+	return c
+}
+
+func (c *Classification) add(m Match) {
+	c.Rating = max(c.Rating, m.Rating)
+	if !slices.Contains(c.Matches, m) {
+		c.Matches = append(c.Matches, m)
+	}
+}
+
+// Above returns the matches that need a stricter rating than assigned, as
+// reasons for a finding.
+func (c Classification) Above(assigned int16) []string {
+	var reasons []string
+	for _, m := range c.Matches {
+		if m.Rating > assigned {
+			reasons = append(reasons, m.String())
+		}
+	}
+	return reasons
+}
+
+// containsTerm reports whether the space-padded token string holds term as
+// whole words, allowing a plural ending on the last word.
+func containsTerm(padded string, term string) bool {
+	phrase := strings.Join(classifyTokens(term), " ")
+	if phrase == "" {
+		return false
+	}
+	for _, suffix := range []string{"", "s", "es"} {
+		if strings.Contains(padded, " "+phrase+suffix+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyTokens lower-cases text and splits it into words. Apostrophes stay
+// inside words and "+" is kept so "18+" survives.
+func classifyTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '+'
+	})
+}
+
+// Item is one piece of content to check at the rating it was assigned. ID is
+// whatever the caller uses to point a reviewer at it, such as a slug.
+type Item struct {
+	ID     string
+	Rating int16
+	Text   string
+}
+
+// Finding is an item whose text suggests a stricter rating than it has.
+type Finding struct {
+	ID        string
+	Assigned  int16
+	Suggested int16
+	Reasons   []string
+}
+
+func (f Finding) String() string {
+	return f.ID + ": " + f.Summary()
+}
+
+// Summary describes the finding without its ID.
+func (f Finding) Summary() string {
+	return fmt.Sprintf("rated %s (%d) but %s", Label(f.Assigned), f.Assigned, strings.Join(f.Reasons, "; "))
+}
+
+// Check classifies each item and returns the ones rated too permissively, in
+// input order.
+func Check(items []Item) []Finding {
+	var findings []Finding
+	for _, item := range items {
+		c := Classify(item.Text)
+		if c.Rating > item.Rating {
+			findings = append(findings, Finding{ID: item.ID, Assigned: item.Rating, Suggested: c.Rating, Reasons: c.Above(item.Rating)})
+		}
+	}
+	return findings
+}
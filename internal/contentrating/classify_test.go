@@ -0,0 +1,69 @@
+package contentrating
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		text string
+		want int16
+		term string
+	}{
+		{text: "What is the capital of France?", want: Kids},
+		{text: "Best snack to share at a bar crawl", want: Work, term: "bar crawl"},
+		{text: "Two Beers or one Wine?", want: Work, term: "beer"},
+		{text: "Worst place for a one-night stand", want: Adults, term: "one night stand"},
+		{text: "What the f*** was that?", want: Adults, term: "f***"},
+		{text: "Is 18+ content allowed?", want: Adults, term: "18+"},
+		{text: "Favorite language: C# or Go?", want: Kids},
+		{text: "Which planet has the most moons?", want: Kids},
+		{text: "Dinner wine, then sexy dancing", want: Adults, term: "sexy"},
+	}
+	for _, tt := range tests {
+		got := Classify(tt.text)
+		if got.Rating != tt.want {
+			t.Errorf("Classify(%q) = %d (%v), want %d", tt.text, got.Rating, got.Matches, tt.want)
+			continue
+		}
+		if tt.term == "" {
+			continue
+		}
+		found := false
+		for _, m := range got.Matches {
+			found = found || m.Term == tt.term
+		}
+		if !found {
+			t.Errorf("Classify(%q) matches %v, want term %q", tt.text, got.Matches, tt.term)
+		}
+	}
+}
+
+func TestClassifyIgnoresSubstrings(t *testing.T) {
+	for _, text := range []string{"Scunthorpe United", "Essex is a county", "The barcode scanner", "Shitake is misspelled"} {
+		if got := Classify(text); got.Rating != Kids {
+			t.Errorf("Classify(%q) = %d (%v), want Kids", text, got.Rating, got.Matches)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	items := []Item{
+		{ID: "clean", Rating: Kids, Text: "Best pizza topping"},
+		{ID: "beer-kids", Rating: Kids, Text: "Best beer for a picnic"},
+		{ID: "beer-work", Rating: Work, Text: "Best beer for a picnic"},
+		{ID: "mixed", Rating: Work, Text: "Wine or weed? Marijuana edition"},
+	}
+	var got []string
+	for _, f := range Check(items) {
+		got = append(got, f.String())
+	}
+	want := []string{
+		`beer-kids: rated Mild (10) but "beer" suggests Polite (20)`,
+		`mixed: rated Polite (20) but "marijuana" suggests Adults (30)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected findings:\n%s", strings.Join(got, "\n"))
+	}
+}
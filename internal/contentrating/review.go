@@ -0,0 +1,157 @@
+package contentrating
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultReviewTimeout = 30 * time.Second
+
+// Reviewer is an optional second opinion on top of Classify, such as a model
+// asked to rate the text. It returns the rating it thinks text needs and a
+// short reason.
+type Reviewer interface {
+	SuggestRating(ctx context.Context, text string) (int16, string, error)
+}
+
+// Review asks reviewer about each item and returns the ones it rates
+// stricter than assigned, in input order. It stops at the first error.
+func Review(ctx context.Context, reviewer Reviewer, items []Item) ([]Finding, error) {
+	var findings []Finding
+	for _, item := range items {
+		rating, reason, err := reviewer.SuggestRating(ctx, item.Text)
+		if err != nil {
+			return findings, fmt.Errorf("review %s: %w", item.ID, err)
+		}
+		if rating > item.Rating {
+			findings = append(findings, Finding{
+				ID:        item.ID,
+				Assigned:  item.Rating,
+				Suggested: rating,
+				Reasons:   []string{fmt.Sprintf("reviewer suggests %s (%d): %s", Label(rating), rating, reason)},
+			})
+		}
+	}
+	return findings, nil
+}
+
+// ChatReviewer asks an OpenAI-compatible chat completions endpoint to rate
+// text.
+type ChatReviewer struct {
+	Provider   string
+	Endpoint   string
+	Model      string
+	APIKey     string
+	HTTPClient *http.Client
+	Timeout    time.Duration
+}
+
+type reviewChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type reviewChatRequest struct {
+	Model          string              `json:"model"`
+	Messages       []reviewChatMessage `json:"messages"`
+	Temperature    float64             `json:"temperature"`
+	ResponseFormat map[string]any      `json:"response_format,omitempty"`
+}
+
+type reviewChatResponse struct {
+	Choices []struct {
+		Message reviewChatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+type reviewVerdict struct {
+	Rating int16  `json:"rating"`
+	Reason string `json:"reason"`
+}
+
+const reviewSystemPrompt = "You rate party game content for the audience it suits. " +
+	"10 (Mild) is family-friendly with no mature themes. " +
+	"20 (Polite) is workplace-safe: light drinking, dating, or mild language are fine. " +
+	"30 (Adults) covers sex, drugs, strong profanity, and other edgy topics. " +
+	"Pick the least permissive rating the text needs. " +
+	`Return strict JSON only: {"rating": 10|20|30, "reason": "under 12 words"}.`
+
+// SuggestRating implements Reviewer.
+func (r ChatReviewer) SuggestRating(ctx context.Context, text string) (int16, string, error) {
+	if strings.TrimSpace(r.Endpoint) == "" {
+		return 0, "", errors.New("reviewer endpoint is required")
+	}
+	if strings.TrimSpace(r.Model) == "" {
+		return 0, "", errors.New("reviewer model is required")
+	}
+
+	payload, err := json.Marshal(reviewChatRequest{
+		Model: r.Model,
+		Messages: []reviewChatMessage{
+			{Role: "system", Content: reviewSystemPrompt},
+			{Role: "user", Content: text},
+		},
+		ResponseFormat: map[string]any{"type": "json_object"},
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = defaultReviewTimeout
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, r.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if key := strings.TrimSpace(r.APIKey); key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, "", err
+	}
+	if resp.StatusCode >= 400 {
+		return 0, "", fmt.Errorf("%s status %d: %s", r.Provider, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var completion reviewChatResponse
+	if err := json.Unmarshal(body, &completion); err != nil {
+		return 0, "", fmt.Errorf("decode completion: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return 0, "", fmt.Errorf("%s returned no choices", r.Provider)
+	}
+
+	var verdict reviewVerdict
+	if err := json.Unmarshal([]byte(completion.Choices[0].Message.Content), &verdict); err != nil {
+		return 0, "", fmt.Errorf("decode rating: %w", err)
+	}
+	if !IsValid(verdict.Rating) {
+		return 0, "", fmt.Errorf("%s returned unsupported rating %d", r.Provider, verdict.Rating)
+	}
+	return verdict.Rating, strings.TrimSpace(verdict.Reason), nil
+}
@@ -0,0 +1,58 @@
+package contentrating
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChatReviewerSuggestRating(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("unexpected auth header %q", got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req reviewChatRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		rating := 10
+		if strings.Contains(req.Messages[len(req.Messages)-1].Content, "hangover") {
+			rating = 20
+		}
+		content, _ := json.Marshal(map[string]any{"rating": rating, "reason": "mentions drinking"})
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": string(content)}}},
+		})
+	}))
+	defer server.Close()
+
+	reviewer := ChatReviewer{Provider: "test", Endpoint: server.URL, Model: "m", APIKey: "test-key"}
+	findings, err := Review(context.Background(), reviewer, []Item{
+		{ID: "clean", Rating: Kids, Text: "Best pizza topping"},
+		{ID: "cure", Rating: Kids, Text: "Best hangover cure"},
+		{ID: "cure-work", Rating: Work, Text: "Best hangover cure"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 1 || findings[0].String() != "cure: rated Mild (10) but reviewer suggests Polite (20): mentions drinking" {
+		t.Fatalf("unexpected findings %v", findings)
+	}
+}
+
+func TestChatReviewerRejectsBadRating(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"choices":[{"message":{"role":"assistant","content":"{\"rating\": 15}"}}]}`)
+	}))
+	defer server.Close()
+
+	_, _, err := ChatReviewer{Provider: "test", Endpoint: server.URL, Model: "m"}.SuggestRating(context.Background(), "text")
+	if err == nil || !strings.Contains(err.Error(), "unsupported rating 15") {
+		t.Fatalf("expected rating error, got %v", err)
+	}
+}
@@ -87,6 +87,8 @@ const (
 	EventClusterSubmissionUpdated = "cluster.submission.updated"
 	EventClusterRoundRevealed     = "cluster.round.revealed"
 	EventClusterExhausted         = "cluster.exhausted"
+	EventClusterSettingsUpdated   = "cluster.settings.updated"
+	EventClusterGuessUpdated      = "cluster.guess.updated"
 )
 
 // PlayerJoinedPayload is the payload for EventPlayerJoined.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log"
+	"math/rand/v2"
 	"sort"
 	"strings"
 	"time"
@@ -21,6 +22,8 @@ import (
 	clustertmpl "github.com/jgoodhcg/mindmeld/templates/cluster"
 )
 
+var errNoSubmissions = errors.New("cluster: no submissions to reveal")
+
 // ClusterGame implements the Game interface for Cluster.
 type ClusterGame struct {
 	queries  *db.Queries
@@ -60,12 +63,12 @@ func (g *ClusterGame) RenderContent(ctx context.Context, lobby db.Lobby, players
 		expectedCount        int
 		revealed             bool
 		dots                 []clustertmpl.DotView
+		buckets              []clustertmpl.BucketView
 		centroidX            float64
 		centroidY            float64
 		roundCenterDistances map[string]float64
 		roundDistances       map[string]float64
 		standings            []clustertmpl.StandingView
-		winners              []string
 		outliers             []string
 		hostTransferOptions  []lobbyview.HostTransferOption
 	)
@@ -77,12 +80,21 @@ func (g *ClusterGame) RenderContent(ctx context.Context, lobby db.Lobby, players
 		})
 	}
 
-	remainingPairs, err := g.countRemainingPromptAxisSets(ctx, g.dbPool, lobby.ID, lobby.ContentRating)
+	settings, err := g.getLobbySettings(ctx, g.dbPool, lobby.ID)
+	if err != nil {
+		log.Printf("[cluster] failed to get settings for lobby %s: %v", lobby.Code, err)
+	}
+
+	remainingPairs, err := g.countRemainingPromptAxisSets(ctx, g.dbPool, lobby.ID, lobby.ContentRating, settings.Themes)
 	if err != nil {
 		log.Printf("[cluster] failed to count remaining prompt-axis pairs for lobby %s: %v", lobby.Code, err)
 	}
+	revealedStrategy := settings.ConsensusStrategy
+	predictions := clustertmpl.PredictionView{Enabled: settings.PredictGroup}
+	guess := clustertmpl.GuessView{Enabled: settings.GuessPlayer}
 
 	roundPoints := map[string]int{}
+	roundPredictionPoints := map[string]int{}
 	activeRound, err := g.getLatestRound(ctx, g.dbPool, lobby.ID)
 	if err == nil {
 		hasRound = true
@@ -98,6 +110,8 @@ func (g *ClusterGame) RenderContent(ctx context.Context, lobby db.Lobby, players
 				XMaxLabel:  pair.XMaxLabel,
 				YMinLabel:  pair.YMinLabel,
 				YMaxLabel:  pair.YMaxLabel,
+				Mode:       pair.Mode,
+				Buckets:    pair.Buckets,
 			}
 		}
 
@@ -105,11 +119,14 @@ func (g *ClusterGame) RenderContent(ctx context.Context, lobby db.Lobby, players
 		if subErr != nil {
 			log.Printf("[cluster] failed to get submissions for round %d: %v", activeRound.RoundNumber, subErr)
 		} else {
-			submittedCount = len(submissions)
+			predictions.RoundEnabled = activeRound.PredictGroup
 			for _, sub := range submissions {
+				if !activeRound.PredictGroup || sub.hasPrediction() {
+					submittedCount++
+				}
 				if sub.PlayerID == player.ID {
 					hasSubmitted = true
-					break
+					predictions.NeedsPrediction = activeRound.PredictGroup && !sub.hasPrediction()
 				}
 			}
 
@@ -117,42 +134,77 @@ func (g *ClusterGame) RenderContent(ctx context.Context, lobby db.Lobby, players
 			if revealed {
 				centroidX = activeRound.CentroidX.Float64
 				centroidY = activeRound.CentroidY.Float64
-				dots, roundPoints, roundCenterDistances, roundDistances, winners, outliers = scoreRound(submissions, centroidX, centroidY, player.ID.String())
+				revealedStrategy = activeRound.Consensus
+				scorer := newRoundScorer(pair.shape(), submissions, centroidX, centroidY)
+				dots, roundPoints, roundCenterDistances, roundDistances, _, outliers = scoreRound(submissions, scorer, player.ID.String())
+				roundPredictionPoints = scorePredictions(submissions, scorer)
+				buckets = buildBucketViews(pair.shape(), submissions, scorer, player.ID.String())
+
+				if activeRound.MysteryPlayerID.Valid {
+					guesses, guessErr := g.getRoundGuesses(ctx, g.dbPool, activeRound.ID)
+					if guessErr != nil {
+						log.Printf("[cluster] failed to get guesses for round %d: %v", activeRound.RoundNumber, guessErr)
+					}
+					guess = buildGuessView(settings.GuessPlayer, activeRound, submissions, guesses, player.ID.String())
+					if guess.Active && len(buckets) > 0 {
+						buckets[BucketIndex(guess.MysteryX, len(buckets))].IsMystery = true
+					}
+				}
 			}
 		}
 	} else if !errors.Is(err, pgx.ErrNoRows) {
 		log.Printf("[cluster] failed to get latest round for lobby %s: %v", lobby.Code, err)
 	}
 
-	standings, standingsErr := g.getStandings(ctx, lobby.ID, players, roundPoints, roundCenterDistances, roundDistances, player.ID.String())
+	standings, standingsErr := g.getStandings(ctx, lobby.ID, players, roundPoints, roundPredictionPoints, roundCenterDistances, roundDistances, player.ID.String())
 	if standingsErr != nil {
 		log.Printf("[cluster] failed to build standings for lobby %s: %v", lobby.Code, standingsErr)
 	}
+	predictions.Leaders = predictionLeaders(standings)
+	guess.Leaders = guessLeaders(standings)
 
+	revealedRounds, err := g.countRevealedRounds(ctx, g.dbPool, lobby.ID)
+	if err != nil {
+		log.Printf("[cluster] failed to count revealed rounds for lobby %s: %v", lobby.Code, err)
+	}
+	remainingPairs = remainingRounds(settings.RoundLimit, revealedRounds, remainingPairs)
 	exhausted := strings.EqualFold(lobby.Phase, "finished") || (strings.EqualFold(lobby.Phase, "playing") && !hasRound && remainingPairs == 0)
 
-	return clustertmpl.GameContent(
-		lobby,
-		players,
-		isHost,
-		minPlayersToStart,
-		hasRound,
-		roundNumber,
-		prompt,
-		submittedCount,
-		expectedCount,
-		hasSubmitted,
-		revealed,
-		hostTransferOptions,
-		dots,
-		centroidX,
-		centroidY,
-		standings,
-		winners,
-		outliers,
-		remainingPairs,
-		exhausted,
-	)
+	var profile clustertmpl.ProfileView
+	if exhausted || (strings.EqualFold(lobby.Phase, "playing") && !hasRound) {
+		profile, err = LoadPlayerProfile(ctx, g.dbPool, player.ID, lobby.ID)
+		if err != nil {
+			log.Printf("[cluster] failed to build profile for lobby %s: %v", lobby.Code, err)
+		}
+	}
+
+	return clustertmpl.GameContent(clustertmpl.GameView{
+		LobbyCode:           lobby.Code,
+		Phase:               lobby.Phase,
+		IsHost:              isHost,
+		HostTransferOptions: hostTransferOptions,
+		MinPlayers:          minPlayersToStart,
+		Session:             clustertmpl.SessionView{Themes: settings.Themes, RoundLimit: settings.RoundLimit, RevealedRounds: revealedRounds},
+		HasRound:            hasRound,
+		RoundNumber:         roundNumber,
+		Prompt:              prompt,
+		SubmittedCount:      submittedCount,
+		ExpectedCount:       expectedCount,
+		HasSubmitted:        hasSubmitted,
+		Revealed:            revealed,
+		Dots:                dots,
+		Buckets:             buckets,
+		CentroidX:           centroidX,
+		CentroidY:           centroidY,
+		Consensus:           buildConsensusView(settings.ConsensusStrategy, revealedStrategy),
+		Predictions:         predictions,
+		Guess:               guess,
+		Profile:             profile,
+		Outliers:            outliers,
+		Standings:           standings,
+		RemainingPairs:      remainingPairs,
+		Exhausted:           exhausted,
+	})
 }
 
 func (g *ClusterGame) countActivePlayers(lobbyCode string, players []db.GetLobbyPlayersRow, now time.Time) int {
@@ -171,6 +223,13 @@ func (g *ClusterGame) RegisterRoutes(r chi.Router) {
 	r.Post("/submissions", g.handleSubmitCoordinate)
 	r.Post("/skip", g.handleSkipPrompt)
 	r.Post("/next", g.handleNextRound)
+	r.Post("/predictions", g.handleSubmitPrediction)
+	r.Post("/settings", g.handleUpdateSettings)
+	r.Post("/guesses", g.handleSubmitGuess)
+	r.Post("/guesses/reveal", g.handleRevealGuess)
+	r.Get("/prompts", g.handlePromptPicker)
+	r.Post("/prompts/pick", g.handlePickPrompt)
+	r.Post("/prompts/custom", g.handleCustomPrompt)
 }
 
 type coordinatesRound struct {
@@ -180,6 +239,10 @@ type coordinatesRound struct {
 	RoundNumber     int32
 	CentroidX       pgtype.Float8
 	CentroidY       pgtype.Float8
+	Consensus       string
+	PredictGroup    bool
+	MysteryPlayerID pgtype.UUID
+	MysteryRevealed bool
 	CreatedAt       pgtype.Timestamptz
 }
 
@@ -190,6 +253,12 @@ type promptAxisSetRecord struct {
 	XMaxLabel  string
 	YMinLabel  string
 	YMaxLabel  string
+	Mode       string
+	Buckets    []string
+}
+
+func (r promptAxisSetRecord) shape() axisShape {
+	return axisShape{Mode: r.Mode, Buckets: r.Buckets}
 }
 
 type submissionRecord struct {
@@ -198,22 +267,47 @@ type submissionRecord struct {
 	Nickname      string
 	X             float64
 	Y             float64
+	PredictedX    pgtype.Float8
+	PredictedY    pgtype.Float8
+}
+
+// hasPrediction reports whether the player placed a group prediction.
+func (s submissionRecord) hasPrediction() bool {
+	return s.PredictedX.Valid && s.PredictedY.Valid
+}
+
+// lobbySettings are the host's Cluster choices for a lobby.
+type lobbySettings struct {
+	ConsensusStrategy string
+	PredictGroup      bool
+	GuessPlayer       bool
+	// Themes limits prompt selection to these themes; empty means any theme.
+	Themes []string
+	// RoundLimit ends the session after this many rounds; 0 means no limit.
+	RoundLimit int
 }
 
 type scoredSubmissionRecord struct {
-	PlayerID   pgtype.UUID
-	Nickname   string
-	X          float64
-	Y          float64
-	CentroidX  float64
-	CentroidY  float64
-	RoundID    pgtype.UUID
-	RoundScore int
+	PlayerID        pgtype.UUID
+	Nickname        string
+	X               float64
+	Y               float64
+	PredictedX      pgtype.Float8
+	PredictedY      pgtype.Float8
+	CentroidX       float64
+	CentroidY       float64
+	RoundID         pgtype.UUID
+	Mode            string
+	Buckets         []string
+	RoundScore      int
+	PredictionScore int
+	HasPrediction   bool
 }
 
 func (g *ClusterGame) getLatestRound(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID) (coordinatesRound, error) {
 	const query = `
-		SELECT id, lobby_id, prompt_axis_set_id, round_number, centroid_x, centroid_y, created_at
+		SELECT id, lobby_id, prompt_axis_set_id, round_number, centroid_x, centroid_y, consensus_strategy, predict_group,
+			mystery_player_id, mystery_revealed_at IS NOT NULL, created_at
 		FROM coordinates_rounds
 		WHERE lobby_id = $1
 		ORDER BY round_number DESC
@@ -229,6 +323,10 @@ func (g *ClusterGame) getLatestRound(ctx context.Context, q db.DBTX, lobbyID pgt
 		&round.RoundNumber,
 		&round.CentroidX,
 		&round.CentroidY,
+		&round.Consensus,
+		&round.PredictGroup,
+		&round.MysteryPlayerID,
+		&round.MysteryRevealed,
 		&round.CreatedAt,
 	)
 	return round, err
@@ -236,9 +334,14 @@ func (g *ClusterGame) getLatestRound(ctx context.Context, q db.DBTX, lobbyID pgt
 
 func (g *ClusterGame) createRound(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID, promptAxisSetID pgtype.UUID, roundNumber int32) (coordinatesRound, error) {
 	const query = `
-		INSERT INTO coordinates_rounds (lobby_id, prompt_axis_set_id, round_number)
-		VALUES ($1, $2, $3)
-		RETURNING id, lobby_id, prompt_axis_set_id, round_number, centroid_x, centroid_y, created_at
+		INSERT INTO coordinates_rounds (lobby_id, prompt_axis_set_id, round_number, predict_group, axis_mode, axis_bucket_labels)
+		SELECT $1, $2, $3, COALESCE((SELECT predict_group FROM coordinates_lobby_settings WHERE lobby_id = $1), FALSE),
+			cas.mode, cas.bucket_labels
+		FROM coordinates_prompt_axis_sets cpas
+		JOIN coordinates_axis_sets cas ON cas.id = cpas.axis_set_id
+		WHERE cpas.id = $2
+		RETURNING id, lobby_id, prompt_axis_set_id, round_number, centroid_x, centroid_y, consensus_strategy, predict_group,
+			mystery_player_id, mystery_revealed_at IS NOT NULL, created_at
 	`
 
 	row := q.QueryRow(ctx, query, lobbyID, promptAxisSetID, roundNumber)
@@ -250,6 +353,10 @@ func (g *ClusterGame) createRound(ctx context.Context, q db.DBTX, lobbyID pgtype
 		&round.RoundNumber,
 		&round.CentroidX,
 		&round.CentroidY,
+		&round.Consensus,
+		&round.PredictGroup,
+		&round.MysteryPlayerID,
+		&round.MysteryRevealed,
 		&round.CreatedAt,
 	)
 	return round, err
@@ -257,16 +364,21 @@ func (g *ClusterGame) createRound(ctx context.Context, q db.DBTX, lobbyID pgtype
 
 func (g *ClusterGame) getPromptAxisSetForRound(ctx context.Context, q db.DBTX, roundID pgtype.UUID) (promptAxisSetRecord, error) {
 	const query = `
-		SELECT cpas.id, cp.prompt_text, cas.x_min_label, cas.x_max_label, cas.y_min_label, cas.y_max_label
+		SELECT cpas.id, cp.prompt_text, cas.x_min_label, cas.x_max_label, cas.y_min_label, cas.y_max_label, cr.axis_mode, cr.axis_bucket_labels,
+			cp.translations -> l.locale, cas.translations -> l.locale
 		FROM coordinates_rounds cr
 		JOIN coordinates_prompt_axis_sets cpas ON cpas.id = cr.prompt_axis_set_id
 		JOIN coordinates_prompts cp ON cp.id = cpas.prompt_id
 		JOIN coordinates_axis_sets cas ON cas.id = cpas.axis_set_id
+		JOIN lobbies l ON l.id = cr.lobby_id
 		WHERE cr.id = $1
 	`
 
 	row := q.QueryRow(ctx, query, roundID)
-	var record promptAxisSetRecord
+	var (
+		record                   promptAxisSetRecord
+		promptLocale, axisLocale []byte
+	)
 	err := row.Scan(
 		&record.ID,
 		&record.PromptText,
@@ -274,13 +386,21 @@ func (g *ClusterGame) getPromptAxisSetForRound(ctx context.Context, q db.DBTX, r
 		&record.XMaxLabel,
 		&record.YMinLabel,
 		&record.YMaxLabel,
+		&record.Mode,
+		&record.Buckets,
+		&promptLocale,
+		&axisLocale,
 	)
-	return record, err
+	if err != nil {
+		return record, err
+	}
+	record.localize(promptLocale, axisLocale)
+	return record, nil
 }
 
 func (g *ClusterGame) getRoundSubmissions(ctx context.Context, q db.DBTX, roundID pgtype.UUID) ([]submissionRecord, error) {
 	const query = `
-		SELECT cs.player_id, lp.player_id, lp.nickname, cs.x, cs.y
+		SELECT cs.player_id, lp.player_id, lp.nickname, cs.x, cs.y, cs.predicted_x, cs.predicted_y
 		FROM coordinates_submissions cs
 		JOIN lobby_players lp ON lp.id = cs.player_id
 		WHERE cs.round_id = $1
@@ -296,7 +416,7 @@ func (g *ClusterGame) getRoundSubmissions(ctx context.Context, q db.DBTX, roundI
 	items := make([]submissionRecord, 0)
 	for rows.Next() {
 		var item submissionRecord
-		if scanErr := rows.Scan(&item.LobbyPlayerID, &item.PlayerID, &item.Nickname, &item.X, &item.Y); scanErr != nil {
+		if scanErr := rows.Scan(&item.LobbyPlayerID, &item.PlayerID, &item.Nickname, &item.X, &item.Y, &item.PredictedX, &item.PredictedY); scanErr != nil {
 			return nil, scanErr
 		}
 		items = append(items, item)
@@ -320,90 +440,173 @@ func (g *ClusterGame) upsertSubmission(ctx context.Context, q db.DBTX, roundID p
 	return err
 }
 
-func (g *ClusterGame) countRoundSubmissions(ctx context.Context, q db.DBTX, roundID pgtype.UUID) (int, error) {
+// setSubmissionPrediction records a player's group prediction. It reports
+// false when the player has not placed their own point yet.
+func (g *ClusterGame) setSubmissionPrediction(ctx context.Context, q db.DBTX, roundID pgtype.UUID, lobbyPlayerID pgtype.UUID, x float64, y float64) (bool, error) {
+	const query = `
+		UPDATE coordinates_submissions
+		SET predicted_x = $3, predicted_y = $4
+		WHERE round_id = $1 AND player_id = $2
+	`
+
+	tag, err := q.Exec(ctx, query, roundID, lobbyPlayerID, x, y)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// countRoundSubmissions counts players who finished placing. When the round
+// asks for predictions, a submission without one is not finished.
+func (g *ClusterGame) countRoundSubmissions(ctx context.Context, q db.DBTX, roundID pgtype.UUID, requirePrediction bool) (int, error) {
 	const query = `
 		SELECT COUNT(*)
 		FROM coordinates_submissions
 		WHERE round_id = $1
+		  AND (NOT $2 OR (predicted_x IS NOT NULL AND predicted_y IS NOT NULL))
 	`
 
 	var count int
-	err := q.QueryRow(ctx, query, roundID).Scan(&count)
+	err := q.QueryRow(ctx, query, roundID, requirePrediction).Scan(&count)
 	return count, err
 }
 
-func (g *ClusterGame) setRoundCentroid(ctx context.Context, q db.DBTX, roundID pgtype.UUID, centroidX float64, centroidY float64) error {
+func (g *ClusterGame) setRoundCentroid(ctx context.Context, q db.DBTX, roundID pgtype.UUID, strategy string, centroidX float64, centroidY float64) error {
 	const query = `
 		UPDATE coordinates_rounds
-		SET centroid_x = $2, centroid_y = $3
+		SET centroid_x = $2, centroid_y = $3, consensus_strategy = $4
 		WHERE id = $1
 	`
 
-	_, err := q.Exec(ctx, query, roundID, centroidX, centroidY)
+	_, err := q.Exec(ctx, query, roundID, centroidX, centroidY, strategy)
 	return err
 }
 
-func (g *ClusterGame) countRemainingPromptAxisSets(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID, lobbyContentRating int16) (int, error) {
+// revealRound computes the group target with the lobby's consensus strategy
+// and stores it on the round. Bucket rounds target the most picked bucket.
+// With guess the player on, it also picks the round's mystery dot.
+func (g *ClusterGame) revealRound(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID, roundID pgtype.UUID, submissions []submissionRecord) error {
+	settings, err := g.getLobbySettings(ctx, q, lobbyID)
+	if err != nil {
+		return err
+	}
+	pair, err := g.getPromptAxisSetForRound(ctx, q, roundID)
+	if err != nil {
+		return err
+	}
+
+	points := make([]Point, 0, len(submissions))
+	for _, sub := range submissions {
+		points = append(points, Point{X: sub.X, Y: sub.Y})
+	}
+	centroidX, centroidY, ok := pair.shape().consensusFor(settings.ConsensusStrategy, points)
+	if !ok {
+		return errNoSubmissions
+	}
+	if err = g.setRoundCentroid(ctx, q, roundID, settings.ConsensusStrategy, centroidX, centroidY); err != nil {
+		return err
+	}
+	if !settings.GuessPlayer {
+		return nil
+	}
+
+	featured, err := g.getFeaturedMysteryPlayers(ctx, q, lobbyID)
+	if err != nil {
+		return err
+	}
+	scorer := newRoundScorer(pair.shape(), submissions, centroidX, centroidY)
+	mysteryID, ok := pickMysteryPlayer(submissions, scorer, featured, rand.IntN)
+	if !ok {
+		return nil
+	}
+	return g.setRoundMystery(ctx, q, roundID, mysteryID)
+}
+
+// revealIfComplete reveals the round once every active player has finished
+// placing. It returns the finished and expected counts for progress updates.
+func (g *ClusterGame) revealIfComplete(ctx context.Context, tx pgx.Tx, lobby db.Lobby, round coordinatesRound) (int, int, bool, error) {
+	players, err := g.queries.WithTx(tx).GetLobbyPlayers(ctx, lobby.ID)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	submissionCount, err := g.countRoundSubmissions(ctx, tx, round.ID, round.PredictGroup)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	expectedPlayers := g.countActivePlayers(lobby.Code, players, time.Now())
+	if expectedPlayers == 0 || submissionCount < expectedPlayers {
+		return submissionCount, expectedPlayers, false, nil
+	}
+
+	submissions, err := g.getRoundSubmissions(ctx, tx, round.ID)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	if err = g.revealRound(ctx, tx, lobby.ID, round.ID, submissions); err != nil {
+		return 0, 0, false, err
+	}
+	return submissionCount, expectedPlayers, true, nil
+}
+
+func (g *ClusterGame) getLobbySettings(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID) (lobbySettings, error) {
 	const query = `
-		SELECT COUNT(*)
-		FROM coordinates_prompt_axis_sets cpas
-		JOIN coordinates_prompts cp ON cp.id = cpas.prompt_id
-		JOIN coordinates_axis_sets cas ON cas.id = cpas.axis_set_id
-		WHERE cpas.is_active = TRUE
-		  AND cp.is_active = TRUE
-		  AND cas.is_active = TRUE
-		  AND cp.min_rating <= $2
-		  AND cas.min_rating <= $2
-		  AND NOT EXISTS (
-				SELECT 1
-				FROM coordinates_rounds cr
-				WHERE cr.lobby_id = $1
-				  AND cr.prompt_axis_set_id = cpas.id
-		  )
+		SELECT consensus_strategy, predict_group, guess_player, themes, round_limit
+		FROM coordinates_lobby_settings
+		WHERE lobby_id = $1
 	`
 
-	var count int
-	err := q.QueryRow(ctx, query, lobbyID, lobbyContentRating).Scan(&count)
-	return count, err
+	var (
+		raw      string
+		settings lobbySettings
+	)
+	err := q.QueryRow(ctx, query, lobbyID).Scan(&raw, &settings.PredictGroup, &settings.GuessPlayer, &settings.Themes, &settings.RoundLimit)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return lobbySettings{ConsensusStrategy: ConsensusMean}, nil
+	}
+	if err != nil {
+		return lobbySettings{}, err
+	}
+	settings.ConsensusStrategy, _ = ParseConsensusStrategy(raw)
+	return settings, nil
 }
 
-func (g *ClusterGame) getNextPromptAxisSet(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID, lobbyContentRating int16) (promptAxisSetRecord, error) {
+func (g *ClusterGame) saveLobbySettings(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID, settings lobbySettings) error {
 	const query = `
-		SELECT cpas.id, cp.prompt_text, cas.x_min_label, cas.x_max_label, cas.y_min_label, cas.y_max_label
-		FROM coordinates_prompt_axis_sets cpas
-		JOIN coordinates_prompts cp ON cp.id = cpas.prompt_id
-		JOIN coordinates_axis_sets cas ON cas.id = cpas.axis_set_id
-		WHERE cpas.is_active = TRUE
-		  AND cp.is_active = TRUE
-		  AND cas.is_active = TRUE
-		  AND cp.min_rating <= $2
-		  AND cas.min_rating <= $2
-		  AND NOT EXISTS (
-				SELECT 1
-				FROM coordinates_rounds cr
-				WHERE cr.lobby_id = $1
-				  AND cr.prompt_axis_set_id = cpas.id
-		  )
-		ORDER BY RANDOM()
-		LIMIT 1
+		INSERT INTO coordinates_lobby_settings (lobby_id, consensus_strategy, predict_group, guess_player, themes, round_limit)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (lobby_id)
+		DO UPDATE SET
+			consensus_strategy = EXCLUDED.consensus_strategy,
+			predict_group = EXCLUDED.predict_group,
+			guess_player = EXCLUDED.guess_player,
+			themes = EXCLUDED.themes,
+			round_limit = EXCLUDED.round_limit,
+			updated_at = NOW()
 	`
 
-	row := q.QueryRow(ctx, query, lobbyID, lobbyContentRating)
-	var record promptAxisSetRecord
-	err := row.Scan(
-		&record.ID,
-		&record.PromptText,
-		&record.XMinLabel,
-		&record.XMaxLabel,
-		&record.YMinLabel,
-		&record.YMaxLabel,
-	)
-	return record, err
+	themes := settings.Themes
+	if themes == nil {
+		themes = []string{}
+	}
+	_, err := q.Exec(ctx, query, lobbyID, settings.ConsensusStrategy, settings.PredictGroup, settings.GuessPlayer, themes, settings.RoundLimit)
+	return err
+}
+
+func (g *ClusterGame) countRemainingPromptAxisSets(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID, lobbyContentRating int16, themes []string) (int, error) {
+	const query = `WITH` + eligiblePairsCTE + `
+		SELECT COUNT(*) FROM eligible_pairs
+	`
+
+	var count int
+	err := q.QueryRow(ctx, query, lobbyID, lobbyContentRating, themes).Scan(&count)
+	return count, err
 }
 
 func (g *ClusterGame) getScoredSubmissionsForLobby(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID) ([]scoredSubmissionRecord, error) {
 	const query = `
-		SELECT lp.player_id, lp.nickname, cs.x, cs.y, cr.centroid_x, cr.centroid_y, cr.id
+		SELECT lp.player_id, lp.nickname, cs.x, cs.y, cs.predicted_x, cs.predicted_y, cr.centroid_x, cr.centroid_y, cr.id, cr.axis_mode, cr.axis_bucket_labels
 		FROM coordinates_rounds cr
 		JOIN coordinates_submissions cs ON cs.round_id = cr.id
 		JOIN lobby_players lp ON lp.id = cs.player_id
@@ -421,22 +624,49 @@ func (g *ClusterGame) getScoredSubmissionsForLobby(ctx context.Context, q db.DBT
 	items := make([]scoredSubmissionRecord, 0)
 	for rows.Next() {
 		var item scoredSubmissionRecord
-		if scanErr := rows.Scan(&item.PlayerID, &item.Nickname, &item.X, &item.Y, &item.CentroidX, &item.CentroidY, &item.RoundID); scanErr != nil {
+		if scanErr := rows.Scan(&item.PlayerID, &item.Nickname, &item.X, &item.Y, &item.PredictedX, &item.PredictedY, &item.CentroidX, &item.CentroidY, &item.RoundID, &item.Mode, &item.Buckets); scanErr != nil {
 			return nil, scanErr
 		}
-		item.RoundScore = CalculateRoundPoints(item.X, item.Y, item.CentroidX, item.CentroidY)
 		items = append(items, item)
 	}
 
 	if rows.Err() != nil {
 		return nil, rows.Err()
 	}
+	scoreLobbySubmissions(items)
 	return items, nil
 }
 
-func (g *ClusterGame) getStandings(ctx context.Context, lobbyID pgtype.UUID, players []db.GetLobbyPlayersRow, roundPoints map[string]int, roundCenterDistances map[string]float64, roundDistances map[string]float64, currentPlayerID string) ([]clustertmpl.StandingView, error) {
+// scoreLobbySubmissions fills in round and prediction scores. Rows are scored
+// per round because bucket rounds depend on what everyone picked.
+func scoreLobbySubmissions(items []scoredSubmissionRecord) {
+	byRound := make(map[pgtype.UUID][]submissionRecord)
+	for _, item := range items {
+		byRound[item.RoundID] = append(byRound[item.RoundID], submissionRecord{X: item.X, Y: item.Y})
+	}
+
+	scorers := make(map[pgtype.UUID]roundScorer, len(byRound))
+	for i := range items {
+		item := &items[i]
+		scorer, ok := scorers[item.RoundID]
+		if !ok {
+			shape := axisShape{Mode: item.Mode, Buckets: item.Buckets}
+			scorer = newRoundScorer(shape, byRound[item.RoundID], item.CentroidX, item.CentroidY)
+			scorers[item.RoundID] = scorer
+		}
+		item.RoundScore = scorer.points(item.X, item.Y)
+		if item.PredictedX.Valid && item.PredictedY.Valid {
+			item.HasPrediction = true
+			item.PredictionScore = scorer.points(item.PredictedX.Float64, item.PredictedY.Float64)
+		}
+	}
+}
+
+func (g *ClusterGame) getStandings(ctx context.Context, lobbyID pgtype.UUID, players []db.GetLobbyPlayersRow, roundPoints map[string]int, roundPredictionPoints map[string]int, roundCenterDistances map[string]float64, roundDistances map[string]float64, currentPlayerID string) ([]clustertmpl.StandingView, error) {
 	totals := make(map[string]int, len(players))
 	roundsPlayed := make(map[string]int, len(players))
+	predictionTotals := make(map[string]int, len(players))
+	predictionRounds := make(map[string]int, len(players))
 	names := make(map[string]string, len(players))
 
 	for _, p := range players {
@@ -450,11 +680,19 @@ func (g *ClusterGame) getStandings(ctx context.Context, lobbyID pgtype.UUID, pla
 	if err != nil {
 		return nil, err
 	}
+	guessTallies, err := g.getGuessTallies(ctx, g.dbPool, lobbyID)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, row := range scored {
 		key := row.PlayerID.String()
 		totals[key] += row.RoundScore
 		roundsPlayed[key] += 1
+		if row.HasPrediction {
+			predictionTotals[key] += row.PredictionScore
+			predictionRounds[key] += 1
+		}
 		if _, ok := names[key]; !ok {
 			names[key] = row.Nickname
 		}
@@ -466,8 +704,13 @@ func (g *ClusterGame) getStandings(ctx context.Context, lobbyID pgtype.UUID, pla
 		if roundsPlayed[key] > 0 {
 			avg = float64(totals[key]) / float64(roundsPlayed[key])
 		}
+		predictionAvg := 0.0
+		if predictionRounds[key] > 0 {
+			predictionAvg = float64(predictionTotals[key]) / float64(predictionRounds[key])
+		}
 		centerDistance, hasCenterDistance := roundCenterDistances[key]
 		distanceFromYou, hasDistanceFromYou := roundDistances[key]
+		roundPrediction, hasRoundPrediction := roundPredictionPoints[key]
 
 		standings = append(standings, clustertmpl.StandingView{
 			Nickname:           nickname,
@@ -478,6 +721,13 @@ func (g *ClusterGame) getStandings(ctx context.Context, lobbyID pgtype.UUID, pla
 			IsCurrentPlayer:    key == currentPlayerID,
 			TotalPoints:        totals[key],
 			AvgPointsPerRound:  avg,
+			RoundPrediction:    roundPrediction,
+			HasRoundPrediction: hasRoundPrediction,
+			PredictionPoints:   predictionTotals[key],
+			PredictionRounds:   predictionRounds[key],
+			AvgPredictionPts:   predictionAvg,
+			GuessPoints:        guessTallies[key].Points,
+			CorrectGuesses:     guessTallies[key].Correct,
 		})
 	}
 
@@ -491,7 +741,7 @@ func (g *ClusterGame) getStandings(ctx context.Context, lobbyID pgtype.UUID, pla
 	return standings, nil
 }
 
-func scoreRound(submissions []submissionRecord, centroidX float64, centroidY float64, currentPlayerID string) ([]clustertmpl.DotView, map[string]int, map[string]float64, map[string]float64, []string, []string) {
+func scoreRound(submissions []submissionRecord, scorer roundScorer, currentPlayerID string) ([]clustertmpl.DotView, map[string]int, map[string]float64, map[string]float64, []string, []string) {
 	if len(submissions) == 0 {
 		return nil, map[string]int{}, map[string]float64{}, map[string]float64{}, nil, nil
 	}
@@ -512,8 +762,8 @@ func scoreRound(submissions []submissionRecord, centroidX float64, centroidY flo
 	}
 
 	for i, sub := range submissions {
-		points := CalculateRoundPoints(sub.X, sub.Y, centroidX, centroidY)
-		centerDistance := CalculateDistance(sub.X, sub.Y, centroidX, centroidY)
+		points := scorer.points(sub.X, sub.Y)
+		centerDistance := scorer.distanceFromTarget(sub.X, sub.Y)
 		playerKey := sub.PlayerID.String()
 		roundPoints[playerKey] = points
 		roundCenterDistances[playerKey] = centerDistance
@@ -527,14 +777,21 @@ func scoreRound(submissions []submissionRecord, centroidX float64, centroidY flo
 			minPoints = points
 		}
 
-		dots = append(dots, clustertmpl.DotView{
+		dot := clustertmpl.DotView{
 			Nickname:        sub.Nickname,
 			X:               sub.X,
 			Y:               sub.Y,
 			Points:          points,
 			AnimationDelay:  i * 80,
 			IsCurrentPlayer: playerKey == currentPlayerID,
-		})
+		}
+		if sub.hasPrediction() {
+			dot.HasPrediction = true
+			dot.PredictedX = sub.PredictedX.Float64
+			dot.PredictedY = sub.PredictedY.Float64
+			dot.PredictionPoints = scorer.points(dot.PredictedX, dot.PredictedY)
+		}
+		dots = append(dots, dot)
 	}
 
 	winners := make([]string, 0)
@@ -556,3 +813,31 @@ func scoreRound(submissions []submissionRecord, centroidX float64, centroidY flo
 	}
 	return dots, roundPoints, roundCenterDistances, roundDistances, winners, outliers
 }
+
+// scorePredictions returns each player's prediction points for a revealed round.
+func scorePredictions(submissions []submissionRecord, scorer roundScorer) map[string]int {
+	points := make(map[string]int, len(submissions))
+	for _, sub := range submissions {
+		if sub.hasPrediction() {
+			points[sub.PlayerID.String()] = scorer.points(sub.PredictedX.Float64, sub.PredictedY.Float64)
+		}
+	}
+	return points
+}
+
+// predictionLeaders orders players with scored predictions by prediction total.
+func predictionLeaders(standings []clustertmpl.StandingView) []clustertmpl.StandingView {
+	leaders := make([]clustertmpl.StandingView, 0, len(standings))
+	for _, standing := range standings {
+		if standing.PredictionRounds > 0 {
+			leaders = append(leaders, standing)
+		}
+	}
+	sort.SliceStable(leaders, func(i, j int) bool {
+		if leaders[i].PredictionPoints == leaders[j].PredictionPoints {
+			return strings.ToLower(leaders[i].Nickname) < strings.ToLower(leaders[j].Nickname)
+		}
+		return leaders[i].PredictionPoints > leaders[j].PredictionPoints
+	})
+	return leaders
+}
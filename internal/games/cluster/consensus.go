@@ -0,0 +1,241 @@
+package cluster
+
+import (
+	"math"
+	"sort"
+
+	clustertmpl "github.com/jgoodhcg/mindmeld/templates/cluster"
+)
+
+// Consensus strategies decide where the group target lands on reveal.
+const (
+	ConsensusMean            = "mean"
+	ConsensusGeometricMedian = "geometric_median"
+	ConsensusTrimmedMean     = "trimmed_mean"
+	ConsensusDensityPeak     = "density_peak"
+)
+
+const (
+	// trimFraction is the share of points dropped from each end of each axis
+	// by the trimmed mean.
+	trimFraction = 0.2
+	// densityBandwidth is the Gaussian kernel width used for the density peak,
+	// in unit-square coordinates.
+	densityBandwidth = 0.15
+
+	consensusMaxIterations = 200
+	consensusEpsilon       = 1e-9
+)
+
+// ConsensusStrategies lists the selectable strategies in display order.
+var ConsensusStrategies = []string{
+	ConsensusMean,
+	ConsensusGeometricMedian,
+	ConsensusTrimmedMean,
+	ConsensusDensityPeak,
+}
+
+// ParseConsensusStrategy returns the strategy named by raw, or the mean for
+// anything unknown.
+func ParseConsensusStrategy(raw string) (string, bool) {
+	for _, strategy := range ConsensusStrategies {
+		if raw == strategy {
+			return strategy, true
+		}
+	}
+	return ConsensusMean, false
+}
+
+// CalculateConsensus returns the group target for points under strategy.
+func CalculateConsensus(strategy string, points []Point) (float64, float64, bool) {
+	switch strategy {
+	case ConsensusGeometricMedian:
+		return CalculateGeometricMedian(points)
+	case ConsensusTrimmedMean:
+		return CalculateTrimmedMean(points)
+	case ConsensusDensityPeak:
+		return CalculateDensityPeak(points)
+	default:
+		return CalculateCentroid(points)
+	}
+}
+
+// CalculateGeometricMedian returns the point minimizing the summed distance to
+// all points, using Weiszfeld's iteration with the Vardi-Zhang correction so
+// the estimate can settle on a data point.
+func CalculateGeometricMedian(points []Point) (float64, float64, bool) {
+	x, y, ok := CalculateCentroid(points)
+	if !ok {
+		return 0, 0, false
+	}
+	clamped := clampPoints(points)
+
+	for range consensusMaxIterations {
+		var sumX, sumY, weight, pullX, pullY float64
+		coincident := 0
+		for _, p := range clamped {
+			d := math.Hypot(p.X-x, p.Y-y)
+			if d < consensusEpsilon {
+				coincident++
+				continue
+			}
+			sumX += p.X / d
+			sumY += p.Y / d
+			weight += 1 / d
+			pullX += (p.X - x) / d
+			pullY += (p.Y - y) / d
+		}
+		if weight == 0 {
+			break
+		}
+
+		nextX, nextY := sumX/weight, sumY/weight
+		if coincident > 0 {
+			// Sitting on a data point: stay if the pull from the other points
+			// cannot outweigh the points stacked here.
+			pull := math.Hypot(pullX, pullY)
+			if pull <= float64(coincident) {
+				break
+			}
+			step := float64(coincident) / pull
+			nextX = (1-step)*nextX + step*x
+			nextY = (1-step)*nextY + step*y
+		}
+
+		moved := math.Hypot(nextX-x, nextY-y)
+		x, y = nextX, nextY
+		if moved < consensusEpsilon {
+			break
+		}
+	}
+	return clampUnit(x), clampUnit(y), true
+}
+
+// CalculateTrimmedMean averages each axis after dropping the lowest and highest
+// trimFraction of values on that axis. Groups under five fall back to the mean.
+func CalculateTrimmedMean(points []Point) (float64, float64, bool) {
+	if len(points) == 0 {
+		return 0, 0, false
+	}
+
+	xs := make([]float64, len(points))
+	ys := make([]float64, len(points))
+	for i, p := range points {
+		xs[i] = clampUnit(p.X)
+		ys[i] = clampUnit(p.Y)
+	}
+	trim := int(float64(len(points)) * trimFraction)
+	return trimmedAverage(xs, trim), trimmedAverage(ys, trim), true
+}
+
+// CalculateDensityPeak returns the highest point of a Gaussian kernel density
+// estimate. Mean shift runs from every point and the densest mode wins, with
+// ties going to the earliest submission.
+func CalculateDensityPeak(points []Point) (float64, float64, bool) {
+	if len(points) == 0 {
+		return 0, 0, false
+	}
+	clamped := clampPoints(points)
+
+	best := clamped[0]
+	bestDensity := -1.0
+	for _, start := range clamped {
+		mode := meanShift(clamped, start)
+		if density := kernelDensity(clamped, mode); density > bestDensity+consensusEpsilon {
+			best, bestDensity = mode, density
+		}
+	}
+	return clampUnit(best.X), clampUnit(best.Y), true
+}
+
+func meanShift(points []Point, start Point) Point {
+	current := start
+	for range consensusMaxIterations {
+		var sumX, sumY, weight float64
+		for _, p := range points {
+			k := gaussianKernel(current, p)
+			sumX += k * p.X
+			sumY += k * p.Y
+			weight += k
+		}
+		if weight == 0 {
+			break
+		}
+		next := Point{X: sumX / weight, Y: sumY / weight}
+		moved := math.Hypot(next.X-current.X, next.Y-current.Y)
+		current = next
+		if moved < consensusEpsilon {
+			break
+		}
+	}
+	return current
+}
+
+func kernelDensity(points []Point, at Point) float64 {
+	total := 0.0
+	for _, p := range points {
+		total += gaussianKernel(at, p)
+	}
+	return total
+}
+
+func gaussianKernel(a, b Point) float64 {
+	dx := a.X - b.X
+	dy := a.Y - b.Y
+	return math.Exp(-(dx*dx + dy*dy) / (2 * densityBandwidth * densityBandwidth))
+}
+
+func trimmedAverage(values []float64, trim int) float64 {
+	sort.Float64s(values)
+	kept := values[trim : len(values)-trim]
+	sum := 0.0
+	for _, v := range kept {
+		sum += v
+	}
+	return sum / float64(len(kept))
+}
+
+func clampPoints(points []Point) []Point {
+	clamped := make([]Point, len(points))
+	for i, p := range points {
+		clamped[i] = Point{X: clampUnit(p.X), Y: clampUnit(p.Y)}
+	}
+	return clamped
+}
+
+var consensusOptions = map[string]clustertmpl.ConsensusOption{
+	ConsensusMean: {
+		Value:       ConsensusMean,
+		Label:       "Average",
+		Description: "Mean of every point. One far-off answer pulls the target.",
+	},
+	ConsensusGeometricMedian: {
+		Value:       ConsensusGeometricMedian,
+		Label:       "Median",
+		Description: "The spot closest to everyone overall. A lone outlier barely moves it.",
+	},
+	ConsensusTrimmedMean: {
+		Value:       ConsensusTrimmedMean,
+		Label:       "Trimmed average",
+		Description: "Average after dropping the most extreme fifth of answers on each axis.",
+	},
+	ConsensusDensityPeak: {
+		Value:       ConsensusDensityPeak,
+		Label:       "Crowd peak",
+		Description: "Where answers are packed most tightly. The biggest cluster wins.",
+	},
+}
+
+func buildConsensusView(current string, revealed string) clustertmpl.ConsensusView {
+	options := make([]clustertmpl.ConsensusOption, 0, len(ConsensusStrategies))
+	for _, strategy := range ConsensusStrategies {
+		options = append(options, consensusOptions[strategy])
+	}
+	current, _ = ParseConsensusStrategy(current)
+	revealed, _ = ParseConsensusStrategy(revealed)
+	return clustertmpl.ConsensusView{
+		Current:  consensusOptions[current],
+		Revealed: consensusOptions[revealed],
+		Options:  options,
+	}
+}
@@ -0,0 +1,144 @@
+package cluster
+
+import (
+	"math"
+	"testing"
+)
+
+func assertNear(t *testing.T, label string, gotX, gotY, wantX, wantY, tolerance float64) {
+	t.Helper()
+	if math.Hypot(gotX-wantX, gotY-wantY) > tolerance {
+		t.Fatalf("%s: expected about (%.3f, %.3f), got (%.4f, %.4f)", label, wantX, wantY, gotX, gotY)
+	}
+}
+
+func TestConsensusResistsSingleOutlier(t *testing.T) {
+	points := []Point{
+		{X: 0.30, Y: 0.30},
+		{X: 0.32, Y: 0.28},
+		{X: 0.28, Y: 0.31},
+		{X: 0.31, Y: 0.32},
+		{X: 1.00, Y: 1.00},
+	}
+
+	meanX, meanY, _ := CalculateCentroid(points)
+	if math.Hypot(meanX-0.3, meanY-0.3) < 0.1 {
+		t.Fatalf("expected the mean to be dragged by the outlier, got (%.3f, %.3f)", meanX, meanY)
+	}
+
+	for _, strategy := range []string{ConsensusGeometricMedian, ConsensusTrimmedMean, ConsensusDensityPeak} {
+		x, y, ok := CalculateConsensus(strategy, points)
+		if !ok {
+			t.Fatalf("%s: expected consensus", strategy)
+		}
+		assertNear(t, strategy, x, y, 0.30, 0.30, 0.03)
+	}
+}
+
+func TestConsensusOutlierDistanceDoesNotMoveMedian(t *testing.T) {
+	base := []Point{
+		{X: 0.40, Y: 0.40},
+		{X: 0.45, Y: 0.42},
+		{X: 0.42, Y: 0.46},
+	}
+	near := append(append([]Point{}, base...), Point{X: 0.60, Y: 0.60})
+	far := append(append([]Point{}, base...), Point{X: 1.00, Y: 1.00})
+
+	nearX, nearY, _ := CalculateGeometricMedian(near)
+	farX, farY, _ := CalculateGeometricMedian(far)
+	assertNear(t, "median", farX, farY, nearX, nearY, 0.01)
+}
+
+func TestGeometricMedianSettlesOnStackedMajority(t *testing.T) {
+	points := []Point{
+		{X: 0.2, Y: 0.2},
+		{X: 0.2, Y: 0.2},
+		{X: 0.2, Y: 0.2},
+		{X: 0.9, Y: 0.9},
+		{X: 0.9, Y: 0.1},
+	}
+
+	x, y, ok := CalculateGeometricMedian(points)
+	if !ok {
+		t.Fatal("expected median")
+	}
+	assertNear(t, "median", x, y, 0.2, 0.2, 1e-6)
+}
+
+func TestGeometricMedianOfCollinearPointsIsMiddlePoint(t *testing.T) {
+	points := []Point{
+		{X: 0.1, Y: 0.5},
+		{X: 0.3, Y: 0.5},
+		{X: 0.4, Y: 0.5},
+		{X: 0.8, Y: 0.5},
+		{X: 0.9, Y: 0.5},
+	}
+
+	x, y, _ := CalculateGeometricMedian(points)
+	assertNear(t, "median", x, y, 0.4, 0.5, 1e-4)
+}
+
+func TestDensityPeakPicksLargerCluster(t *testing.T) {
+	points := []Point{
+		{X: 0.80, Y: 0.20},
+		{X: 0.82, Y: 0.22},
+		{X: 0.20, Y: 0.80},
+		{X: 0.22, Y: 0.78},
+		{X: 0.18, Y: 0.82},
+		{X: 0.21, Y: 0.81},
+	}
+
+	meanX, meanY, _ := CalculateCentroid(points)
+	assertNear(t, "mean lands between clusters", meanX, meanY, 0.4, 0.6, 0.05)
+
+	x, y, _ := CalculateDensityPeak(points)
+	assertNear(t, "density peak", x, y, 0.20, 0.80, 0.03)
+}
+
+func TestTrimmedMeanDropsExtremesPerAxis(t *testing.T) {
+	points := []Point{
+		{X: 0.0, Y: 0.5},
+		{X: 0.4, Y: 0.5},
+		{X: 0.5, Y: 0.5},
+		{X: 0.6, Y: 0.5},
+		{X: 1.0, Y: 0.5},
+	}
+
+	x, y, _ := CalculateTrimmedMean(points)
+	assertNear(t, "trimmed mean", x, y, 0.5, 0.5, 1e-9)
+
+	small := []Point{{X: 0.2, Y: 0.2}, {X: 0.4, Y: 0.4}, {X: 0.9, Y: 0.9}}
+	tx, ty, _ := CalculateTrimmedMean(small)
+	mx, my, _ := CalculateCentroid(small)
+	assertNear(t, "small group falls back to mean", tx, ty, mx, my, 1e-9)
+}
+
+func TestConsensusHandlesDegenerateInput(t *testing.T) {
+	for _, strategy := range ConsensusStrategies {
+		if _, _, ok := CalculateConsensus(strategy, nil); ok {
+			t.Fatalf("%s: expected no consensus for empty input", strategy)
+		}
+
+		same := []Point{{X: 0.7, Y: 0.1}, {X: 0.7, Y: 0.1}, {X: 0.7, Y: 0.1}}
+		x, y, ok := CalculateConsensus(strategy, same)
+		if !ok {
+			t.Fatalf("%s: expected consensus for identical points", strategy)
+		}
+		assertNear(t, strategy+" identical", x, y, 0.7, 0.1, 1e-9)
+
+		outside := []Point{{X: -3, Y: 4}, {X: 5, Y: -2}}
+		x, y, _ = CalculateConsensus(strategy, outside)
+		if x < 0 || x > 1 || y < 0 || y > 1 {
+			t.Fatalf("%s: expected result inside the unit square, got (%v, %v)", strategy, x, y)
+		}
+	}
+}
+
+func TestParseConsensusStrategy(t *testing.T) {
+	if got, ok := ParseConsensusStrategy(ConsensusDensityPeak); !ok || got != ConsensusDensityPeak {
+		t.Fatalf("expected density_peak, got %q (ok=%v)", got, ok)
+	}
+	if got, ok := ParseConsensusStrategy("mode"); ok || got != ConsensusMean {
+		t.Fatalf("expected unknown strategy to fall back to mean, got %q (ok=%v)", got, ok)
+	}
+}
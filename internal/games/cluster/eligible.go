@@ -0,0 +1,29 @@
+package cluster
+
+// eligiblePairsCTE selects the library prompt-axis pairs a lobby can still
+// play: every row active, rated at or below the lobby, in the theme playlist
+// (any theme when empty), not written by a host, and not played in the lobby
+// yet. It binds $1 lobby id, $2 lobby content rating and $3 theme playlist.
+//
+// The remaining-rounds count, the rotation draw and the host picker all
+// select from it, so they always agree on what is left to play.
+const eligiblePairsCTE = `
+	eligible_pairs AS (
+		SELECT cpas.id, cpas.prompt_id, cpas.axis_set_id
+		FROM coordinates_prompt_axis_sets cpas
+		JOIN coordinates_prompts cp ON cp.id = cpas.prompt_id
+		JOIN coordinates_axis_sets cas ON cas.id = cpas.axis_set_id
+		WHERE cpas.is_active = TRUE
+		  AND cp.is_active = TRUE
+		  AND cas.is_active = TRUE
+		  AND cp.created_by_kind <> 'user'
+		  AND cp.min_rating <= $2
+		  AND cas.min_rating <= $2
+		  AND (COALESCE(cardinality($3::text[]), 0) = 0 OR cp.provenance->>'theme' = ANY($3::text[]))
+		  AND NOT EXISTS (
+				SELECT 1
+				FROM coordinates_rounds cr
+				WHERE cr.lobby_id = $1
+				  AND cr.prompt_axis_set_id = cpas.id
+		  )
+	)`
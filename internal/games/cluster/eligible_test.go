@@ -0,0 +1,93 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+var errRecorded = errors.New("recorded")
+
+type recordedQuery struct {
+	sql  string
+	args []any
+}
+
+// recordingDB captures the SQL sent to it. Single-value scans (round numbers
+// and counts) succeed with zero so callers carry on to their main query.
+type recordingDB struct {
+	queries []recordedQuery
+}
+
+func (r *recordingDB) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	r.queries = append(r.queries, recordedQuery{sql, args})
+	return pgconn.CommandTag{}, errRecorded
+}
+
+func (r *recordingDB) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	r.queries = append(r.queries, recordedQuery{sql, args})
+	return nil, errRecorded
+}
+
+func (r *recordingDB) QueryRow(_ context.Context, sql string, args ...any) pgx.Row {
+	r.queries = append(r.queries, recordedQuery{sql, args})
+	return recordedRow{}
+}
+
+type recordedRow struct{}
+
+func (recordedRow) Scan(dest ...any) error {
+	if len(dest) != 1 {
+		return pgx.ErrNoRows
+	}
+	switch d := dest[0].(type) {
+	case *int:
+		*d = 0
+	case *int32:
+		*d = 0
+	default:
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func TestEligiblePairQueriesShareOneFilter(t *testing.T) {
+	ctx := context.Background()
+	g := &ClusterGame{}
+	lobbyID := uuidByte(9)
+	rating := contentrating.Work
+	themes := []string{"work"}
+
+	rec := &recordingDB{}
+	_, _ = g.countRemainingPromptAxisSets(ctx, rec, lobbyID, rating, themes)
+	_, _, _ = g.getRotationCandidates(ctx, rec, lobbyID, rating, themes)
+	_, _ = g.listEligiblePromptAxisSets(ctx, rec, lobbyID, rating, themes, "en")
+	_, _ = g.getEligiblePromptAxisSet(ctx, rec, lobbyID, rating, themes, uuidByte(1))
+
+	want := []any{lobbyID, rating, themes}
+	eligible := 0
+	for _, q := range rec.queries {
+		if !strings.Contains(q.sql, "coordinates_prompt_axis_sets") {
+			continue
+		}
+		eligible++
+		if !strings.Contains(q.sql, eligiblePairsCTE) {
+			t.Errorf("expected query to select from eligible_pairs:\n%s", q.sql)
+		}
+		if n := strings.Count(q.sql, "cpas.is_active"); n != 1 {
+			t.Errorf("expected the eligibility filter once, found it %d times:\n%s", n, q.sql)
+		}
+		if len(q.args) < len(want) || !reflect.DeepEqual(q.args[:len(want)], want) {
+			t.Errorf("expected eligibility args %v, got %v", want, q.args)
+		}
+	}
+	if eligible != 4 {
+		t.Fatalf("expected count, rotation, picker list and picker lookup queries, got %d", eligible)
+	}
+}
@@ -0,0 +1,286 @@
+package cluster
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	clustertmpl "github.com/jgoodhcg/mindmeld/templates/cluster"
+)
+
+const (
+	// minGuessPlayers is the smallest round that features a mystery dot. With
+	// two players the answer is whoever you are not.
+	minGuessPlayers = 3
+	// guessPoints are awarded for each correct attribution.
+	guessPoints = 50
+)
+
+type guessRecord struct {
+	GuesserID pgtype.UUID
+	GuessedID pgtype.UUID
+}
+
+type guessTally struct {
+	Correct int
+	Points  int
+}
+
+// pickMysteryPlayer chooses whose dot to feature. Players are ranked by how
+// far they landed from the group target, with anyone already featured in the
+// lobby ranked last, and one of the most distinctive third is drawn with intn.
+func pickMysteryPlayer(submissions []submissionRecord, scorer roundScorer, featured map[string]bool, intn func(int) int) (pgtype.UUID, bool) {
+	if len(submissions) < minGuessPlayers {
+		return pgtype.UUID{}, false
+	}
+
+	ranked := slices.Clone(submissions)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		fi := featured[ranked[i].LobbyPlayerID.String()]
+		fj := featured[ranked[j].LobbyPlayerID.String()]
+		if fi != fj {
+			return !fi
+		}
+		return scorer.distanceFromTarget(ranked[i].X, ranked[i].Y) > scorer.distanceFromTarget(ranked[j].X, ranked[j].Y)
+	})
+
+	pool := max(1, len(ranked)/3)
+	fresh := 0
+	for _, sub := range ranked {
+		if !featured[sub.LobbyPlayerID.String()] {
+			fresh++
+		}
+	}
+	if fresh > 0 {
+		pool = min(pool, fresh)
+	}
+	return ranked[intn(pool)].LobbyPlayerID, true
+}
+
+// buildGuessView describes the guess-the-player reveal for the viewer. Names
+// tied to the mystery dot stay out of the view until the answer is shown.
+func buildGuessView(enabled bool, round coordinatesRound, submissions []submissionRecord, guesses []guessRecord, currentPlayerID string) clustertmpl.GuessView {
+	view := clustertmpl.GuessView{Enabled: enabled}
+	if !round.MysteryPlayerID.Valid {
+		return view
+	}
+
+	mysteryKey := round.MysteryPlayerID.String()
+	names := make(map[string]string, len(submissions))
+	var viewerKey string
+	for _, sub := range submissions {
+		key := sub.LobbyPlayerID.String()
+		names[key] = sub.Nickname
+		if sub.PlayerID.String() == currentPlayerID {
+			viewerKey = key
+		}
+		if key == mysteryKey {
+			view.Active = true
+			view.MysteryX = sub.X
+			view.MysteryY = sub.Y
+		}
+	}
+	if !view.Active {
+		return view
+	}
+
+	view.AnswerShown = round.MysteryRevealed
+	view.IsMystery = viewerKey == mysteryKey
+	view.CanGuess = viewerKey != "" && !view.IsMystery && !view.AnswerShown
+	view.ExpectedGuesses = len(submissions) - 1
+	view.PointsPerGuess = guessPoints
+	view.GuessCount = len(guesses)
+
+	for _, guess := range guesses {
+		guesser := guess.GuesserID.String()
+		if guesser == viewerKey {
+			view.GuessedValue = guess.GuessedID.String()
+			view.GuessedNickname = names[view.GuessedValue]
+		}
+		if view.AnswerShown && guess.GuessedID.String() == mysteryKey {
+			view.CorrectGuessers = append(view.CorrectGuessers, names[guesser])
+		}
+	}
+	sort.Strings(view.CorrectGuessers)
+
+	if view.AnswerShown {
+		view.AnswerNickname = names[mysteryKey]
+	}
+	if view.CanGuess {
+		for _, sub := range submissions {
+			key := sub.LobbyPlayerID.String()
+			if key == viewerKey {
+				continue
+			}
+			view.Options = append(view.Options, clustertmpl.GuessOption{Value: key, Nickname: sub.Nickname})
+		}
+		sort.SliceStable(view.Options, func(i, j int) bool {
+			return strings.ToLower(view.Options[i].Nickname) < strings.ToLower(view.Options[j].Nickname)
+		})
+	}
+	return view
+}
+
+// guessLeaders orders players with correct attributions by guess points.
+func guessLeaders(standings []clustertmpl.StandingView) []clustertmpl.StandingView {
+	leaders := make([]clustertmpl.StandingView, 0, len(standings))
+	for _, standing := range standings {
+		if standing.CorrectGuesses > 0 {
+			leaders = append(leaders, standing)
+		}
+	}
+	sort.SliceStable(leaders, func(i, j int) bool {
+		if leaders[i].GuessPoints == leaders[j].GuessPoints {
+			return strings.ToLower(leaders[i].Nickname) < strings.ToLower(leaders[j].Nickname)
+		}
+		return leaders[i].GuessPoints > leaders[j].GuessPoints
+	})
+	return leaders
+}
+
+func (g *ClusterGame) setRoundMystery(ctx context.Context, q db.DBTX, roundID pgtype.UUID, lobbyPlayerID pgtype.UUID) error {
+	const query = `
+		UPDATE coordinates_rounds
+		SET mystery_player_id = $2
+		WHERE id = $1
+	`
+
+	_, err := q.Exec(ctx, query, roundID, lobbyPlayerID)
+	return err
+}
+
+// revealMystery shows the answer for the round's mystery dot. It is a no-op
+// once the answer is already shown.
+func (g *ClusterGame) revealMystery(ctx context.Context, q db.DBTX, roundID pgtype.UUID) error {
+	const query = `
+		UPDATE coordinates_rounds
+		SET mystery_revealed_at = NOW()
+		WHERE id = $1
+		  AND mystery_player_id IS NOT NULL
+		  AND mystery_revealed_at IS NULL
+	`
+
+	_, err := q.Exec(ctx, query, roundID)
+	return err
+}
+
+// getFeaturedMysteryPlayers returns the lobby players already featured in
+// earlier rounds, keyed by lobby player id.
+func (g *ClusterGame) getFeaturedMysteryPlayers(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID) (map[string]bool, error) {
+	const query = `
+		SELECT DISTINCT mystery_player_id
+		FROM coordinates_rounds
+		WHERE lobby_id = $1
+		  AND mystery_player_id IS NOT NULL
+	`
+
+	rows, err := q.Query(ctx, query, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	featured := make(map[string]bool)
+	for rows.Next() {
+		var id pgtype.UUID
+		if scanErr := rows.Scan(&id); scanErr != nil {
+			return nil, scanErr
+		}
+		featured[id.String()] = true
+	}
+	return featured, rows.Err()
+}
+
+func (g *ClusterGame) upsertGuess(ctx context.Context, q db.DBTX, roundID pgtype.UUID, guesserID pgtype.UUID, guessedID pgtype.UUID) error {
+	const query = `
+		INSERT INTO coordinates_player_guesses (round_id, guesser_id, guessed_player_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (round_id, guesser_id)
+		DO UPDATE SET guessed_player_id = EXCLUDED.guessed_player_id, guessed_at = NOW()
+	`
+
+	_, err := q.Exec(ctx, query, roundID, guesserID, guessedID)
+	return err
+}
+
+// guessingDone reports whether every player still expected to guess has.
+// Only players who placed this round and are currently active count, minus
+// the mystery player, so one disconnected player cannot stall the reveal.
+func guessingDone(submissions []submissionRecord, guesses []guessRecord, mysteryID pgtype.UUID, isActive func(playerID pgtype.UUID) bool) bool {
+	guessed := make(map[pgtype.UUID]bool, len(guesses))
+	for _, guess := range guesses {
+		guessed[guess.GuesserID] = true
+	}
+	for _, sub := range submissions {
+		if sub.LobbyPlayerID == mysteryID || guessed[sub.LobbyPlayerID] {
+			continue
+		}
+		if isActive(sub.PlayerID) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *ClusterGame) getRoundGuesses(ctx context.Context, q db.DBTX, roundID pgtype.UUID) ([]guessRecord, error) {
+	const query = `
+		SELECT guesser_id, guessed_player_id
+		FROM coordinates_player_guesses
+		WHERE round_id = $1
+		ORDER BY guessed_at
+	`
+
+	rows, err := q.Query(ctx, query, roundID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]guessRecord, 0)
+	for rows.Next() {
+		var item guessRecord
+		if scanErr := rows.Scan(&item.GuesserID, &item.GuessedID); scanErr != nil {
+			return nil, scanErr
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// getGuessTallies totals correct attributions per player across rounds whose
+// answer has been shown, keyed by player id. Open rounds are left out so the
+// standings cannot give the answer away.
+func (g *ClusterGame) getGuessTallies(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID) (map[string]guessTally, error) {
+	const query = `
+		SELECT lp.player_id, COUNT(*)
+		FROM coordinates_player_guesses cg
+		JOIN coordinates_rounds cr ON cr.id = cg.round_id
+		JOIN lobby_players lp ON lp.id = cg.guesser_id
+		WHERE cr.lobby_id = $1
+		  AND cr.mystery_revealed_at IS NOT NULL
+		  AND cg.guessed_player_id = cr.mystery_player_id
+		GROUP BY lp.player_id
+	`
+
+	rows, err := q.Query(ctx, query, lobbyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tallies := make(map[string]guessTally)
+	for rows.Next() {
+		var (
+			playerID pgtype.UUID
+			correct  int
+		)
+		if scanErr := rows.Scan(&playerID, &correct); scanErr != nil {
+			return nil, scanErr
+		}
+		tallies[playerID.String()] = guessTally{Correct: correct, Points: correct * guessPoints}
+	}
+	return tallies, rows.Err()
+}
@@ -0,0 +1,127 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func guessSubmissions() []submissionRecord {
+	return []submissionRecord{
+		{LobbyPlayerID: uuidByte(11), PlayerID: uuidByte(1), Nickname: "Ada", X: 0.50, Y: 0.50},
+		{LobbyPlayerID: uuidByte(12), PlayerID: uuidByte(2), Nickname: "Bo", X: 0.55, Y: 0.45},
+		{LobbyPlayerID: uuidByte(13), PlayerID: uuidByte(3), Nickname: "Cy", X: 0.95, Y: 0.95},
+		{LobbyPlayerID: uuidByte(14), PlayerID: uuidByte(4), Nickname: "Di", X: 0.48, Y: 0.52},
+		{LobbyPlayerID: uuidByte(15), PlayerID: uuidByte(5), Nickname: "Ed", X: 0.05, Y: 0.10},
+		{LobbyPlayerID: uuidByte(16), PlayerID: uuidByte(6), Nickname: "Flo", X: 0.52, Y: 0.50},
+	}
+}
+
+func TestPickMysteryPlayerDrawsFromMostDistinctive(t *testing.T) {
+	submissions := guessSubmissions()
+	scorer := newRoundScorer(axisShape{}, submissions, 0.5, 0.5)
+
+	seen := map[string]bool{}
+	for i := range 2 {
+		id, ok := pickMysteryPlayer(submissions, scorer, nil, func(int) int { return i })
+		if !ok {
+			t.Fatal("expected a mystery player")
+		}
+		seen[id.String()] = true
+	}
+	if len(seen) != 2 || !seen[uuidByte(13).String()] || !seen[uuidByte(15).String()] {
+		t.Fatalf("expected the two far points to be the pool, got %v", seen)
+	}
+}
+
+func TestPickMysteryPlayerSkipsFeaturedPlayers(t *testing.T) {
+	submissions := guessSubmissions()
+	scorer := newRoundScorer(axisShape{}, submissions, 0.5, 0.5)
+	featured := map[string]bool{uuidByte(13).String(): true, uuidByte(15).String(): true}
+
+	id, ok := pickMysteryPlayer(submissions, scorer, featured, func(int) int { return 0 })
+	if !ok || id != uuidByte(12) {
+		t.Fatalf("expected the most distinctive unfeatured player, got %v", id)
+	}
+
+	everyone := map[string]bool{}
+	for _, sub := range submissions {
+		everyone[sub.LobbyPlayerID.String()] = true
+	}
+	id, ok = pickMysteryPlayer(submissions, scorer, everyone, func(int) int { return 0 })
+	if !ok || id != uuidByte(13) {
+		t.Fatalf("expected fallback to the most distinctive player, got %v", id)
+	}
+}
+
+func TestPickMysteryPlayerNeedsThreePlayers(t *testing.T) {
+	submissions := guessSubmissions()[:2]
+	scorer := newRoundScorer(axisShape{}, submissions, 0.5, 0.5)
+	if _, ok := pickMysteryPlayer(submissions, scorer, nil, func(int) int { return 0 }); ok {
+		t.Fatal("expected no mystery player with two submissions")
+	}
+}
+
+func TestBuildGuessViewHidesAnswerUntilShown(t *testing.T) {
+	submissions := guessSubmissions()[:3]
+	round := coordinatesRound{MysteryPlayerID: uuidByte(13)}
+	guesses := []guessRecord{
+		{GuesserID: uuidByte(11), GuessedID: uuidByte(13)},
+		{GuesserID: uuidByte(12), GuessedID: uuidByte(11)},
+	}
+
+	view := buildGuessView(true, round, submissions, guesses, uuidByte(1).String())
+	if !view.Active || view.AnswerShown || view.AnswerNickname != "" || len(view.CorrectGuessers) != 0 {
+		t.Fatalf("expected an open guess with no answer, got %+v", view)
+	}
+	if !view.CanGuess || view.GuessedNickname != "Cy" || view.GuessCount != 2 || view.ExpectedGuesses != 2 {
+		t.Fatalf("unexpected viewer state: %+v", view)
+	}
+	if len(view.Options) != 2 || view.Options[0].Nickname != "Bo" || view.Options[1].Nickname != "Cy" {
+		t.Fatalf("expected the other players as options, got %+v", view.Options)
+	}
+	if view.MysteryX != 0.95 || view.MysteryY != 0.95 {
+		t.Fatalf("expected the mystery point, got (%v, %v)", view.MysteryX, view.MysteryY)
+	}
+
+	mystery := buildGuessView(true, round, submissions, guesses, uuidByte(3).String())
+	if !mystery.IsMystery || mystery.CanGuess {
+		t.Fatalf("expected the mystery player not to guess, got %+v", mystery)
+	}
+
+	round.MysteryRevealed = true
+	shown := buildGuessView(true, round, submissions, guesses, uuidByte(2).String())
+	if shown.CanGuess || shown.AnswerNickname != "Cy" || len(shown.CorrectGuessers) != 1 || shown.CorrectGuessers[0] != "Ada" {
+		t.Fatalf("unexpected shown answer: %+v", shown)
+	}
+}
+
+func TestBuildGuessViewWithoutMystery(t *testing.T) {
+	view := buildGuessView(true, coordinatesRound{MysteryPlayerID: pgtype.UUID{}}, guessSubmissions(), nil, uuidByte(1).String())
+	if !view.Enabled || view.Active {
+		t.Fatalf("expected an inactive guess view, got %+v", view)
+	}
+}
+
+func TestGuessingDoneIgnoresInactivePlayers(t *testing.T) {
+	submissions := guessSubmissions()
+	mystery := uuidByte(13)
+	guesses := []guessRecord{
+		{GuesserID: uuidByte(11), GuessedID: mystery},
+		{GuesserID: uuidByte(12), GuessedID: mystery},
+		{GuesserID: uuidByte(14), GuessedID: uuidByte(15)},
+		{GuesserID: uuidByte(16), GuessedID: mystery},
+	}
+	everyoneActive := func(pgtype.UUID) bool { return true }
+	edAway := func(playerID pgtype.UUID) bool { return playerID != uuidByte(5) }
+
+	if guessingDone(submissions, guesses, mystery, everyoneActive) {
+		t.Fatal("expected to wait for Ed while Ed is active")
+	}
+	if !guessingDone(submissions, guesses, mystery, edAway) {
+		t.Fatal("expected reveal once only the disconnected player is missing")
+	}
+	if guessingDone(submissions, guesses[:2], mystery, edAway) {
+		t.Fatal("expected to wait for active players who have not guessed")
+	}
+}
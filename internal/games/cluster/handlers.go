@@ -1,18 +1,23 @@
 package cluster
 
 import (
+	"context"
 	"errors"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jgoodhcg/mindmeld/internal/auth"
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
 	"github.com/jgoodhcg/mindmeld/internal/db"
 	"github.com/jgoodhcg/mindmeld/internal/events"
+	clustertmpl "github.com/jgoodhcg/mindmeld/templates/cluster"
 )
 
 func (g *ClusterGame) handleStartGame(w http.ResponseWriter, r *http.Request) {
@@ -50,7 +55,14 @@ func (g *ClusterGame) handleStartGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	next, nextErr := g.getNextPromptAxisSet(r.Context(), g.dbPool, lobby.ID, lobby.ContentRating)
+	settings, err := g.getLobbySettings(r.Context(), g.dbPool, lobby.ID)
+	if err != nil {
+		log.Printf("[cluster] failed to get settings for lobby %s: %v", code, err)
+		http.Error(w, "Failed to start Cluster", http.StatusInternalServerError)
+		return
+	}
+
+	next, nextErr := g.getNextPromptAxisSet(r.Context(), g.dbPool, lobby.ID, lobby.ContentRating, settings.Themes)
 	if nextErr != nil {
 		if errors.Is(nextErr, pgx.ErrNoRows) {
 			if updateErr := g.queries.UpdateLobbyPhase(r.Context(), db.UpdateLobbyPhaseParams{ID: lobby.ID, Phase: "finished"}); updateErr != nil {
@@ -148,59 +160,143 @@ func (g *ClusterGame) handleSubmitCoordinate(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	pair, err := g.getPromptAxisSetForRound(ctx, tx, round.ID)
+	if err != nil {
+		log.Printf("[cluster] failed loading axis set for %s: %v", code, err)
+		http.Error(w, "Failed to submit coordinate", http.StatusInternalServerError)
+		return
+	}
+	x, y = pair.shape().normalize(x, y)
+
 	if err = g.upsertSubmission(ctx, tx, round.ID, participation.ID, x, y); err != nil {
 		log.Printf("[cluster] failed upserting submission for lobby %s: %v", code, err)
 		http.Error(w, "Failed to submit coordinate", http.StatusInternalServerError)
 		return
 	}
 
-	players, err := qtx.GetLobbyPlayers(ctx, lobby.ID)
+	submissionCount, expectedPlayers, revealed, err := g.revealIfComplete(ctx, tx, lobby, round)
 	if err != nil {
-		log.Printf("[cluster] failed loading players for lobby %s: %v", code, err)
+		log.Printf("[cluster] failed checking reveal for lobby %s: %v", code, err)
+		http.Error(w, "Failed to reveal round", http.StatusInternalServerError)
+		return
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Printf("[cluster] failed committing submission transaction for lobby %s: %v", code, err)
 		http.Error(w, "Failed to submit coordinate", http.StatusInternalServerError)
 		return
 	}
 
-	submissionCount, err := g.countRoundSubmissions(ctx, tx, round.ID)
+	g.publishSubmissionProgress(ctx, code, submissionCount, expectedPlayers, revealed)
+	http.Redirect(w, r, "/lobbies/"+code, http.StatusSeeOther)
+}
+
+// handleSubmitPrediction records where a player expects the group target to
+// land. It follows the player's own point in rounds that ask for predictions.
+func (g *ClusterGame) handleSubmitPrediction(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	player := auth.GetPlayer(r.Context())
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	x, err := strconv.ParseFloat(strings.TrimSpace(r.FormValue("x")), 64)
+	if err != nil || x < 0 || x > 1 {
+		http.Error(w, "x must be a number between 0 and 1", http.StatusBadRequest)
+		return
+	}
+	y, err := strconv.ParseFloat(strings.TrimSpace(r.FormValue("y")), 64)
+	if err != nil || y < 0 || y > 1 {
+		http.Error(w, "y must be a number between 0 and 1", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	lobby, err := g.queries.GetLobbyByCode(ctx, code)
 	if err != nil {
-		log.Printf("[cluster] failed counting submissions for lobby %s: %v", code, err)
-		http.Error(w, "Failed to submit coordinate", http.StatusInternalServerError)
+		http.Error(w, "Lobby not found", http.StatusNotFound)
+		return
+	}
+	if !strings.EqualFold(lobby.Phase, "playing") {
+		http.Error(w, "Cluster is not currently accepting submissions", http.StatusConflict)
 		return
 	}
 
-	expectedPlayers := g.countActivePlayers(code, players, time.Now())
-	revealed := submissionCount >= expectedPlayers && expectedPlayers > 0
-	if revealed {
-		submissions, subErr := g.getRoundSubmissions(ctx, tx, round.ID)
-		if subErr != nil {
-			log.Printf("[cluster] failed fetching submissions for centroid in lobby %s: %v", code, subErr)
-			http.Error(w, "Failed to reveal round", http.StatusInternalServerError)
-			return
-		}
+	tx, err := g.dbPool.Begin(ctx)
+	if err != nil {
+		log.Printf("[cluster] failed to begin prediction transaction: %v", err)
+		http.Error(w, "Failed to submit prediction", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
 
-		points := make([]Point, 0, len(submissions))
-		for _, sub := range submissions {
-			points = append(points, Point{X: sub.X, Y: sub.Y})
-		}
-		centroidX, centroidY, ok := CalculateCentroid(points)
-		if !ok {
-			http.Error(w, "Failed to reveal round", http.StatusInternalServerError)
-			return
-		}
+	participation, err := g.queries.WithTx(tx).GetPlayerParticipation(ctx, db.GetPlayerParticipationParams{
+		LobbyID:  lobby.ID,
+		PlayerID: player.ID,
+	})
+	if err != nil {
+		http.Error(w, "Not in lobby", http.StatusForbidden)
+		return
+	}
 
-		if err = g.setRoundCentroid(ctx, tx, round.ID, centroidX, centroidY); err != nil {
-			log.Printf("[cluster] failed storing centroid for lobby %s: %v", code, err)
-			http.Error(w, "Failed to reveal round", http.StatusInternalServerError)
+	round, err := g.getLatestRound(ctx, tx, lobby.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "No active Cluster round", http.StatusBadRequest)
 			return
 		}
+		log.Printf("[cluster] failed loading active round for %s: %v", code, err)
+		http.Error(w, "Failed to submit prediction", http.StatusInternalServerError)
+		return
+	}
+	if !round.PredictGroup {
+		http.Error(w, "This round does not take predictions", http.StatusConflict)
+		return
+	}
+	if round.CentroidX.Valid && round.CentroidY.Valid {
+		http.Error(w, "This round is already revealed", http.StatusConflict)
+		return
+	}
+
+	pair, err := g.getPromptAxisSetForRound(ctx, tx, round.ID)
+	if err != nil {
+		log.Printf("[cluster] failed loading axis set for %s: %v", code, err)
+		http.Error(w, "Failed to submit prediction", http.StatusInternalServerError)
+		return
+	}
+	x, y = pair.shape().normalize(x, y)
+
+	updated, err := g.setSubmissionPrediction(ctx, tx, round.ID, participation.ID, x, y)
+	if err != nil {
+		log.Printf("[cluster] failed storing prediction for lobby %s: %v", code, err)
+		http.Error(w, "Failed to submit prediction", http.StatusInternalServerError)
+		return
+	}
+	if !updated {
+		http.Error(w, "Place your own point first", http.StatusConflict)
+		return
+	}
+
+	submissionCount, expectedPlayers, revealed, err := g.revealIfComplete(ctx, tx, lobby, round)
+	if err != nil {
+		log.Printf("[cluster] failed checking reveal for lobby %s: %v", code, err)
+		http.Error(w, "Failed to reveal round", http.StatusInternalServerError)
+		return
 	}
 
 	if err = tx.Commit(ctx); err != nil {
-		log.Printf("[cluster] failed committing submission transaction for lobby %s: %v", code, err)
-		http.Error(w, "Failed to submit coordinate", http.StatusInternalServerError)
+		log.Printf("[cluster] failed committing prediction transaction for lobby %s: %v", code, err)
+		http.Error(w, "Failed to submit prediction", http.StatusInternalServerError)
 		return
 	}
 
+	g.publishSubmissionProgress(ctx, code, submissionCount, expectedPlayers, revealed)
+	http.Redirect(w, r, "/lobbies/"+code, http.StatusSeeOther)
+}
+
+func (g *ClusterGame) publishSubmissionProgress(ctx context.Context, code string, submissionCount int, expectedPlayers int, revealed bool) {
 	eventType := events.EventClusterSubmissionUpdated
 	var payload any = events.ClusterSubmissionUpdatedPayload{
 		SubmittedCount: submissionCount,
@@ -211,19 +307,78 @@ func (g *ClusterGame) handleSubmitCoordinate(w http.ResponseWriter, r *http.Requ
 		payload = nil
 	}
 	g.eventBus.Publish(ctx, events.Event{Type: eventType, LobbyCode: code, Payload: payload})
+}
+
+// handleUpdateSettings saves the host's scoring choices. The consensus strategy
+// and guess the player apply from the next reveal and group predictions from
+// the next round; earlier rounds keep what they were played with.
+func (g *ClusterGame) handleUpdateSettings(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	player := auth.GetPlayer(r.Context())
 
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+	strategy, ok := ParseConsensusStrategy(strings.TrimSpace(r.FormValue("strategy")))
+	if !ok {
+		http.Error(w, "Unknown consensus strategy", http.StatusBadRequest)
+		return
+	}
+
+	lobby, err := g.queries.GetLobbyByCode(r.Context(), code)
+	if err != nil {
+		http.Error(w, "Lobby not found", http.StatusNotFound)
+		return
+	}
+
+	participation, err := g.queries.GetPlayerParticipation(r.Context(), db.GetPlayerParticipationParams{
+		LobbyID:  lobby.ID,
+		PlayerID: player.ID,
+	})
+	if err != nil || !participation.IsHost {
+		http.Error(w, "Only the host can change scoring", http.StatusForbidden)
+		return
+	}
+
+	settings, err := g.getLobbySettings(r.Context(), g.dbPool, lobby.ID)
+	if err != nil {
+		log.Printf("[cluster] failed to get settings for lobby %s: %v", code, err)
+		http.Error(w, "Failed to update scoring", http.StatusInternalServerError)
+		return
+	}
+	settings.ConsensusStrategy = strategy
+	settings.PredictGroup = r.FormValue("predict_group") != ""
+	settings.GuessPlayer = r.FormValue("guess_player") != ""
+	if err = g.saveLobbySettings(r.Context(), g.dbPool, lobby.ID, settings); err != nil {
+		log.Printf("[cluster] failed saving settings for lobby %s: %v", code, err)
+		http.Error(w, "Failed to update scoring", http.StatusInternalServerError)
+		return
+	}
+
+	g.eventBus.Publish(r.Context(), events.Event{Type: events.EventClusterSettingsUpdated, LobbyCode: code})
 	http.Redirect(w, r, "/lobbies/"+code, http.StatusSeeOther)
 }
 
+// promptSelector chooses the prompt-axis pair for the next round.
+type promptSelector func(ctx context.Context, q db.DBTX, lobby db.Lobby, settings lobbySettings, host db.LobbyPlayer) (promptAxisSetRecord, error)
+
+func (g *ClusterGame) nextPromptAxisSet(ctx context.Context, q db.DBTX, lobby db.Lobby, settings lobbySettings, _ db.LobbyPlayer) (promptAxisSetRecord, error) {
+	return g.getNextPromptAxisSet(ctx, q, lobby.ID, lobby.ContentRating, settings.Themes)
+}
+
 func (g *ClusterGame) handleSkipPrompt(w http.ResponseWriter, r *http.Request) {
-	g.advanceRound(w, r, false)
+	g.advanceRound(w, r, false, g.nextPromptAxisSet)
 }
 
 func (g *ClusterGame) handleNextRound(w http.ResponseWriter, r *http.Request) {
-	g.advanceRound(w, r, true)
+	g.advanceRound(w, r, true, g.nextPromptAxisSet)
 }
 
-func (g *ClusterGame) advanceRound(w http.ResponseWriter, r *http.Request, requireRevealed bool) {
+// advanceRound starts the next round with the pair choose returns. Running
+// out of pairs, or continuing past the lobby's round limit, finishes the
+// session.
+func (g *ClusterGame) advanceRound(w http.ResponseWriter, r *http.Request, requireRevealed bool, choose promptSelector) {
 	code := chi.URLParam(r, "code")
 	player := auth.GetPlayer(r.Context())
 	ctx := r.Context()
@@ -259,7 +414,36 @@ func (g *ClusterGame) advanceRound(w http.ResponseWriter, r *http.Request, requi
 		return
 	}
 
-	next, nextErr := g.getNextPromptAxisSet(ctx, g.dbPool, lobby.ID, lobby.ContentRating)
+	settings, err := g.getLobbySettings(ctx, g.dbPool, lobby.ID)
+	if err != nil {
+		log.Printf("[cluster] failed to get settings for lobby %s: %v", code, err)
+		http.Error(w, "Failed to continue Cluster", http.StatusInternalServerError)
+		return
+	}
+
+	tx, err := g.dbPool.Begin(ctx)
+	if err != nil {
+		http.Error(w, "Failed to continue Cluster", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	revealedRounds, err := g.countRevealedRounds(ctx, tx, lobby.ID)
+	if err != nil {
+		log.Printf("[cluster] failed to count revealed rounds for lobby %s: %v", code, err)
+		http.Error(w, "Failed to continue Cluster", http.StatusInternalServerError)
+		return
+	}
+
+	var (
+		next    promptAxisSetRecord
+		nextErr error
+	)
+	if remainingRounds(settings.RoundLimit, revealedRounds, 1) == 0 {
+		nextErr = pgx.ErrNoRows
+	} else {
+		next, nextErr = choose(ctx, tx, lobby, settings, participation)
+	}
 	if nextErr != nil {
 		if errors.Is(nextErr, pgx.ErrNoRows) {
 			if updateErr := g.queries.UpdateLobbyPhase(ctx, db.UpdateLobbyPhaseParams{ID: lobby.ID, Phase: "finished"}); updateErr != nil {
@@ -272,19 +456,300 @@ func (g *ClusterGame) advanceRound(w http.ResponseWriter, r *http.Request, requi
 			http.Redirect(w, r, "/lobbies/"+code, http.StatusSeeOther)
 			return
 		}
+		if errors.Is(nextErr, errPromptUnavailable) {
+			http.Error(w, "That prompt is no longer available", http.StatusConflict)
+			return
+		}
 
 		log.Printf("[cluster] failed selecting next prompt-axis for lobby %s: %v", code, nextErr)
 		http.Error(w, "Failed to continue Cluster", http.StatusInternalServerError)
 		return
 	}
 
-	_, createErr := g.createRound(ctx, g.dbPool, lobby.ID, next.ID, latestRound.RoundNumber+1)
+	_, createErr := g.createRound(ctx, tx, lobby.ID, next.ID, latestRound.RoundNumber+1)
 	if createErr != nil {
 		log.Printf("[cluster] failed creating round %d for lobby %s: %v", latestRound.RoundNumber+1, code, createErr)
 		http.Error(w, "Failed to continue Cluster", http.StatusInternalServerError)
 		return
 	}
+	if err = tx.Commit(ctx); err != nil {
+		log.Printf("[cluster] failed committing round %d for lobby %s: %v", latestRound.RoundNumber+1, code, err)
+		http.Error(w, "Failed to continue Cluster", http.StatusInternalServerError)
+		return
+	}
 
 	g.eventBus.Publish(ctx, events.Event{Type: events.EventClusterRoundStarted, LobbyCode: code})
 	http.Redirect(w, r, "/lobbies/"+code, http.StatusSeeOther)
 }
+
+// handleSubmitGuess records who a player thinks placed the mystery dot. The
+// answer is shown once every other player in the round has guessed.
+func (g *ClusterGame) handleSubmitGuess(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	player := auth.GetPlayer(r.Context())
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+	guessedPlayerID := strings.TrimSpace(r.FormValue("guessed_player_id"))
+	if guessedPlayerID == "" {
+		http.Error(w, "Pick a player to guess", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	lobby, err := g.queries.GetLobbyByCode(ctx, code)
+	if err != nil {
+		http.Error(w, "Lobby not found", http.StatusNotFound)
+		return
+	}
+	if !strings.EqualFold(lobby.Phase, "playing") {
+		http.Error(w, "Cluster is not currently accepting guesses", http.StatusConflict)
+		return
+	}
+
+	tx, err := g.dbPool.Begin(ctx)
+	if err != nil {
+		log.Printf("[cluster] failed to begin guess transaction: %v", err)
+		http.Error(w, "Failed to submit guess", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	participation, err := g.queries.WithTx(tx).GetPlayerParticipation(ctx, db.GetPlayerParticipationParams{
+		LobbyID:  lobby.ID,
+		PlayerID: player.ID,
+	})
+	if err != nil {
+		http.Error(w, "Not in lobby", http.StatusForbidden)
+		return
+	}
+
+	round, err := g.getLatestRound(ctx, tx, lobby.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "No active Cluster round", http.StatusBadRequest)
+			return
+		}
+		log.Printf("[cluster] failed loading active round for %s: %v", code, err)
+		http.Error(w, "Failed to submit guess", http.StatusInternalServerError)
+		return
+	}
+	if !round.MysteryPlayerID.Valid || round.MysteryRevealed {
+		http.Error(w, "This round is not taking guesses", http.StatusConflict)
+		return
+	}
+	if round.MysteryPlayerID == participation.ID {
+		http.Error(w, "You are the mystery player this round", http.StatusConflict)
+		return
+	}
+
+	submissions, err := g.getRoundSubmissions(ctx, tx, round.ID)
+	if err != nil {
+		log.Printf("[cluster] failed loading submissions for %s: %v", code, err)
+		http.Error(w, "Failed to submit guess", http.StatusInternalServerError)
+		return
+	}
+
+	var (
+		placed  bool
+		guessed *submissionRecord
+	)
+	for i := range submissions {
+		if submissions[i].LobbyPlayerID == participation.ID {
+			placed = true
+			continue
+		}
+		if submissions[i].LobbyPlayerID.String() == guessedPlayerID {
+			guessed = &submissions[i]
+		}
+	}
+	if !placed {
+		http.Error(w, "Only players who placed this round can guess", http.StatusConflict)
+		return
+	}
+	if guessed == nil {
+		http.Error(w, "That player did not place this round", http.StatusBadRequest)
+		return
+	}
+
+	if err = g.upsertGuess(ctx, tx, round.ID, participation.ID, guessed.LobbyPlayerID); err != nil {
+		log.Printf("[cluster] failed storing guess for lobby %s: %v", code, err)
+		http.Error(w, "Failed to submit guess", http.StatusInternalServerError)
+		return
+	}
+
+	guesses, err := g.getRoundGuesses(ctx, tx, round.ID)
+	if err != nil {
+		log.Printf("[cluster] failed counting guesses for lobby %s: %v", code, err)
+		http.Error(w, "Failed to submit guess", http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	isActive := func(playerID pgtype.UUID) bool {
+		return g.hub.Presence(code, playerID.String()).IsActiveAt(now)
+	}
+	if guessingDone(submissions, guesses, round.MysteryPlayerID, isActive) {
+		if err = g.revealMystery(ctx, tx, round.ID); err != nil {
+			log.Printf("[cluster] failed revealing mystery player for lobby %s: %v", code, err)
+			http.Error(w, "Failed to submit guess", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		log.Printf("[cluster] failed committing guess transaction for lobby %s: %v", code, err)
+		http.Error(w, "Failed to submit guess", http.StatusInternalServerError)
+		return
+	}
+
+	g.eventBus.Publish(ctx, events.Event{Type: events.EventClusterGuessUpdated, LobbyCode: code})
+	http.Redirect(w, r, "/lobbies/"+code, http.StatusSeeOther)
+}
+
+// handleRevealGuess lets the host show the mystery player before everyone
+// has guessed.
+func (g *ClusterGame) handleRevealGuess(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	player := auth.GetPlayer(r.Context())
+	ctx := r.Context()
+
+	lobby, err := g.queries.GetLobbyByCode(ctx, code)
+	if err != nil {
+		http.Error(w, "Lobby not found", http.StatusNotFound)
+		return
+	}
+
+	participation, err := g.queries.GetPlayerParticipation(ctx, db.GetPlayerParticipationParams{
+		LobbyID:  lobby.ID,
+		PlayerID: player.ID,
+	})
+	if err != nil || !participation.IsHost {
+		http.Error(w, "Only the host can reveal the answer", http.StatusForbidden)
+		return
+	}
+
+	round, err := g.getLatestRound(ctx, g.dbPool, lobby.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "No active Cluster round", http.StatusBadRequest)
+			return
+		}
+		log.Printf("[cluster] failed loading active round for %s: %v", code, err)
+		http.Error(w, "Failed to reveal answer", http.StatusInternalServerError)
+		return
+	}
+	if !round.MysteryPlayerID.Valid {
+		http.Error(w, "This round has no mystery player", http.StatusConflict)
+		return
+	}
+
+	if err = g.revealMystery(ctx, g.dbPool, round.ID); err != nil {
+		log.Printf("[cluster] failed revealing mystery player for lobby %s: %v", code, err)
+		http.Error(w, "Failed to reveal answer", http.StatusInternalServerError)
+		return
+	}
+
+	g.eventBus.Publish(ctx, events.Event{Type: events.EventClusterGuessUpdated, LobbyCode: code})
+	http.Redirect(w, r, "/lobbies/"+code, http.StatusSeeOther)
+}
+
+// handlePromptPicker lets the host browse the lobby's remaining pairs and
+// write a custom prompt for the next round.
+func (g *ClusterGame) handlePromptPicker(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	player := auth.GetPlayer(r.Context())
+	ctx := r.Context()
+
+	lobby, err := g.queries.GetLobbyByCode(ctx, code)
+	if err != nil {
+		http.Error(w, "Lobby not found", http.StatusNotFound)
+		return
+	}
+
+	participation, err := g.queries.GetPlayerParticipation(ctx, db.GetPlayerParticipationParams{
+		LobbyID:  lobby.ID,
+		PlayerID: player.ID,
+	})
+	if err != nil || !participation.IsHost {
+		http.Error(w, "Only the host can choose prompts", http.StatusForbidden)
+		return
+	}
+	if !strings.EqualFold(lobby.Phase, "playing") {
+		http.Redirect(w, r, "/lobbies/"+code, http.StatusSeeOther)
+		return
+	}
+
+	settings, err := g.getLobbySettings(ctx, g.dbPool, lobby.ID)
+	if err != nil {
+		log.Printf("[cluster] failed to get settings for lobby %s: %v", code, err)
+		http.Error(w, "Failed to load prompts", http.StatusInternalServerError)
+		return
+	}
+	choices, err := g.listEligiblePromptAxisSets(ctx, g.dbPool, lobby.ID, lobby.ContentRating, settings.Themes, lobbyLocale(lobby.Locale))
+	if err != nil {
+		log.Printf("[cluster] failed listing prompt-axis pairs for lobby %s: %v", code, err)
+		http.Error(w, "Failed to load prompts", http.StatusInternalServerError)
+		return
+	}
+	axisSets, err := g.listAxisSetChoices(ctx, g.dbPool, lobby.ContentRating, lobbyLocale(lobby.Locale))
+	if err != nil {
+		log.Printf("[cluster] failed listing axis sets for lobby %s: %v", code, err)
+		http.Error(w, "Failed to load prompts", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	rating, ratingErr := contentrating.ParseID(query.Get("rating"))
+	if ratingErr != nil || strings.TrimSpace(query.Get("rating")) == "" {
+		rating = lobby.ContentRating
+	}
+	view := buildPromptPickerView(code, lobby.ContentRating, choices, axisSets, strings.TrimSpace(query.Get("theme")), rating)
+	clustertmpl.PromptPicker(view).Render(ctx, w)
+}
+
+// handlePickPrompt starts the next round with the pair the host picked,
+// replacing the current round like a skip when it is not revealed yet.
+func (g *ClusterGame) handlePickPrompt(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+	var promptAxisSetID pgtype.UUID
+	if err := promptAxisSetID.Scan(strings.TrimSpace(r.FormValue("prompt_axis_set_id"))); err != nil {
+		http.Error(w, "Invalid prompt", http.StatusBadRequest)
+		return
+	}
+
+	g.advanceRound(w, r, false, func(ctx context.Context, q db.DBTX, lobby db.Lobby, settings lobbySettings, _ db.LobbyPlayer) (promptAxisSetRecord, error) {
+		return g.getEligiblePromptAxisSet(ctx, q, lobby.ID, lobby.ContentRating, settings.Themes, promptAxisSetID)
+	})
+}
+
+// handleCustomPrompt starts the next round with a prompt the host typed in,
+// placed on an axis set they chose.
+func (g *ClusterGame) handleCustomPrompt(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+	promptText := strings.Join(strings.Fields(r.FormValue("prompt_text")), " ")
+	if promptText == "" {
+		http.Error(w, "Prompt text is required", http.StatusBadRequest)
+		return
+	}
+	if utf8.RuneCountInString(promptText) > maxCustomPromptLength {
+		http.Error(w, "Prompt text is too long", http.StatusBadRequest)
+		return
+	}
+	var axisSetID pgtype.UUID
+	if err := axisSetID.Scan(strings.TrimSpace(r.FormValue("axis_set_id"))); err != nil {
+		http.Error(w, "Choose an axis set", http.StatusBadRequest)
+		return
+	}
+
+	g.advanceRound(w, r, false, func(ctx context.Context, q db.DBTX, lobby db.Lobby, _ lobbySettings, host db.LobbyPlayer) (promptAxisSetRecord, error) {
+		return g.createCustomPromptAxisSet(ctx, q, lobby, host, promptText, axisSetID)
+	})
+}
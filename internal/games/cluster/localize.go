@@ -0,0 +1,63 @@
+package cluster
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+	"github.com/jgoodhcg/mindmeld/internal/contentlocale"
+)
+
+// lobbyLocale reads a stored or submitted locale, falling back to the default
+// for anything unsupported so an old form or row still plays.
+func lobbyLocale(raw string) string {
+	locale, err := contentlocale.Parse(raw)
+	if err != nil {
+		return contentlocale.Default()
+	}
+	return locale
+}
+
+// localize swaps in the lobby locale's prompt text and axis labels. The raw
+// values are that locale's entries from the translations columns, nil when
+// the row has none. Anything missing or malformed keeps the library text.
+func (r *promptAxisSetRecord) localize(promptRaw, axisRaw []byte) {
+	if len(promptRaw) > 0 {
+		var tr clustercontent.PromptTranslation
+		if json.Unmarshal(promptRaw, &tr) == nil {
+			r.PromptText = translated(tr.Text, r.PromptText)
+		}
+	}
+	applyAxisTranslation(axisRaw, &r.XMinLabel, &r.XMaxLabel, &r.YMinLabel, &r.YMaxLabel, &r.Buckets)
+}
+
+// applyAxisTranslation overwrites the labels raw translates. Bucket labels are
+// only replaced when the count matches, since answers are stored by bucket
+// position.
+func applyAxisTranslation(raw []byte, xMin, xMax, yMin, yMax *string, buckets *[]string) {
+	if len(raw) == 0 {
+		return
+	}
+	var tr clustercontent.AxisTranslation
+	if json.Unmarshal(raw, &tr) != nil {
+		return
+	}
+	*xMin = translated(tr.XMinLabel, *xMin)
+	*xMax = translated(tr.XMaxLabel, *xMax)
+	*yMin = translated(tr.YMinLabel, *yMin)
+	*yMax = translated(tr.YMaxLabel, *yMax)
+	if len(tr.Buckets) > 0 && len(tr.Buckets) == len(*buckets) {
+		labels := make([]string, len(tr.Buckets))
+		for i, label := range tr.Buckets {
+			labels[i] = translated(label, (*buckets)[i])
+		}
+		*buckets = labels
+	}
+}
+
+func translated(value, fallback string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,57 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocalizeFallsBackPerField(t *testing.T) {
+	record := promptAxisSetRecord{
+		PromptText: "Breakfast foods",
+		XMinLabel:  "Sweet",
+		XMaxLabel:  "Savory",
+		YMinLabel:  "Light",
+		YMaxLabel:  "Heavy",
+	}
+	record.localize([]byte(`{"text":"Comidas de desayuno"}`), []byte(`{"x_min_label":"Dulce","x_max_label":"Salado"}`))
+
+	want := promptAxisSetRecord{
+		PromptText: "Comidas de desayuno",
+		XMinLabel:  "Dulce",
+		XMaxLabel:  "Salado",
+		YMinLabel:  "Light",
+		YMaxLabel:  "Heavy",
+	}
+	if !reflect.DeepEqual(record, want) {
+		t.Fatalf("got %+v, want %+v", record, want)
+	}
+}
+
+func TestLocalizeBucketsOnlyWhenCountsMatch(t *testing.T) {
+	record := promptAxisSetRecord{Mode: "buckets", Buckets: []string{"Cat", "Dog"}}
+	record.localize(nil, []byte(`{"buckets":["Gato","Perro","Pez"]}`))
+	if !reflect.DeepEqual(record.Buckets, []string{"Cat", "Dog"}) {
+		t.Fatalf("expected mismatched buckets to be ignored, got %v", record.Buckets)
+	}
+
+	record.localize(nil, []byte(`{"buckets":["Gato","Perro"]}`))
+	if !reflect.DeepEqual(record.Buckets, []string{"Gato", "Perro"}) {
+		t.Fatalf("expected translated buckets, got %v", record.Buckets)
+	}
+}
+
+func TestLocalizeIgnoresMalformedTranslations(t *testing.T) {
+	record := promptAxisSetRecord{PromptText: "Breakfast foods", XMinLabel: "Sweet"}
+	record.localize([]byte(`"oops"`), []byte(`[1]`))
+	if record.PromptText != "Breakfast foods" || record.XMinLabel != "Sweet" {
+		t.Fatalf("expected library text to be kept, got %+v", record)
+	}
+}
+
+func TestLobbyLocale(t *testing.T) {
+	for raw, want := range map[string]string{"": "en", " ES ": "es", "fr": "en"} {
+		if got := lobbyLocale(raw); got != want {
+			t.Fatalf("lobbyLocale(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
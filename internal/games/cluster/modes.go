@@ -0,0 +1,161 @@
+package cluster
+
+import (
+	"math"
+
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+	clustertmpl "github.com/jgoodhcg/mindmeld/templates/cluster"
+)
+
+// axisShape is how a round's axis set takes placements. Every mode stores
+// x/y in the unit square: spectrum pins y to the middle and buckets store the
+// center of the chosen bucket on x.
+type axisShape struct {
+	Mode    string
+	Buckets []string
+}
+
+func (s axisShape) isBuckets() bool {
+	return s.Mode == clustercontent.ModeBuckets && len(s.Buckets) > 0
+}
+
+// normalize snaps a raw placement onto the shape.
+func (s axisShape) normalize(x, y float64) (float64, float64) {
+	x, y = clampUnit(x), clampUnit(y)
+	switch {
+	case s.isBuckets():
+		return BucketCenter(BucketIndex(x, len(s.Buckets)), len(s.Buckets)), 0.5
+	case s.Mode == clustercontent.ModeSpectrum:
+		return x, 0.5
+	default:
+		return x, y
+	}
+}
+
+// BucketIndex returns which of n equal-width buckets x falls in.
+func BucketIndex(x float64, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return min(int(math.Floor(clampUnit(x)*float64(n))), n-1)
+}
+
+// BucketCenter returns the x stored for bucket i of n.
+func BucketCenter(i int, n int) float64 {
+	return (float64(i) + 0.5) / float64(n)
+}
+
+// CalculateSpectrumPoints scores a placement on a single axis. A full-width
+// miss scores zero, matching the corner-to-corner miss on the plane.
+func CalculateSpectrumPoints(x, targetX float64) int {
+	return int(math.Round((1 - math.Abs(clampUnit(x)-clampUnit(targetX))) * 100))
+}
+
+// PluralityBuckets returns the buckets picked most often. Ties are all
+// included.
+func PluralityBuckets(xs []float64, n int) map[int]bool {
+	counts := make([]int, n)
+	best := 0
+	for _, x := range xs {
+		i := BucketIndex(x, n)
+		counts[i]++
+		best = max(best, counts[i])
+	}
+	plurality := make(map[int]bool)
+	if best == 0 {
+		return plurality
+	}
+	for i, count := range counts {
+		if count == best {
+			plurality[i] = true
+		}
+	}
+	return plurality
+}
+
+// consensusFor returns the group target for a round. Bucket rounds target
+// the first plurality bucket; the other modes use the consensus strategy.
+func (s axisShape) consensusFor(strategy string, points []Point) (float64, float64, bool) {
+	if !s.isBuckets() {
+		return CalculateConsensus(strategy, points)
+	}
+	if len(points) == 0 {
+		return 0, 0, false
+	}
+	xs := make([]float64, len(points))
+	for i, p := range points {
+		xs[i] = p.X
+	}
+	plurality := PluralityBuckets(xs, len(s.Buckets))
+	for i := range s.Buckets {
+		if plurality[i] {
+			return BucketCenter(i, len(s.Buckets)), 0.5, true
+		}
+	}
+	return 0, 0, false
+}
+
+// roundScorer scores placements against a revealed round.
+type roundScorer struct {
+	shape     axisShape
+	targetX   float64
+	targetY   float64
+	plurality map[int]bool
+}
+
+// newRoundScorer builds the scorer for a revealed round. Bucket rounds score
+// against every plurality bucket, so ties all earn full points.
+func newRoundScorer(shape axisShape, submissions []submissionRecord, targetX, targetY float64) roundScorer {
+	scorer := roundScorer{shape: shape, targetX: targetX, targetY: targetY}
+	if shape.isBuckets() {
+		xs := make([]float64, len(submissions))
+		for i, sub := range submissions {
+			xs[i] = sub.X
+		}
+		scorer.plurality = PluralityBuckets(xs, len(shape.Buckets))
+	}
+	return scorer
+}
+
+func (s roundScorer) points(x, y float64) int {
+	switch {
+	case s.shape.isBuckets():
+		if s.plurality[BucketIndex(x, len(s.shape.Buckets))] {
+			return 100
+		}
+		return 0
+	case s.shape.Mode == clustercontent.ModeSpectrum:
+		return CalculateSpectrumPoints(x, s.targetX)
+	default:
+		return CalculateRoundPoints(x, y, s.targetX, s.targetY)
+	}
+}
+
+func (s roundScorer) distanceFromTarget(x, y float64) float64 {
+	return CalculateDistance(x, y, s.targetX, s.targetY)
+}
+
+// buildBucketViews groups a revealed bucket round by bucket.
+func buildBucketViews(shape axisShape, submissions []submissionRecord, scorer roundScorer, currentPlayerID string) []clustertmpl.BucketView {
+	if !shape.isBuckets() {
+		return nil
+	}
+	n := len(shape.Buckets)
+	views := make([]clustertmpl.BucketView, n)
+	for i, label := range shape.Buckets {
+		views[i] = clustertmpl.BucketView{Label: label, IsPlurality: scorer.plurality[i]}
+	}
+	for _, sub := range submissions {
+		i := BucketIndex(sub.X, n)
+		views[i].Count++
+		views[i].Players = append(views[i].Players, sub.Nickname)
+		if sub.PlayerID.String() == currentPlayerID {
+			views[i].IsCurrentPlayer = true
+		}
+		if sub.hasPrediction() {
+			p := BucketIndex(sub.PredictedX.Float64, n)
+			views[p].Predictors = append(views[p].Predictors, sub.Nickname)
+		}
+	}
+	return views
+}
@@ -0,0 +1,124 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+)
+
+func TestAxisShapeNormalize(t *testing.T) {
+	spectrum := axisShape{Mode: clustercontent.ModeSpectrum}
+	if x, y := spectrum.normalize(0.3, 0.9); x != 0.3 || y != 0.5 {
+		t.Fatalf("spectrum normalize = (%v, %v), want (0.3, 0.5)", x, y)
+	}
+
+	buckets := axisShape{Mode: clustercontent.ModeBuckets, Buckets: []string{"a", "b", "c"}}
+	tests := []struct {
+		x    float64
+		want float64
+	}{
+		{x: 0, want: 1.0 / 6},
+		{x: 0.33, want: 1.0 / 6},
+		{x: 0.34, want: 0.5},
+		{x: 1, want: 5.0 / 6},
+	}
+	for _, tt := range tests {
+		if x, y := buckets.normalize(tt.x, 0.1); x != tt.want || y != 0.5 {
+			t.Fatalf("buckets normalize(%v) = (%v, %v), want (%v, 0.5)", tt.x, x, y, tt.want)
+		}
+	}
+
+	plane := axisShape{}
+	if x, y := plane.normalize(0.3, 0.9); x != 0.3 || y != 0.9 {
+		t.Fatalf("plane normalize = (%v, %v), want (0.3, 0.9)", x, y)
+	}
+}
+
+func TestCalculateSpectrumPointsUsesFullRange(t *testing.T) {
+	if got := CalculateSpectrumPoints(0, 1); got != 0 {
+		t.Fatalf("expected opposite ends to score 0, got %d", got)
+	}
+	if got := CalculateSpectrumPoints(0.4, 0.5); got != 90 {
+		t.Fatalf("expected 90, got %d", got)
+	}
+}
+
+func TestBucketScoringRewardsEveryTiedPluralityBucket(t *testing.T) {
+	shape := axisShape{Mode: clustercontent.ModeBuckets, Buckets: []string{"Plan", "Improvise", "Delegate"}}
+	planX := BucketCenter(0, 3)
+	improviseX := BucketCenter(1, 3)
+	delegateX := BucketCenter(2, 3)
+	submissions := []submissionRecord{
+		{PlayerID: uuidByte(1), Nickname: "A", X: planX, Y: 0.5},
+		{PlayerID: uuidByte(2), Nickname: "B", X: planX, Y: 0.5},
+		{PlayerID: uuidByte(3), Nickname: "C", X: delegateX, Y: 0.5},
+		{PlayerID: uuidByte(4), Nickname: "D", X: delegateX, Y: 0.5,
+			PredictedX: pgtype.Float8{Float64: improviseX, Valid: true},
+			PredictedY: pgtype.Float8{Float64: 0.5, Valid: true}},
+		{PlayerID: uuidByte(5), Nickname: "E", X: improviseX, Y: 0.5,
+			PredictedX: pgtype.Float8{Float64: delegateX, Valid: true},
+			PredictedY: pgtype.Float8{Float64: 0.5, Valid: true}},
+	}
+
+	points := make([]Point, len(submissions))
+	for i, sub := range submissions {
+		points[i] = Point{X: sub.X, Y: sub.Y}
+	}
+	targetX, targetY, ok := shape.consensusFor(ConsensusMean, points)
+	if !ok || targetX != planX || targetY != 0.5 {
+		t.Fatalf("expected first plurality bucket as target, got (%v, %v, %v)", targetX, targetY, ok)
+	}
+
+	scorer := newRoundScorer(shape, submissions, targetX, targetY)
+	_, roundPoints, _, _, winners, outliers := scoreRound(submissions, scorer, "")
+	for id, want := range map[string]int{
+		uuidByte(1).String(): 100,
+		uuidByte(3).String(): 100,
+		uuidByte(5).String(): 0,
+	} {
+		if roundPoints[id] != want {
+			t.Fatalf("player %s scored %d, want %d", id, roundPoints[id], want)
+		}
+	}
+	if len(winners) != 4 || len(outliers) != 1 || outliers[0] != "E" {
+		t.Fatalf("unexpected winners %v / outliers %v", winners, outliers)
+	}
+
+	predictions := scorePredictions(submissions, scorer)
+	if predictions[uuidByte(4).String()] != 0 || predictions[uuidByte(5).String()] != 100 {
+		t.Fatalf("unexpected prediction points: %v", predictions)
+	}
+
+	views := buildBucketViews(shape, submissions, scorer, uuidByte(5).String())
+	if len(views) != 3 {
+		t.Fatalf("expected 3 bucket views, got %d", len(views))
+	}
+	if !views[0].IsPlurality || views[1].IsPlurality || !views[2].IsPlurality {
+		t.Fatalf("unexpected plurality flags: %+v", views)
+	}
+	if views[1].Count != 1 || !views[1].IsCurrentPlayer || len(views[1].Predictors) != 1 || views[1].Predictors[0] != "D" {
+		t.Fatalf("unexpected improvise bucket: %+v", views[1])
+	}
+}
+
+func TestScoreLobbySubmissionsScoresEachRoundByItsMode(t *testing.T) {
+	planeRound := uuidByte(10)
+	bucketRound := uuidByte(11)
+	buckets := []string{"Yes", "No"}
+	items := []scoredSubmissionRecord{
+		{PlayerID: uuidByte(1), RoundID: planeRound, X: 0.5, Y: 0.5, CentroidX: 0.5, CentroidY: 0.5},
+		{PlayerID: uuidByte(1), RoundID: bucketRound, Mode: clustercontent.ModeBuckets, Buckets: buckets, X: 0.25, Y: 0.5, CentroidX: 0.25, CentroidY: 0.5},
+		{PlayerID: uuidByte(2), RoundID: bucketRound, Mode: clustercontent.ModeBuckets, Buckets: buckets, X: 0.25, Y: 0.5, CentroidX: 0.25, CentroidY: 0.5},
+		{PlayerID: uuidByte(3), RoundID: bucketRound, Mode: clustercontent.ModeBuckets, Buckets: buckets, X: 0.75, Y: 0.5, CentroidX: 0.25, CentroidY: 0.5},
+	}
+
+	scoreLobbySubmissions(items)
+
+	want := []int{100, 100, 100, 0}
+	for i, item := range items {
+		if item.RoundScore != want[i] {
+			t.Fatalf("item %d scored %d, want %d", i, item.RoundScore, want[i])
+		}
+	}
+}
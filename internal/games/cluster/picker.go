@@ -0,0 +1,292 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	clustertmpl "github.com/jgoodhcg/mindmeld/templates/cluster"
+)
+
+// maxCustomPromptLength keeps host-written prompts to roughly the size of the
+// library ones.
+const maxCustomPromptLength = 200
+
+// errPromptUnavailable is returned when a host picks a pair or axis set that
+// is no longer eligible for the lobby.
+var errPromptUnavailable = errors.New("prompt is no longer available")
+
+// promptChoice is an eligible prompt-axis pair the host can pick. Rating is
+// the stricter of the prompt and axis set ratings.
+type promptChoice struct {
+	promptAxisSetRecord
+	Theme  string
+	Rating int16
+}
+
+type axisSetChoice struct {
+	ID        pgtype.UUID
+	Mode      string
+	XMinLabel string
+	XMaxLabel string
+	YMinLabel string
+	YMaxLabel string
+	Buckets   []string
+}
+
+// filterPromptChoices keeps choices in theme (any theme when empty) rated at
+// or below maxRating.
+func filterPromptChoices(choices []promptChoice, theme string, maxRating int16) []promptChoice {
+	filtered := make([]promptChoice, 0, len(choices))
+	for _, choice := range choices {
+		if theme != "" && choice.Theme != theme {
+			continue
+		}
+		if choice.Rating > maxRating {
+			continue
+		}
+		filtered = append(filtered, choice)
+	}
+	return filtered
+}
+
+// promptThemes lists the distinct themes among choices, sorted.
+func promptThemes(choices []promptChoice) []string {
+	seen := make(map[string]bool)
+	themes := make([]string, 0)
+	for _, choice := range choices {
+		if choice.Theme == "" || seen[choice.Theme] {
+			continue
+		}
+		seen[choice.Theme] = true
+		themes = append(themes, choice.Theme)
+	}
+	sort.Strings(themes)
+	return themes
+}
+
+// buildPromptPickerView applies the host's filters. An unknown theme or a
+// rating above the lobby's falls back to showing everything eligible.
+func buildPromptPickerView(lobbyCode string, lobbyRating int16, choices []promptChoice, axisSets []axisSetChoice, theme string, rating int16) clustertmpl.PromptPickerView {
+	themes := promptThemes(choices)
+	found := false
+	for _, option := range themes {
+		found = found || option == theme
+	}
+	if !found {
+		theme = ""
+	}
+	if !contentrating.IsValid(rating) || rating > lobbyRating {
+		rating = lobbyRating
+	}
+
+	view := clustertmpl.PromptPickerView{
+		LobbyCode:       lobbyCode,
+		Theme:           theme,
+		Themes:          themes,
+		Rating:          rating,
+		TotalChoices:    len(choices),
+		MaxPromptLength: maxCustomPromptLength,
+	}
+	for _, option := range []int16{contentrating.Kids, contentrating.Work, contentrating.Adults} {
+		if option <= lobbyRating {
+			view.Ratings = append(view.Ratings, clustertmpl.RatingOption{Value: option, Label: contentrating.Label(option)})
+		}
+	}
+	for _, choice := range filterPromptChoices(choices, theme, rating) {
+		view.Choices = append(view.Choices, clustertmpl.PromptChoiceView{
+			ID:          choice.ID.String(),
+			PromptText:  choice.PromptText,
+			Theme:       choice.Theme,
+			RatingLabel: contentrating.Label(choice.Rating),
+			Axes: clustertmpl.PromptAxisView{
+				XMinLabel: choice.XMinLabel,
+				XMaxLabel: choice.XMaxLabel,
+				YMinLabel: choice.YMinLabel,
+				YMaxLabel: choice.YMaxLabel,
+				Mode:      choice.Mode,
+				Buckets:   choice.Buckets,
+			},
+		})
+	}
+	for _, axis := range axisSets {
+		view.AxisSets = append(view.AxisSets, clustertmpl.AxisSetOption{
+			ID: axis.ID.String(),
+			Axes: clustertmpl.PromptAxisView{
+				XMinLabel: axis.XMinLabel,
+				XMaxLabel: axis.XMaxLabel,
+				YMinLabel: axis.YMinLabel,
+				YMaxLabel: axis.YMaxLabel,
+				Mode:      axis.Mode,
+				Buckets:   axis.Buckets,
+			},
+		})
+	}
+	return view
+}
+
+// listEligiblePromptAxisSets returns every library pair the lobby has not
+// played yet, localized for the lobby. Host-written prompts are never part of
+// the rotation.
+func (g *ClusterGame) listEligiblePromptAxisSets(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID, lobbyContentRating int16, themes []string, locale string) ([]promptChoice, error) {
+	const query = `WITH` + eligiblePairsCTE + `
+		SELECT e.id, cp.prompt_text, cas.x_min_label, cas.x_max_label, cas.y_min_label, cas.y_max_label, cas.mode, cas.bucket_labels,
+			COALESCE(cp.provenance->>'theme', ''), GREATEST(cp.min_rating, cas.min_rating),
+			cp.translations -> $4::text, cas.translations -> $4::text
+		FROM eligible_pairs e
+		JOIN coordinates_prompts cp ON cp.id = e.prompt_id
+		JOIN coordinates_axis_sets cas ON cas.id = e.axis_set_id
+		ORDER BY cp.prompt_text, cas.x_min_label
+	`
+
+	rows, err := q.Query(ctx, query, lobbyID, lobbyContentRating, themes, locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]promptChoice, 0)
+	for rows.Next() {
+		var (
+			item                     promptChoice
+			promptLocale, axisLocale []byte
+		)
+		if scanErr := rows.Scan(
+			&item.ID,
+			&item.PromptText,
+			&item.XMinLabel,
+			&item.XMaxLabel,
+			&item.YMinLabel,
+			&item.YMaxLabel,
+			&item.Mode,
+			&item.Buckets,
+			&item.Theme,
+			&item.Rating,
+			&promptLocale,
+			&axisLocale,
+		); scanErr != nil {
+			return nil, scanErr
+		}
+		item.localize(promptLocale, axisLocale)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// getEligiblePromptAxisSet loads one pair the host picked, returning
+// errPromptUnavailable if the lobby cannot play it.
+func (g *ClusterGame) getEligiblePromptAxisSet(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID, lobbyContentRating int16, themes []string, promptAxisSetID pgtype.UUID) (promptAxisSetRecord, error) {
+	const query = `WITH` + eligiblePairsCTE + `
+		SELECT e.id, cp.prompt_text, cas.x_min_label, cas.x_max_label, cas.y_min_label, cas.y_max_label, cas.mode, cas.bucket_labels
+		FROM eligible_pairs e
+		JOIN coordinates_prompts cp ON cp.id = e.prompt_id
+		JOIN coordinates_axis_sets cas ON cas.id = e.axis_set_id
+		WHERE e.id = $4
+	`
+
+	var record promptAxisSetRecord
+	err := q.QueryRow(ctx, query, lobbyID, lobbyContentRating, themes, promptAxisSetID).Scan(
+		&record.ID,
+		&record.PromptText,
+		&record.XMinLabel,
+		&record.XMaxLabel,
+		&record.YMinLabel,
+		&record.YMaxLabel,
+		&record.Mode,
+		&record.Buckets,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return promptAxisSetRecord{}, errPromptUnavailable
+	}
+	return record, err
+}
+
+// listAxisSetChoices returns the active axis sets a custom prompt can use,
+// localized for the lobby.
+func (g *ClusterGame) listAxisSetChoices(ctx context.Context, q db.DBTX, lobbyContentRating int16, locale string) ([]axisSetChoice, error) {
+	const query = `
+		SELECT id, mode, x_min_label, x_max_label, y_min_label, y_max_label, bucket_labels, translations -> $2::text
+		FROM coordinates_axis_sets
+		WHERE is_active = TRUE
+		  AND min_rating <= $1
+		ORDER BY mode, x_min_label
+	`
+
+	rows, err := q.Query(ctx, query, lobbyContentRating, locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]axisSetChoice, 0)
+	for rows.Next() {
+		var (
+			item       axisSetChoice
+			axisLocale []byte
+		)
+		if scanErr := rows.Scan(&item.ID, &item.Mode, &item.XMinLabel, &item.XMaxLabel, &item.YMinLabel, &item.YMaxLabel, &item.Buckets, &axisLocale); scanErr != nil {
+			return nil, scanErr
+		}
+		applyAxisTranslation(axisLocale, &item.XMinLabel, &item.XMaxLabel, &item.YMinLabel, &item.YMaxLabel, &item.Buckets)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// createCustomPromptAxisSet stores a host-written prompt as a user-authored
+// row paired with the chosen axis set. The prompt takes the lobby's rating
+// since it was written for that audience, and has no created_by_label so
+// library imports never deactivate it.
+func (g *ClusterGame) createCustomPromptAxisSet(ctx context.Context, q db.DBTX, lobby db.Lobby, author db.LobbyPlayer, promptText string, axisSetID pgtype.UUID) (promptAxisSetRecord, error) {
+	const axisQuery = `
+		SELECT x_min_label, x_max_label, y_min_label, y_max_label, mode, bucket_labels
+		FROM coordinates_axis_sets
+		WHERE id = $1
+		  AND is_active = TRUE
+		  AND min_rating <= $2
+	`
+	const promptQuery = `
+		INSERT INTO coordinates_prompts (
+			id, prompt_text, created_by_kind, created_by_player_id, authoring_mode,
+			provenance, min_rating, is_active
+		) VALUES (
+			gen_random_uuid(), $1, 'user', $2, 'manual',
+			jsonb_build_object('source', 'cluster-host', 'lobby_code', $3::text), $4, TRUE
+		)
+		RETURNING id
+	`
+	const pairQuery = `
+		INSERT INTO coordinates_prompt_axis_sets (id, prompt_id, axis_set_id, is_active)
+		VALUES (gen_random_uuid(), $1, $2, TRUE)
+		RETURNING id
+	`
+
+	record := promptAxisSetRecord{PromptText: promptText}
+	err := q.QueryRow(ctx, axisQuery, axisSetID, lobby.ContentRating).Scan(
+		&record.XMinLabel,
+		&record.XMaxLabel,
+		&record.YMinLabel,
+		&record.YMaxLabel,
+		&record.Mode,
+		&record.Buckets,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return promptAxisSetRecord{}, errPromptUnavailable
+	}
+	if err != nil {
+		return promptAxisSetRecord{}, err
+	}
+
+	var promptID pgtype.UUID
+	if err = q.QueryRow(ctx, promptQuery, promptText, author.ID, lobby.Code, lobby.ContentRating).Scan(&promptID); err != nil {
+		return promptAxisSetRecord{}, err
+	}
+	if err = q.QueryRow(ctx, pairQuery, promptID, axisSetID).Scan(&record.ID); err != nil {
+		return promptAxisSetRecord{}, err
+	}
+	return record, nil
+}
@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+func pickerChoices() []promptChoice {
+	choice := func(id byte, text string, theme string, rating int16) promptChoice {
+		return promptChoice{
+			promptAxisSetRecord: promptAxisSetRecord{ID: uuidByte(id), PromptText: text},
+			Theme:               theme,
+			Rating:              rating,
+		}
+	}
+	return []promptChoice{
+		choice(1, "Standup", "work", contentrating.Kids),
+		choice(2, "Retro", "work", contentrating.Work),
+		choice(3, "Picnic", "everyday", contentrating.Kids),
+		choice(4, "First date", "dating", contentrating.Adults),
+		choice(5, "Untagged", "", contentrating.Work),
+	}
+}
+
+func TestFilterPromptChoices(t *testing.T) {
+	choices := pickerChoices()
+
+	if got := filterPromptChoices(choices, "", contentrating.Adults); len(got) != len(choices) {
+		t.Fatalf("expected no filter to keep all choices, got %d", len(got))
+	}
+	work := filterPromptChoices(choices, "work", contentrating.Kids)
+	if len(work) != 1 || work[0].PromptText != "Standup" {
+		t.Fatalf("unexpected work choices at Mild: %+v", work)
+	}
+	if got := promptThemes(choices); len(got) != 3 || got[0] != "dating" || got[2] != "work" {
+		t.Fatalf("unexpected themes: %v", got)
+	}
+}
+
+func TestBuildPromptPickerViewClampsFilters(t *testing.T) {
+	choices := pickerChoices()[:3]
+	view := buildPromptPickerView("ABCD", contentrating.Work, choices, nil, "dating", contentrating.Adults)
+
+	if view.Theme != "" || view.Rating != contentrating.Work {
+		t.Fatalf("expected filters to fall back to the lobby defaults, got theme %q rating %d", view.Theme, view.Rating)
+	}
+	if len(view.Ratings) != 2 || view.Ratings[1].Value != contentrating.Work {
+		t.Fatalf("expected rating options up to the lobby rating, got %+v", view.Ratings)
+	}
+	if len(view.Choices) != 3 || view.TotalChoices != 3 || view.MaxPromptLength != maxCustomPromptLength {
+		t.Fatalf("unexpected view: %+v", view)
+	}
+
+	filtered := buildPromptPickerView("ABCD", contentrating.Work, choices, nil, "everyday", contentrating.Kids)
+	if len(filtered.Choices) != 1 || filtered.Choices[0].ID != uuidByte(3).String() || filtered.Choices[0].RatingLabel != "Mild" {
+		t.Fatalf("unexpected filtered choices: %+v", filtered.Choices)
+	}
+}
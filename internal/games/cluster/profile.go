@@ -0,0 +1,247 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	"github.com/jgoodhcg/mindmeld/internal/sharecode"
+	clustertmpl "github.com/jgoodhcg/mindmeld/templates/cluster"
+)
+
+const (
+	// profileTeammateLimit caps each of the aligned and least aligned lists.
+	profileTeammateLimit = 3
+)
+
+// profilePlacement is one revealed submission in a round the profile player
+// took part in.
+type profilePlacement struct {
+	RoundID  string
+	PlayerID string
+	Nickname string
+	X        float64
+	Y        float64
+
+	AxisID    string
+	AxisSlug  string
+	Mode      string
+	XMinLabel string
+	XMaxLabel string
+	YMinLabel string
+	YMaxLabel string
+	Buckets   []string
+}
+
+type axisTally struct {
+	view         clustertmpl.AxisTendencyView
+	sumX, sumY   float64
+	bucketCounts []int
+}
+
+type teammateTally struct {
+	nickname string
+	distance float64
+	rounds   int
+}
+
+// buildProfile aggregates where playerID tends to sit on each axis set and
+// how closely each teammate's answers track theirs. Placements must be in
+// play order; the latest nickname wins.
+func buildProfile(playerID string, placements []profilePlacement) clustertmpl.ProfileView {
+	own := make(map[string]profilePlacement)
+	for _, p := range placements {
+		if p.PlayerID == playerID {
+			own[p.RoundID] = p
+		}
+	}
+
+	profile := clustertmpl.ProfileView{}
+	axes := make(map[string]*axisTally)
+	var axisOrder []string
+	teammates := make(map[string]*teammateTally)
+
+	for _, p := range placements {
+		mine, ok := own[p.RoundID]
+		if !ok {
+			continue
+		}
+		if p.PlayerID != playerID {
+			tally := teammates[p.PlayerID]
+			if tally == nil {
+				tally = &teammateTally{}
+				teammates[p.PlayerID] = tally
+			}
+			tally.nickname = p.Nickname
+			tally.distance += CalculateDistance(mine.X, mine.Y, p.X, p.Y)
+			tally.rounds++
+			continue
+		}
+
+		profile.Nickname = p.Nickname
+		profile.Rounds++
+
+		tally := axes[p.AxisID]
+		if tally == nil {
+			tally = &axisTally{view: clustertmpl.AxisTendencyView{
+				Slug:      p.AxisSlug,
+				Mode:      p.Mode,
+				XMinLabel: p.XMinLabel,
+				XMaxLabel: p.XMaxLabel,
+				YMinLabel: p.YMinLabel,
+				YMaxLabel: p.YMaxLabel,
+				Buckets:   p.Buckets,
+			}}
+			if p.Mode == clustercontent.ModeBuckets && len(p.Buckets) > 0 {
+				tally.bucketCounts = make([]int, len(p.Buckets))
+			}
+			axes[p.AxisID] = tally
+			axisOrder = append(axisOrder, p.AxisID)
+		}
+		tally.view.Rounds++
+		tally.sumX += p.X
+		tally.sumY += p.Y
+		if tally.bucketCounts != nil {
+			i := BucketIndex(p.X, len(tally.bucketCounts))
+			tally.bucketCounts[i]++
+			if tally.bucketCounts[i] > tally.view.TopBucketCount {
+				tally.view.TopBucket = p.Buckets[i]
+				tally.view.TopBucketCount = tally.bucketCounts[i]
+			}
+		}
+	}
+
+	for _, id := range axisOrder {
+		tally := axes[id]
+		tally.view.AvgX = tally.sumX / float64(tally.view.Rounds)
+		tally.view.AvgY = tally.sumY / float64(tally.view.Rounds)
+		profile.Axes = append(profile.Axes, tally.view)
+	}
+	sort.SliceStable(profile.Axes, func(i, j int) bool {
+		return profile.Axes[i].Rounds > profile.Axes[j].Rounds
+	})
+
+	ranked := make([]clustertmpl.TeammateView, 0, len(teammates))
+	for _, tally := range teammates {
+		ranked = append(ranked, clustertmpl.TeammateView{
+			Nickname:     tally.nickname,
+			AvgDistance:  tally.distance / float64(tally.rounds),
+			SharedRounds: tally.rounds,
+		})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].AvgDistance == ranked[j].AvgDistance {
+			return strings.ToLower(ranked[i].Nickname) < strings.ToLower(ranked[j].Nickname)
+		}
+		return ranked[i].AvgDistance < ranked[j].AvgDistance
+	})
+
+	// Split the ranking so nobody shows up as both aligned and least aligned.
+	alignedCount := min(profileTeammateLimit, (len(ranked)+1)/2)
+	profile.MostAligned = ranked[:alignedCount]
+	rest := ranked[alignedCount:]
+	for i := len(rest) - 1; i >= 0 && len(profile.LeastAligned) < profileTeammateLimit; i-- {
+		profile.LeastAligned = append(profile.LeastAligned, rest[i])
+	}
+	return profile
+}
+
+// LoadPlayerProfile builds a player's Cluster profile from revealed rounds.
+// A valid lobbyID limits it to that lobby; otherwise every lobby counts.
+func LoadPlayerProfile(ctx context.Context, q db.DBTX, playerID pgtype.UUID, lobbyID pgtype.UUID) (clustertmpl.ProfileView, error) {
+	const query = `
+		SELECT cr.id, lp.player_id, lp.nickname, cs.x, cs.y,
+			cas.id, COALESCE(cas.provenance->>'slug', ''), cr.axis_mode,
+			cas.x_min_label, cas.x_max_label, cas.y_min_label, cas.y_max_label, cr.axis_bucket_labels
+		FROM coordinates_rounds cr
+		JOIN coordinates_submissions cs ON cs.round_id = cr.id
+		JOIN lobby_players lp ON lp.id = cs.player_id
+		JOIN coordinates_prompt_axis_sets cpas ON cpas.id = cr.prompt_axis_set_id
+		JOIN coordinates_axis_sets cas ON cas.id = cpas.axis_set_id
+		WHERE cr.centroid_x IS NOT NULL
+		  AND ($2::uuid IS NULL OR cr.lobby_id = $2)
+		  AND EXISTS (
+				SELECT 1
+				FROM coordinates_submissions own
+				JOIN lobby_players olp ON olp.id = own.player_id
+				WHERE own.round_id = cr.id
+				  AND olp.player_id = $1
+		  )
+		ORDER BY cr.created_at, lp.joined_at
+	`
+
+	rows, err := q.Query(ctx, query, playerID, lobbyID)
+	if err != nil {
+		return clustertmpl.ProfileView{}, err
+	}
+	defer rows.Close()
+
+	placements := make([]profilePlacement, 0)
+	for rows.Next() {
+		var (
+			roundID, rowPlayerID, axisID pgtype.UUID
+			p                            profilePlacement
+		)
+		if scanErr := rows.Scan(
+			&roundID, &rowPlayerID, &p.Nickname, &p.X, &p.Y,
+			&axisID, &p.AxisSlug, &p.Mode,
+			&p.XMinLabel, &p.XMaxLabel, &p.YMinLabel, &p.YMaxLabel, &p.Buckets,
+		); scanErr != nil {
+			return clustertmpl.ProfileView{}, scanErr
+		}
+		p.RoundID = roundID.String()
+		p.PlayerID = rowPlayerID.String()
+		p.AxisID = axisID.String()
+		placements = append(placements, p)
+	}
+	if rows.Err() != nil {
+		return clustertmpl.ProfileView{}, rows.Err()
+	}
+
+	return buildProfile(playerID.String(), placements), nil
+}
+
+// EnsureProfileShareCode returns the player's profile share code, creating
+// one on first use. A colliding code is retried, so q should not be a
+// transaction that a failed insert would abort.
+func EnsureProfileShareCode(ctx context.Context, q db.DBTX, playerID pgtype.UUID) (string, error) {
+	const query = `
+		INSERT INTO coordinates_profile_shares (player_id, share_code)
+		VALUES ($1, $2)
+		ON CONFLICT (player_id) DO UPDATE SET player_id = EXCLUDED.player_id
+		RETURNING share_code
+	`
+
+	var code string
+	err := sharecode.Insert(func(candidate string) error {
+		return q.QueryRow(ctx, query, playerID, candidate).Scan(&code)
+	})
+	return code, err
+}
+
+// GetProfileShareCode returns the player's share code, or "" if they have not
+// shared their profile.
+func GetProfileShareCode(ctx context.Context, q db.DBTX, playerID pgtype.UUID) (string, error) {
+	const query = `SELECT share_code FROM coordinates_profile_shares WHERE player_id = $1`
+
+	var code string
+	err := q.QueryRow(ctx, query, playerID).Scan(&code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return code, err
+}
+
+// GetProfileOwner resolves a profile share code to its player.
+func GetProfileOwner(ctx context.Context, q db.DBTX, shareCode string) (pgtype.UUID, error) {
+	const query = `SELECT player_id FROM coordinates_profile_shares WHERE share_code = $1`
+
+	var playerID pgtype.UUID
+	err := q.QueryRow(ctx, query, sharecode.Normalize(shareCode)).Scan(&playerID)
+	return playerID, err
+}
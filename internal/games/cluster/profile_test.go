@@ -0,0 +1,74 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
+)
+
+func profilePlanePlacement(round string, player string, nickname string, x float64, y float64) profilePlacement {
+	return profilePlacement{
+		RoundID: round, PlayerID: player, Nickname: nickname, X: x, Y: y,
+		AxisID: "plane", Mode: clustercontent.ModePlane,
+		XMinLabel: "Cautious", XMaxLabel: "Bold", YMinLabel: "Solo", YMaxLabel: "Team",
+	}
+}
+
+func TestBuildProfileAveragesEachAxis(t *testing.T) {
+	buckets := []string{"Plan", "Improvise", "Delegate"}
+	bucket := func(round string, x float64) profilePlacement {
+		return profilePlacement{
+			RoundID: round, PlayerID: "me", Nickname: "Ada B", X: x, Y: 0.5,
+			AxisID: "pick", Mode: clustercontent.ModeBuckets, Buckets: buckets,
+		}
+	}
+	placements := []profilePlacement{
+		profilePlanePlacement("r1", "me", "Ada", 0.8, 0.2),
+		profilePlanePlacement("r2", "me", "Ada B", 0.9, 0.4),
+		profilePlanePlacement("r3", "other", "Bo", 0.1, 0.1),
+		bucket("r4", BucketCenter(2, 3)),
+		bucket("r5", BucketCenter(0, 3)),
+		bucket("r6", BucketCenter(2, 3)),
+		bucket("r7", BucketCenter(2, 3)),
+	}
+
+	profile := buildProfile("me", placements)
+	if profile.Nickname != "Ada B" || profile.Rounds != 6 || len(profile.Axes) != 2 {
+		t.Fatalf("unexpected profile: %+v", profile)
+	}
+
+	pick := profile.Axes[0]
+	if pick.Rounds != 4 || pick.TopBucket != "Delegate" || pick.TopBucketCount != 3 {
+		t.Fatalf("expected the bucket axis first with Delegate on top, got %+v", pick)
+	}
+	plane := profile.Axes[1]
+	if plane.Rounds != 2 || plane.AvgX < 0.849 || plane.AvgX > 0.851 || plane.AvgY < 0.299 || plane.AvgY > 0.301 {
+		t.Fatalf("unexpected plane averages: %+v", plane)
+	}
+	if len(profile.MostAligned) != 0 || len(profile.LeastAligned) != 0 {
+		t.Fatalf("expected no teammates from rounds the player skipped, got %+v", profile)
+	}
+}
+
+func TestBuildProfileRanksTeammatesWithoutOverlap(t *testing.T) {
+	placements := []profilePlacement{
+		profilePlanePlacement("r1", "me", "Ada", 0.5, 0.5),
+		profilePlanePlacement("r1", "bo", "Bo", 0.5, 0.6),
+		profilePlanePlacement("r1", "cy", "Cy", 0.9, 0.9),
+		profilePlanePlacement("r1", "di", "Di", 0.5, 0.3),
+		profilePlanePlacement("r2", "me", "Ada", 0.2, 0.2),
+		profilePlanePlacement("r2", "bo", "Bo", 0.2, 0.3),
+		profilePlanePlacement("r2", "cy", "Cy", 0.8, 0.8),
+	}
+
+	profile := buildProfile("me", placements)
+	if len(profile.MostAligned) != 2 || profile.MostAligned[0].Nickname != "Bo" || profile.MostAligned[1].Nickname != "Di" {
+		t.Fatalf("unexpected most aligned: %+v", profile.MostAligned)
+	}
+	if profile.MostAligned[0].SharedRounds != 2 {
+		t.Fatalf("expected Bo to share both rounds, got %+v", profile.MostAligned[0])
+	}
+	if len(profile.LeastAligned) != 1 || profile.LeastAligned[0].Nickname != "Cy" {
+		t.Fatalf("unexpected least aligned: %+v", profile.LeastAligned)
+	}
+}
@@ -0,0 +1,192 @@
+package cluster
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+)
+
+const (
+	// repeatAfter is how long until a prompt a player saw in another lobby
+	// counts as fresh again. Until then its weight recovers linearly.
+	repeatAfter = 30 * 24 * time.Hour
+	// minRotationWeight keeps every eligible pair reachable, however stale or
+	// often skipped.
+	minRotationWeight = 0.05
+)
+
+// rotationCandidate is an eligible pair with the history used to weight it.
+type rotationCandidate struct {
+	promptAxisSetRecord
+	// Revealed and Skipped count rounds of this pair across all lobbies, as
+	// defined by the coordinates_round_outcomes view.
+	Revealed int
+	Skipped  int
+	// SeenAges holds, for each current player who saw the prompt in another
+	// lobby, how long ago they last saw it.
+	SeenAges  []time.Duration
+	GroupSize int
+}
+
+// qualityWeight favors pairs groups play through over ones they skip. The
+// Laplace smoothing puts a pair with no history at 0.5.
+func (c rotationCandidate) qualityWeight() float64 {
+	return float64(c.Revealed+1) / float64(c.Revealed+c.Skipped+2)
+}
+
+// freshnessWeight is the share of the group for whom the prompt is fresh,
+// where a prompt seen elsewhere recovers over repeatAfter.
+func (c rotationCandidate) freshnessWeight() float64 {
+	if c.GroupSize <= 0 {
+		return 1
+	}
+	stale := 0.0
+	for _, age := range c.SeenAges {
+		stale += 1 - min(1, float64(age)/float64(repeatAfter))
+	}
+	return max(0, 1-stale/float64(c.GroupSize))
+}
+
+func (c rotationCandidate) weight() float64 {
+	return max(minRotationWeight, c.qualityWeight()*c.freshnessWeight())
+}
+
+// rotationSeed makes selection reproducible for a lobby and round number.
+func rotationSeed(lobbyID pgtype.UUID, roundNumber int32) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write(lobbyID.Bytes[:])
+	var round [4]byte
+	binary.BigEndian.PutUint32(round[:], uint32(roundNumber))
+	h.Write(round[:])
+	return h.Sum64(), uint64(roundNumber)
+}
+
+// pickRotationCandidate draws a candidate with probability proportional to
+// its weight. Candidates must be in a stable order for the draw to repeat.
+func pickRotationCandidate(candidates []rotationCandidate, rng *rand.Rand) int {
+	total := 0.0
+	for _, candidate := range candidates {
+		total += candidate.weight()
+	}
+	target := rng.Float64() * total
+	for i, candidate := range candidates {
+		target -= candidate.weight()
+		if target < 0 {
+			return i
+		}
+	}
+	return len(candidates) - 1
+}
+
+// getNextPromptAxisSet picks the next unplayed pair for the lobby, weighted
+// toward prompts the current players have not seen elsewhere and pairs
+// groups rarely skip. It returns pgx.ErrNoRows once no pair is eligible.
+func (g *ClusterGame) getNextPromptAxisSet(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID, lobbyContentRating int16, themes []string) (promptAxisSetRecord, error) {
+	candidates, roundNumber, err := g.getRotationCandidates(ctx, q, lobbyID, lobbyContentRating, themes)
+	if err != nil {
+		return promptAxisSetRecord{}, err
+	}
+	if len(candidates) == 0 {
+		return promptAxisSetRecord{}, pgx.ErrNoRows
+	}
+
+	rng := rand.New(rand.NewPCG(rotationSeed(lobbyID, roundNumber)))
+	return candidates[pickRotationCandidate(candidates, rng)].promptAxisSetRecord, nil
+}
+
+// getRotationCandidates loads the eligible pairs in id order along with the
+// number of the round about to start.
+func (g *ClusterGame) getRotationCandidates(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID, lobbyContentRating int16, themes []string) ([]rotationCandidate, int32, error) {
+	const roundQuery = `
+		SELECT COALESCE(MAX(round_number), 0) + 1
+		FROM coordinates_rounds
+		WHERE lobby_id = $1
+	`
+	// History and seen are scoped to the eligible pairs so round start does
+	// not scan every round ever played.
+	const query = `
+		WITH group_players AS (
+			SELECT DISTINCT player_id
+			FROM lobby_players
+			WHERE lobby_id = $1
+		),
+		` + eligiblePairsCTE + `,
+		history AS (
+			SELECT o.prompt_axis_set_id,
+				COUNT(*) FILTER (WHERE o.revealed) AS revealed,
+				COUNT(*) FILTER (WHERE o.skipped) AS skipped
+			FROM coordinates_round_outcomes o
+			WHERE o.prompt_axis_set_id IN (SELECT id FROM eligible_pairs)
+			GROUP BY o.prompt_axis_set_id
+		),
+		seen AS (
+			SELECT cpas.prompt_id, lp.player_id, MAX(cr.created_at) AS last_seen
+			FROM lobby_players lp
+			JOIN coordinates_rounds cr ON cr.lobby_id = lp.lobby_id
+			JOIN coordinates_prompt_axis_sets cpas ON cpas.id = cr.prompt_axis_set_id
+			WHERE lp.player_id IN (SELECT player_id FROM group_players)
+			  AND lp.lobby_id <> $1
+			  AND cpas.prompt_id IN (SELECT prompt_id FROM eligible_pairs)
+			GROUP BY cpas.prompt_id, lp.player_id
+		)
+		SELECT e.id, cp.prompt_text, cas.x_min_label, cas.x_max_label, cas.y_min_label, cas.y_max_label, cas.mode, cas.bucket_labels,
+			COALESCE(h.revealed, 0), COALESCE(h.skipped, 0),
+			ARRAY(
+				SELECT EXTRACT(EPOCH FROM NOW() - s.last_seen)::float8
+				FROM seen s
+				WHERE s.prompt_id = e.prompt_id
+			),
+			(SELECT COUNT(*) FROM group_players)
+		FROM eligible_pairs e
+		JOIN coordinates_prompts cp ON cp.id = e.prompt_id
+		JOIN coordinates_axis_sets cas ON cas.id = e.axis_set_id
+		LEFT JOIN history h ON h.prompt_axis_set_id = e.id
+		ORDER BY e.id
+	`
+
+	var roundNumber int32
+	if err := q.QueryRow(ctx, roundQuery, lobbyID).Scan(&roundNumber); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := q.Query(ctx, query, lobbyID, lobbyContentRating, themes)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]rotationCandidate, 0)
+	for rows.Next() {
+		var (
+			item       rotationCandidate
+			seenAgeSec []float64
+		)
+		if scanErr := rows.Scan(
+			&item.ID,
+			&item.PromptText,
+			&item.XMinLabel,
+			&item.XMaxLabel,
+			&item.YMinLabel,
+			&item.YMaxLabel,
+			&item.Mode,
+			&item.Buckets,
+			&item.Revealed,
+			&item.Skipped,
+			&seenAgeSec,
+			&item.GroupSize,
+		); scanErr != nil {
+			return nil, 0, scanErr
+		}
+		for _, sec := range seenAgeSec {
+			item.SeenAges = append(item.SeenAges, time.Duration(sec*float64(time.Second)))
+		}
+		items = append(items, item)
+	}
+	return items, roundNumber, rows.Err()
+}
@@ -0,0 +1,70 @@
+package cluster
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestRotationWeightPrefersFreshUnskippedPairs(t *testing.T) {
+	fresh := rotationCandidate{GroupSize: 4}
+	if got := fresh.weight(); got < 0.499 || got > 0.501 {
+		t.Fatalf("expected an unplayed pair to weigh 0.5, got %v", got)
+	}
+
+	skipped := rotationCandidate{Revealed: 1, Skipped: 6, GroupSize: 4}
+	if skipped.weight() >= fresh.weight() {
+		t.Fatalf("expected an often skipped pair to weigh less, got %v", skipped.weight())
+	}
+
+	seenYesterday := rotationCandidate{GroupSize: 4, SeenAges: []time.Duration{24 * time.Hour, 24 * time.Hour}}
+	seenLastMonth := rotationCandidate{GroupSize: 4, SeenAges: []time.Duration{repeatAfter, 2 * repeatAfter}}
+	if seenYesterday.weight() >= fresh.weight() {
+		t.Fatalf("expected a recently seen prompt to weigh less, got %v", seenYesterday.weight())
+	}
+	if seenLastMonth.weight() != fresh.weight() {
+		t.Fatalf("expected a prompt seen over %v ago to be fresh again, got %v", repeatAfter, seenLastMonth.weight())
+	}
+
+	stale := rotationCandidate{Skipped: 20, GroupSize: 2, SeenAges: []time.Duration{0, 0}}
+	if stale.weight() != minRotationWeight {
+		t.Fatalf("expected the weight floor, got %v", stale.weight())
+	}
+}
+
+func TestPickRotationCandidateIsSeeded(t *testing.T) {
+	candidates := []rotationCandidate{{GroupSize: 3}, {GroupSize: 3}, {GroupSize: 3}, {GroupSize: 3}}
+	lobby := pgtype.UUID{Bytes: [16]byte{1, 2, 3}, Valid: true}
+
+	first := pickRotationCandidate(candidates, rand.New(rand.NewPCG(rotationSeed(lobby, 2))))
+	for range 5 {
+		if got := pickRotationCandidate(candidates, rand.New(rand.NewPCG(rotationSeed(lobby, 2)))); got != first {
+			t.Fatalf("expected the same pick for the same lobby and round, got %d and %d", first, got)
+		}
+	}
+
+	picks := make(map[int]bool)
+	for round := range int32(20) {
+		picks[pickRotationCandidate(candidates, rand.New(rand.NewPCG(rotationSeed(lobby, round+1))))] = true
+	}
+	if len(picks) < 2 {
+		t.Fatalf("expected rounds to vary the pick, got %v", picks)
+	}
+}
+
+func TestPickRotationCandidateFollowsWeights(t *testing.T) {
+	candidates := []rotationCandidate{
+		{GroupSize: 2, SeenAges: []time.Duration{0, 0}},
+		{GroupSize: 2},
+	}
+	rng := rand.New(rand.NewPCG(7, 11))
+	counts := make([]int, len(candidates))
+	for range 2000 {
+		counts[pickRotationCandidate(candidates, rng)]++
+	}
+	if counts[1] < 8*counts[0] {
+		t.Fatalf("expected the fresh pair to dominate, got %v", counts)
+	}
+}
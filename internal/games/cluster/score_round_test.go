@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	clustertmpl "github.com/jgoodhcg/mindmeld/templates/cluster"
 )
 
 func TestScoreRoundReturnsWinnersAndOutliers(t *testing.T) {
@@ -29,7 +30,7 @@ func TestScoreRoundReturnsWinnersAndOutliers(t *testing.T) {
 		},
 	}
 
-	dots, _, _, distances, winners, outliers := scoreRound(submissions, 0.5, 0.5, uuidByte(1).String())
+	dots, _, _, distances, winners, outliers := scoreRound(submissions, newRoundScorer(axisShape{}, submissions, 0.5, 0.5), uuidByte(1).String())
 	if len(dots) != 3 {
 		t.Fatalf("expected 3 dots, got %d", len(dots))
 	}
@@ -69,7 +70,7 @@ func TestScoreRoundTwoPlayersExposeViewerDistancesWithoutOutliers(t *testing.T)
 		},
 	}
 
-	_, roundPoints, _, distances, winners, outliers := scoreRound(submissions, 0.475, 0.425, hostID.String())
+	_, roundPoints, _, distances, winners, outliers := scoreRound(submissions, newRoundScorer(axisShape{}, submissions, 0.475, 0.425), hostID.String())
 
 	if roundPoints[hostID.String()] != roundPoints[guestID.String()] {
 		t.Fatalf("expected two-player centroid scoring tie, got %d vs %d", roundPoints[hostID.String()], roundPoints[guestID.String()])
@@ -96,3 +97,87 @@ func uuidByte(n byte) pgtype.UUID {
 		Valid: true,
 	}
 }
+
+func TestScoreRoundWithMedianTargetKeepsOutlierFromWinning(t *testing.T) {
+	submissions := []submissionRecord{
+		{PlayerID: uuidByte(1), Nickname: "A", X: 0.30, Y: 0.30},
+		{PlayerID: uuidByte(2), Nickname: "B", X: 0.32, Y: 0.30},
+		{PlayerID: uuidByte(3), Nickname: "C", X: 0.30, Y: 0.33},
+		{PlayerID: uuidByte(4), Nickname: "Wild", X: 1.00, Y: 1.00},
+	}
+	points := make([]Point, 0, len(submissions))
+	for _, sub := range submissions {
+		points = append(points, Point{X: sub.X, Y: sub.Y})
+	}
+
+	meanX, meanY, _ := CalculateConsensus(ConsensusMean, points)
+	_, meanPoints, _, _, _, _ := scoreRound(submissions, newRoundScorer(axisShape{}, submissions, meanX, meanY), "")
+	medianX, medianY, _ := CalculateConsensus(ConsensusGeometricMedian, points)
+	_, medianPoints, _, _, winners, outliers := scoreRound(submissions, newRoundScorer(axisShape{}, submissions, medianX, medianY), "")
+
+	for _, id := range []byte{1, 2, 3} {
+		key := uuidByte(id).String()
+		if medianPoints[key] <= meanPoints[key] {
+			t.Fatalf("expected clustered player %d to score higher against the median (%d vs %d)", id, medianPoints[key], meanPoints[key])
+		}
+	}
+	if len(outliers) != 1 || outliers[0] != "Wild" {
+		t.Fatalf("expected Wild as outlier, got %v", outliers)
+	}
+	for _, winner := range winners {
+		if winner == "Wild" {
+			t.Fatalf("expected Wild not to win, got %v", winners)
+		}
+	}
+}
+
+func TestScorePredictionsIsIndependentOfOwnPoint(t *testing.T) {
+	submissions := []submissionRecord{
+		{
+			PlayerID:   uuidByte(1),
+			Nickname:   "Contrarian",
+			X:          0.95,
+			Y:          0.95,
+			PredictedX: pgtype.Float8{Float64: 0.5, Valid: true},
+			PredictedY: pgtype.Float8{Float64: 0.5, Valid: true},
+		},
+		{
+			PlayerID: uuidByte(2),
+			Nickname: "Skipped",
+			X:        0.50,
+			Y:        0.50,
+		},
+	}
+
+	predictions := scorePredictions(submissions, newRoundScorer(axisShape{}, submissions, 0.5, 0.5))
+	if got := predictions[uuidByte(1).String()]; got != 100 {
+		t.Fatalf("expected perfect prediction to score 100, got %d", got)
+	}
+	if _, ok := predictions[uuidByte(2).String()]; ok {
+		t.Fatal("expected no prediction score for a player without a prediction")
+	}
+
+	dots, roundPoints, _, _, _, _ := scoreRound(submissions, newRoundScorer(axisShape{}, submissions, 0.5, 0.5), "")
+	if roundPoints[uuidByte(1).String()] >= 100 {
+		t.Fatalf("expected self score to stay separate from prediction, got %d", roundPoints[uuidByte(1).String()])
+	}
+	if !dots[0].HasPrediction || dots[0].PredictionPoints != 100 {
+		t.Fatalf("expected prediction on reveal dot, got %+v", dots[0])
+	}
+	if dots[1].HasPrediction {
+		t.Fatalf("expected no prediction marker for Skipped, got %+v", dots[1])
+	}
+}
+
+func TestPredictionLeadersRankByPredictionTotal(t *testing.T) {
+	standings := []clustertmpl.StandingView{
+		{Nickname: "Self", TotalPoints: 300, PredictionPoints: 50, PredictionRounds: 1},
+		{Nickname: "Reader", TotalPoints: 100, PredictionPoints: 180, PredictionRounds: 2},
+		{Nickname: "Never", TotalPoints: 200},
+	}
+
+	leaders := predictionLeaders(standings)
+	if len(leaders) != 2 || leaders[0].Nickname != "Reader" || leaders[1].Nickname != "Self" {
+		t.Fatalf("expected Reader then Self, got %+v", leaders)
+	}
+}
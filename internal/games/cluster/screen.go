@@ -0,0 +1,96 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/jackc/pgx/v5"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	clustertmpl "github.com/jgoodhcg/mindmeld/templates/cluster"
+)
+
+// RenderScreen builds the big-screen view: the prompt, live submission count,
+// and the reveal with every dot.
+func (g *ClusterGame) RenderScreen(ctx context.Context, lobby db.Lobby, players []db.GetLobbyPlayersRow) templ.Component {
+	view := clustertmpl.ScreenView{
+		LobbyCode:     lobby.Code,
+		Phase:         lobby.Phase,
+		PlayerCount:   len(players),
+		ExpectedCount: g.countActivePlayers(lobby.Code, players, time.Now()),
+	}
+
+	settings, err := g.getLobbySettings(ctx, g.dbPool, lobby.ID)
+	if err != nil {
+		log.Printf("[cluster] failed to get settings for lobby %s screen: %v", lobby.Code, err)
+	}
+	revealedStrategy := settings.ConsensusStrategy
+
+	roundPoints := map[string]int{}
+	roundPredictionPoints := map[string]int{}
+	var roundCenterDistances, roundDistances map[string]float64
+	round, err := g.getLatestRound(ctx, g.dbPool, lobby.ID)
+	if err == nil {
+		view.HasRound = true
+		view.RoundNumber = round.RoundNumber
+
+		pair, pairErr := g.getPromptAxisSetForRound(ctx, g.dbPool, round.ID)
+		if pairErr != nil {
+			log.Printf("[cluster] failed to get prompt-axis for round %d screen: %v", round.RoundNumber, pairErr)
+		}
+		view.Prompt = clustertmpl.PromptAxisView{
+			PromptText: pair.PromptText,
+			XMinLabel:  pair.XMinLabel,
+			XMaxLabel:  pair.XMaxLabel,
+			YMinLabel:  pair.YMinLabel,
+			YMaxLabel:  pair.YMaxLabel,
+			Mode:       pair.Mode,
+			Buckets:    pair.Buckets,
+		}
+
+		submissions, subErr := g.getRoundSubmissions(ctx, g.dbPool, round.ID)
+		if subErr != nil {
+			log.Printf("[cluster] failed to get submissions for round %d screen: %v", round.RoundNumber, subErr)
+		}
+		for _, sub := range submissions {
+			if !round.PredictGroup || sub.hasPrediction() {
+				view.SubmittedCount++
+			}
+		}
+
+		view.Revealed = round.CentroidX.Valid && round.CentroidY.Valid
+		if view.Revealed {
+			view.CentroidX = round.CentroidX.Float64
+			view.CentroidY = round.CentroidY.Float64
+			revealedStrategy = round.Consensus
+			scorer := newRoundScorer(pair.shape(), submissions, view.CentroidX, view.CentroidY)
+			view.Dots, roundPoints, roundCenterDistances, roundDistances, _, view.Outliers = scoreRound(submissions, scorer, "")
+			roundPredictionPoints = scorePredictions(submissions, scorer)
+			view.Buckets = buildBucketViews(pair.shape(), submissions, scorer, "")
+
+			guess := buildGuessView(settings.GuessPlayer, round, submissions, nil, "")
+			view.Anonymous = guess.Active && !guess.AnswerShown
+			view.MysteryX, view.MysteryY = guess.MysteryX, guess.MysteryY
+		}
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		log.Printf("[cluster] failed to get latest round for lobby %s screen: %v", lobby.Code, err)
+	}
+	view.Consensus = buildConsensusView(settings.ConsensusStrategy, revealedStrategy).Revealed
+
+	view.Standings, err = g.getStandings(ctx, lobby.ID, players, roundPoints, roundPredictionPoints, roundCenterDistances, roundDistances, "")
+	if err != nil {
+		log.Printf("[cluster] failed to build standings for lobby %s screen: %v", lobby.Code, err)
+	}
+
+	revealedRounds, err := g.countRevealedRounds(ctx, g.dbPool, lobby.ID)
+	if err != nil {
+		log.Printf("[cluster] failed to count revealed rounds for lobby %s screen: %v", lobby.Code, err)
+	}
+	view.Session = clustertmpl.SessionView{Themes: settings.Themes, RoundLimit: settings.RoundLimit, RevealedRounds: revealedRounds}
+	view.Exhausted = strings.EqualFold(lobby.Phase, "finished") || (strings.EqualFold(lobby.Phase, "playing") && !view.HasRound)
+
+	return clustertmpl.Screen(view)
+}
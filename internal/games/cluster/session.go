@@ -0,0 +1,101 @@
+package cluster
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+)
+
+// maxRoundLimit caps the session length a lobby can ask for.
+const maxRoundLimit = 50
+
+// remainingRounds returns how many more rounds the session can start given
+// its round limit, rounds already revealed, and unplayed pairs.
+func remainingRounds(roundLimit int, revealedRounds int, remainingPairs int) int {
+	if roundLimit <= 0 {
+		return remainingPairs
+	}
+	return max(0, min(remainingPairs, roundLimit-revealedRounds))
+}
+
+// parsePlaylist reads the themes and round count from the lobby creation
+// form. Themes outside known are dropped and the round count is clamped, so
+// a stale form still creates a playable lobby.
+func parsePlaylist(form url.Values, known []string) ([]string, int) {
+	themes := make([]string, 0)
+	for _, raw := range form["themes"] {
+		theme := strings.TrimSpace(raw)
+		if slices.Contains(known, theme) && !slices.Contains(themes, theme) {
+			themes = append(themes, theme)
+		}
+	}
+	slices.Sort(themes)
+
+	roundLimit, err := strconv.Atoi(strings.TrimSpace(form.Get("round_limit")))
+	if err != nil || roundLimit < 0 {
+		roundLimit = 0
+	}
+	return themes, min(roundLimit, maxRoundLimit)
+}
+
+// ConfigureLobby saves the theme playlist and round count chosen when the
+// lobby was created. The locale is stored on the lobby itself.
+func (g *ClusterGame) ConfigureLobby(ctx context.Context, q db.DBTX, lobby db.Lobby, form url.Values) error {
+	known, err := ListPromptThemes(ctx, q)
+	if err != nil {
+		return err
+	}
+
+	settings, err := g.getLobbySettings(ctx, q, lobby.ID)
+	if err != nil {
+		return err
+	}
+	settings.Themes, settings.RoundLimit = parsePlaylist(form, known)
+	return g.saveLobbySettings(ctx, q, lobby.ID, settings)
+}
+
+// ListPromptThemes returns the themes of the active library prompts, sorted.
+func ListPromptThemes(ctx context.Context, q db.DBTX) ([]string, error) {
+	const query = `
+		SELECT DISTINCT provenance->>'theme'
+		FROM coordinates_prompts
+		WHERE is_active = TRUE
+		  AND created_by_kind <> 'user'
+		  AND COALESCE(provenance->>'theme', '') <> ''
+		ORDER BY 1
+	`
+
+	rows, err := q.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	themes := make([]string, 0)
+	for rows.Next() {
+		var theme string
+		if scanErr := rows.Scan(&theme); scanErr != nil {
+			return nil, scanErr
+		}
+		themes = append(themes, theme)
+	}
+	return themes, rows.Err()
+}
+
+func (g *ClusterGame) countRevealedRounds(ctx context.Context, q db.DBTX, lobbyID pgtype.UUID) (int, error) {
+	const query = `
+		SELECT COUNT(*)
+		FROM coordinates_rounds
+		WHERE lobby_id = $1
+		  AND centroid_x IS NOT NULL
+	`
+
+	var count int
+	err := q.QueryRow(ctx, query, lobbyID).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,51 @@
+package cluster
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestRemainingRounds(t *testing.T) {
+	tests := []struct {
+		name           string
+		roundLimit     int
+		revealedRounds int
+		remainingPairs int
+		want           int
+	}{
+		{name: "no limit uses pairs", roundLimit: 0, revealedRounds: 40, remainingPairs: 7, want: 7},
+		{name: "limit below pairs", roundLimit: 10, revealedRounds: 3, remainingPairs: 50, want: 7},
+		{name: "pairs below limit", roundLimit: 10, revealedRounds: 3, remainingPairs: 2, want: 2},
+		{name: "limit reached", roundLimit: 5, revealedRounds: 5, remainingPairs: 50, want: 0},
+		{name: "past limit", roundLimit: 5, revealedRounds: 6, remainingPairs: 50, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := remainingRounds(tt.roundLimit, tt.revealedRounds, tt.remainingPairs); got != tt.want {
+				t.Fatalf("remainingRounds = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePlaylist(t *testing.T) {
+	known := []string{"dating", "everyday", "work"}
+	form := url.Values{
+		"themes":      {"work", " everyday ", "unknown", "work"},
+		"round_limit": {"10"},
+	}
+	themes, roundLimit := parsePlaylist(form, known)
+	if !reflect.DeepEqual(themes, []string{"everyday", "work"}) || roundLimit != 10 {
+		t.Fatalf("unexpected playlist: %v / %d", themes, roundLimit)
+	}
+
+	themes, roundLimit = parsePlaylist(url.Values{"round_limit": {"999"}}, known)
+	if len(themes) != 0 || roundLimit != maxRoundLimit {
+		t.Fatalf("expected all themes and a clamped limit, got %v / %d", themes, roundLimit)
+	}
+
+	if _, roundLimit = parsePlaylist(url.Values{"round_limit": {"-3"}}, known); roundLimit != 0 {
+		t.Fatalf("expected a negative limit to mean no limit, got %d", roundLimit)
+	}
+}
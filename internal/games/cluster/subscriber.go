@@ -5,7 +5,6 @@ import (
 	"context"
 	"log"
 	"strings"
-	"time"
 
 	"github.com/jgoodhcg/mindmeld/internal/db"
 	"github.com/jgoodhcg/mindmeld/internal/events"
@@ -18,7 +17,9 @@ func (g *ClusterGame) HandleEvent(ctx context.Context, event events.Event, hub *
 	switch event.Type {
 	case events.EventClusterRoundStarted,
 		events.EventClusterRoundRevealed,
-		events.EventClusterExhausted:
+		events.EventClusterExhausted,
+		events.EventClusterSettingsUpdated,
+		events.EventClusterGuessUpdated:
 		ws.BroadcastUpdateTrigger(ctx, event.LobbyCode, hub)
 		return true
 	case events.EventClusterSubmissionUpdated:
@@ -49,6 +50,13 @@ func (g *ClusterGame) broadcastSubmissionStatus(ctx context.Context, lobbyCode s
 		return
 	}
 	hub.Broadcast(ctx, lobbyCode, buf.Bytes())
+
+	buf.Reset()
+	if err := clustertmpl.ScreenSubmissionStatus(payload.SubmittedCount, payload.TotalPlayers, true).Render(ctx, &buf); err != nil {
+		log.Printf("[cluster-subscriber] failed to render screen submission status for lobby %s: %v", lobbyCode, err)
+		return
+	}
+	hub.BroadcastSpectators(ctx, lobbyCode, buf.Bytes())
 }
 
 func (g *ClusterGame) handleGraceExpired(ctx context.Context, lobbyCode string) bool {
@@ -57,15 +65,6 @@ func (g *ClusterGame) handleGraceExpired(ctx context.Context, lobbyCode string)
 		return false
 	}
 
-	players, err := g.queries.GetLobbyPlayers(ctx, lobby.ID)
-	if err != nil {
-		return false
-	}
-	expectedPlayers := g.countActivePlayers(lobbyCode, players, time.Now())
-	if expectedPlayers == 0 {
-		return false
-	}
-
 	tx, err := g.dbPool.Begin(ctx)
 	if err != nil {
 		return false
@@ -77,24 +76,8 @@ func (g *ClusterGame) handleGraceExpired(ctx context.Context, lobbyCode string)
 		return false
 	}
 
-	submissionCount, err := g.countRoundSubmissions(ctx, tx, round.ID)
-	if err != nil || submissionCount < expectedPlayers {
-		return false
-	}
-
-	submissions, err := g.getRoundSubmissions(ctx, tx, round.ID)
-	if err != nil {
-		return false
-	}
-	points := make([]Point, 0, len(submissions))
-	for _, sub := range submissions {
-		points = append(points, Point{X: sub.X, Y: sub.Y})
-	}
-	centroidX, centroidY, ok := CalculateCentroid(points)
-	if !ok {
-		return false
-	}
-	if err = g.setRoundCentroid(ctx, tx, round.ID, centroidX, centroidY); err != nil {
+	_, _, revealed, err := g.revealIfComplete(ctx, tx, lobby, round)
+	if err != nil || !revealed {
 		return false
 	}
 	if err = tx.Commit(ctx); err != nil {
@@ -3,6 +3,7 @@ package games
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"sort"
 	"strings"
 	"sync"
@@ -30,6 +31,19 @@ type Game interface {
 	HandleEvent(ctx context.Context, event events.Event, hub *ws.Hub, queries *db.Queries) bool
 }
 
+// LobbyConfigurer is implemented by games that read their own options from
+// the lobby creation form. q is the transaction that created the lobby, so a
+// failed configuration leaves no half-made lobby behind.
+type LobbyConfigurer interface {
+	ConfigureLobby(ctx context.Context, q db.DBTX, lobby db.Lobby, form url.Values) error
+}
+
+// ScreenRenderer is implemented by games with a read-only big-screen view.
+// The screen has no player, so it shows shared state only.
+type ScreenRenderer interface {
+	RenderScreen(ctx context.Context, lobby db.Lobby, players []db.GetLobbyPlayersRow) templ.Component
+}
+
 // Registry holds all registered games.
 type Registry struct {
 	mu    sync.RWMutex
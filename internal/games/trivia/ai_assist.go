@@ -89,7 +89,7 @@ type aiProviderConfig struct {
 	SourceTag string
 }
 
-func generateAssistedQuestion(ctx context.Context, lobbyRating int16, topic string) (generatedQuestion, error) {
+func generateAssistedQuestion(ctx context.Context, lobbyRating int16, topic string, catalog questions.Catalog) (generatedQuestion, error) {
 	if cfg := loadAIProviderConfig(); cfg != nil {
 		q, err := generateQuestionWithProvider(ctx, *cfg, lobbyRating, topic)
 		if err == nil {
@@ -98,7 +98,7 @@ func generateAssistedQuestion(ctx context.Context, lobbyRating int16, topic stri
 		log.Printf("[trivia-ai] provider %s failed, falling back to local generator: %v", cfg.Name, err)
 	}
 
-	q := generateLocalQuestion(lobbyRating, topic)
+	q := generateLocalQuestion(lobbyRating, topic, catalog)
 	if err := validateGeneratedQuestion(q); err != nil {
 		return generatedQuestion{}, err
 	}
@@ -346,16 +346,16 @@ func buildQuestionAssistPrompts(lobbyRating int16, topic string) (string, string
 	return systemPrompt, fmt.Sprintf("Now generate a question from this input:\n%s", cleanedTopic)
 }
 
-func generateLocalQuestion(lobbyRating int16, topic string) generatedQuestion {
+func generateLocalQuestion(lobbyRating int16, topic string, catalog questions.Catalog) generatedQuestion {
 	topic = cleanTopic(topic)
 	if fact, ok := parseStatedFact(topic); ok {
 		q := generateQuestionFromStatedFact(fact)
 		q.Source = "local-fallback"
 		return q
 	}
-	candidates := localTopicCandidates(topic, lobbyRating)
+	candidates := localTopicCandidates(topic, lobbyRating, catalog)
 	if len(candidates) == 0 {
-		candidates = localTopicCandidates("", lobbyRating)
+		candidates = localTopicCandidates("", lobbyRating, catalog)
 	}
 	if len(candidates) == 0 {
 		candidates = []generatedQuestion{
@@ -375,9 +375,9 @@ func generateLocalQuestion(lobbyRating int16, topic string) generatedQuestion {
 	return q
 }
 
-func localTopicCandidates(topic string, lobbyRating int16) []generatedQuestion {
+func localTopicCandidates(topic string, lobbyRating int16, catalog questions.Catalog) []generatedQuestion {
 	normalizedTopic := strings.ToLower(strings.TrimSpace(topic))
-	allowed := questions.GetAvailableTemplates(nil, lobbyRating)
+	allowed := catalog.GetAvailableTemplates(nil, lobbyRating)
 
 	result := make([]generatedQuestion, 0, len(allowed))
 	for _, t := range allowed {
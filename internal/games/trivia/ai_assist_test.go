@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+	"github.com/jgoodhcg/mindmeld/internal/questions"
 )
 
 func TestLoadAIProviderConfigDefaultsToOpenRouterWhenKeyPresent(t *testing.T) {
@@ -154,7 +155,7 @@ func TestBuildQuestionAssistPromptsForFirstPersonFact(t *testing.T) {
 }
 
 func TestGenerateLocalQuestionFromFirstPersonFact(t *testing.T) {
-	q := generateLocalQuestion(contentrating.Work, "my favorite fruit is blueberry")
+	q := generateLocalQuestion(contentrating.Work, "my favorite fruit is blueberry", questions.Catalog{})
 
 	if q.Source != "local-fallback" {
 		t.Fatalf("expected local fallback source, got %q", q.Source)
@@ -0,0 +1,163 @@
+package trivia
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	"github.com/jgoodhcg/mindmeld/internal/questions"
+)
+
+// errEmptyTemplateCatalog means no curated templates are installed for the
+// lobby's rating. The server seeds an empty catalog at startup, so this points
+// at a failed seed or a deactivated or half-imported library.
+var errEmptyTemplateCatalog = errors.New("trivia template catalog is empty; run trivia-content import")
+
+// loadTemplateCatalog reads the active template packs and templates allowed
+// for a lobby's content rating. It returns errEmptyTemplateCatalog along with
+// the empty catalog when there are none.
+func (g *TriviaGame) loadTemplateCatalog(ctx context.Context, lobbyRating int16) (questions.Catalog, error) {
+	packRows, err := g.queries.ListTriviaTemplatePacks(ctx, lobbyRating)
+	if err != nil {
+		return questions.Catalog{}, err
+	}
+	templateRows, err := g.queries.ListTriviaTemplates(ctx, lobbyRating)
+	if err != nil {
+		return questions.Catalog{}, err
+	}
+
+	catalog := questions.Catalog{
+		Packs:     make([]questions.Pack, 0, len(packRows)),
+		Templates: make([]questions.Template, 0, len(templateRows)),
+	}
+	for _, row := range packRows {
+		catalog.Packs = append(catalog.Packs, questions.Pack{
+			ID:          row.Slug,
+			Name:        row.Name,
+			Description: row.Description,
+			MinRating:   row.MinRating,
+		})
+	}
+	for _, row := range templateRows {
+		catalog.Templates = append(catalog.Templates, questions.Template{
+			ID:            row.Slug,
+			PackID:        row.PackSlug,
+			Category:      row.Category,
+			QuestionText:  row.QuestionText,
+			CorrectAnswer: row.CorrectAnswer,
+			WrongAnswer1:  row.WrongAnswer1,
+			WrongAnswer2:  row.WrongAnswer2,
+			WrongAnswer3:  row.WrongAnswer3,
+			MinRating:     row.MinRating,
+			Difficulty:    row.Difficulty,
+		})
+	}
+
+	if len(catalog.Templates) == 0 {
+		return catalog, errEmptyTemplateCatalog
+	}
+	return catalog, nil
+}
+
+// withLearnedDifficulty attaches answer history so templates report learned
+// difficulty. A failure only costs the learned labels, so it is logged.
+func (g *TriviaGame) withLearnedDifficulty(ctx context.Context, catalog questions.Catalog) questions.Catalog {
+	if len(catalog.Templates) == 0 {
+		return catalog
+	}
+	templateIDs := make([]string, len(catalog.Templates))
+	for i, tpl := range catalog.Templates {
+		templateIDs[i] = tpl.ID
+	}
+	rows, err := g.queries.ListTemplateAnswerStats(ctx, templateIDs)
+	if err != nil {
+		log.Printf("Error loading template answer stats: %v", err)
+		return catalog
+	}
+	stats := make(map[string]questions.AnswerStat, len(rows))
+	for _, row := range rows {
+		stats[row.TemplateID] = questions.AnswerStat{
+			Answers: int(row.AnswerCount),
+			Correct: int(row.CorrectCount),
+		}
+	}
+	return catalog.WithAnswerStats(stats)
+}
+
+// loadLobbyCatalog loads everything a player can pick from in a lobby: the
+// curated catalog for its rating plus the player's own and saved packs, with
+// learned difficulty. Handlers load it once per request and pass it down. It
+// returns whatever did load along with any error, so callers can still offer
+// player packs when the curated catalog is empty or unavailable. A failed
+// load wins over errEmptyTemplateCatalog.
+func (g *TriviaGame) loadLobbyCatalog(ctx context.Context, lobby db.Lobby, playerID pgtype.UUID) (questions.Catalog, error) {
+	catalog, err := g.loadTemplateCatalog(ctx, lobby.ContentRating)
+	playerPacks, packErr := g.loadPlayerPackCatalog(ctx, playerID, lobby.ContentRating)
+	if packErr != nil && (err == nil || errors.Is(err, errEmptyTemplateCatalog)) {
+		err = packErr
+	}
+	return g.withLearnedDifficulty(ctx, catalog.Prepend(playerPacks)), err
+}
+
+// unchangedTemplateID returns templateID when the submitted question text and
+// correct answer still match that template, and "" otherwise. An edited fill
+// is a different question, so its answers must not count toward the
+// template's learned difficulty.
+func (g *TriviaGame) unchangedTemplateID(ctx context.Context, lobbyRating int16, playerID pgtype.UUID, templateID, questionText, correctAnswer string) string {
+	if templateID == "" {
+		return ""
+	}
+	tpl, err := g.getTemplateFill(ctx, lobbyRating, playerID, templateID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Error loading question template %s: %v", templateID, err)
+		}
+		return ""
+	}
+	if !templateFillMatches(&tpl, questionText, correctAnswer) {
+		return ""
+	}
+	return templateID
+}
+
+// getTemplateFill loads the question and correct answer of one template the
+// player can pick in the lobby: a curated template by slug, or a question from
+// their own or saved packs. It returns pgx.ErrNoRows when there is none.
+func (g *TriviaGame) getTemplateFill(ctx context.Context, lobbyRating int16, playerID pgtype.UUID, templateID string) (questions.Template, error) {
+	if rest, ok := strings.CutPrefix(templateID, playerTemplatePrefix); ok {
+		var questionID pgtype.UUID
+		if err := questionID.Scan(rest); err != nil {
+			return questions.Template{}, pgx.ErrNoRows
+		}
+		row, err := g.queries.GetPlayerTriviaPackQuestionFill(ctx, db.GetPlayerTriviaPackQuestionFillParams{
+			ID:            questionID,
+			OwnerPlayerID: playerID,
+			MinRating:     lobbyRating,
+		})
+		if err != nil {
+			return questions.Template{}, err
+		}
+		return questions.Template{ID: templateID, QuestionText: row.QuestionText, CorrectAnswer: row.CorrectAnswer}, nil
+	}
+
+	row, err := g.queries.GetTriviaTemplateFill(ctx, db.GetTriviaTemplateFillParams{
+		Slug:      templateID,
+		MinRating: lobbyRating,
+	})
+	if err != nil {
+		return questions.Template{}, err
+	}
+	return questions.Template{ID: templateID, QuestionText: row.QuestionText, CorrectAnswer: row.CorrectAnswer}, nil
+}
+
+// templateFillMatches reports whether a submission is still tpl's question,
+// ignoring surrounding whitespace. Wrong answers may be swapped freely.
+func templateFillMatches(tpl *questions.Template, questionText, correctAnswer string) bool {
+	return tpl != nil &&
+		strings.TrimSpace(tpl.QuestionText) == strings.TrimSpace(questionText) &&
+		strings.TrimSpace(tpl.CorrectAnswer) == strings.TrimSpace(correctAnswer)
+}
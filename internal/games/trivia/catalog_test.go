@@ -0,0 +1,24 @@
+package trivia
+
+import (
+	"testing"
+
+	"github.com/jgoodhcg/mindmeld/internal/questions"
+)
+
+func TestTemplateFillMatches(t *testing.T) {
+	tpl := &questions.Template{ID: "capitals-france", QuestionText: "What is the capital of France?", CorrectAnswer: "Paris"}
+
+	if !templateFillMatches(tpl, " What is the capital of France? ", "Paris\n") {
+		t.Fatal("expected untouched fill to match")
+	}
+	if templateFillMatches(tpl, "What is the capital of Spain?", "Paris") {
+		t.Fatal("expected edited question text not to match")
+	}
+	if templateFillMatches(tpl, "What is the capital of France?", "Lyon") {
+		t.Fatal("expected edited correct answer not to match")
+	}
+	if templateFillMatches(nil, "What is the capital of France?", "Paris") {
+		t.Fatal("expected unknown template not to match")
+	}
+}
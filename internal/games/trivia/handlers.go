@@ -90,7 +90,7 @@ func (g *TriviaGame) handleGetQuestionTemplates(w http.ResponseWriter, r *http.R
 	}
 
 	// Verify player is in lobby
-	_, err = g.queries.GetPlayerParticipation(r.Context(), db.GetPlayerParticipationParams{
+	participation, err := g.queries.GetPlayerParticipation(r.Context(), db.GetPlayerParticipationParams{
 		LobbyID:  lobby.ID,
 		PlayerID: player.ID,
 	})
@@ -99,6 +99,12 @@ func (g *TriviaGame) handleGetQuestionTemplates(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	g.renderQuestionTemplates(w, r, lobby, player.ID, participation.IsHost, "")
+}
+
+// renderQuestionTemplates renders the templates modal from curated packs plus
+// the player's own and saved packs. Hosts also get the question bank upload form.
+func (g *TriviaGame) renderQuestionTemplates(w http.ResponseWriter, r *http.Request, lobby db.Lobby, playerID pgtype.UUID, isHost bool, notice string) {
 	// Get used templates for this lobby
 	usedTemplateIDs, err := g.queries.GetUsedTemplatesForLobby(r.Context(), lobby.ID)
 	if err != nil {
@@ -106,8 +112,21 @@ func (g *TriviaGame) handleGetQuestionTemplates(w http.ResponseWriter, r *http.R
 		usedTemplateIDs = []string{}
 	}
 
-	sections := questions.BuildPackSections(usedTemplateIDs, lobby.ContentRating)
-	triviatmpl.QuestionTemplatesModal(sections).Render(r.Context(), w)
+	catalog, err := g.loadLobbyCatalog(r.Context(), lobby, playerID)
+	if err != nil {
+		log.Printf("Error loading question templates: %v", err)
+		if notice == "" {
+			notice = "Curated question packs are unavailable right now."
+		}
+	}
+
+	// Difficulty filter and sort come from the modal's own controls.
+	difficulty := r.URL.Query().Get("difficulty")
+	sortOrder := r.URL.Query().Get("sort")
+
+	sections := catalog.FilterByDifficulty(difficulty).BuildPackSections(usedTemplateIDs, lobby.ContentRating)
+	questions.SortSectionsByDifficulty(sections, sortOrder)
+	triviatmpl.QuestionTemplatesModal(lobby.Code, sections, notice, isHost, difficulty, sortOrder).Render(r.Context(), w)
 }
 
 func (g *TriviaGame) handleSubmitQuestion(w http.ResponseWriter, r *http.Request) {
@@ -132,7 +151,19 @@ func (g *TriviaGame) handleSubmitQuestion(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	draft := questionDraftFromForm(r)
+	if rejectMatureQuestion(w, r, lobby, draft) {
+		return
+	}
+
 	ctx := r.Context()
+
+	// A template fill is recorded on the question so its answers feed learned
+	// difficulty, but only while it is still the template's question.
+	templateID := draft.TemplateID
+	statsTemplateID := g.unchangedTemplateID(ctx, lobby.ContentRating, player.ID, templateID,
+		draft.QuestionText, draft.CorrectAnswer)
+
 	tx, err := g.dbPool.Begin(ctx)
 	if err != nil {
 		log.Printf("Error starting transaction: %v", err)
@@ -144,15 +175,16 @@ func (g *TriviaGame) handleSubmitQuestion(w http.ResponseWriter, r *http.Request
 	qtx := g.queries.WithTx(tx)
 
 	// Create Question
-	_, err = qtx.CreateQuestion(ctx, db.CreateQuestionParams{
+	question, err := qtx.CreateQuestion(ctx, db.CreateQuestionParams{
 		RoundID:       round.ID,
 		Author:        player.ID,
-		QuestionText:  r.FormValue("question_text"),
-		CorrectAnswer: r.FormValue("correct_answer"),
-		WrongAnswer1:  r.FormValue("wrong_answer_1"),
-		WrongAnswer2:  r.FormValue("wrong_answer_2"),
-		WrongAnswer3:  r.FormValue("wrong_answer_3"),
+		QuestionText:  draft.QuestionText,
+		CorrectAnswer: draft.CorrectAnswer,
+		WrongAnswer1:  draft.WrongAnswer1,
+		WrongAnswer2:  draft.WrongAnswer2,
+		WrongAnswer3:  draft.WrongAnswer3,
 		MinRating:     lobby.ContentRating,
+		TemplateID:    pgtype.Text{String: statsTemplateID, Valid: statsTemplateID != ""},
 	})
 	if err != nil {
 		log.Printf("Error creating question: %v", err)
@@ -161,7 +193,6 @@ func (g *TriviaGame) handleSubmitQuestion(w http.ResponseWriter, r *http.Request
 	}
 
 	// Check if a template was used and mark it
-	templateID := r.FormValue("template_id")
 	if templateID != "" {
 		err = qtx.MarkTemplateUsed(ctx, db.MarkTemplateUsedParams{
 			LobbyID:    lobby.ID,
@@ -180,6 +211,11 @@ func (g *TriviaGame) handleSubmitQuestion(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// Saving to a personal pack is best-effort; the submission already counts.
+	if err := g.saveQuestionToPlayerPack(ctx, player.ID, draft.SavePackName, question); err != nil {
+		log.Printf("Error saving question to player pack: %v", err)
+	}
+
 	// Get updated counts for real-time update
 	players, err := g.queries.GetLobbyPlayers(r.Context(), lobby.ID)
 	if err != nil {
@@ -210,7 +246,38 @@ func (g *TriviaGame) handleSubmitQuestion(w http.ResponseWriter, r *http.Request
 		},
 	})
 
-	http.Redirect(w, r, "/lobbies/"+code, http.StatusSeeOther)
+	// The form is posted with HTMX, so a plain redirect would be swapped into the form.
+	w.Header().Set("HX-Redirect", "/lobbies/"+code)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// questionDraftFromForm reads the question form fields.
+func questionDraftFromForm(r *http.Request) triviatmpl.QuestionDraft {
+	return triviatmpl.QuestionDraft{
+		TemplateID:    r.FormValue("template_id"),
+		QuestionText:  r.FormValue("question_text"),
+		CorrectAnswer: r.FormValue("correct_answer"),
+		WrongAnswer1:  r.FormValue("wrong_answer_1"),
+		WrongAnswer2:  r.FormValue("wrong_answer_2"),
+		WrongAnswer3:  r.FormValue("wrong_answer_3"),
+		SavePackName:  r.FormValue("save_pack_name"),
+	}
+}
+
+// rejectMatureQuestion re-renders the question form with a notice when the
+// rating check is on and the draft reads above the lobby's audience setting.
+// It reports whether the question was rejected.
+func rejectMatureQuestion(w http.ResponseWriter, r *http.Request, lobby db.Lobby, draft triviatmpl.QuestionDraft) bool {
+	if !ratingCheckEnabled() {
+		return false
+	}
+	notice := submissionRatingNotice(lobby.ContentRating,
+		draft.QuestionText, draft.CorrectAnswer, draft.WrongAnswer1, draft.WrongAnswer2, draft.WrongAnswer3)
+	if notice == "" {
+		return false
+	}
+	triviatmpl.SubmitQuestionForm(lobby.Code, draft, notice).Render(r.Context(), w)
+	return true
 }
 
 func (g *TriviaGame) handleAdvanceRound(w http.ResponseWriter, r *http.Request) {
@@ -51,8 +51,13 @@ func (g *TriviaGame) handleGenerateQuestion(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	catalog, err := g.loadTemplateCatalog(r.Context(), lobby.ContentRating)
+	if err != nil {
+		log.Printf("Error loading question templates for lobby %s: %v", lobby.Code, err)
+	}
+
 	topic := strings.TrimSpace(r.FormValue("topic"))
-	generated, genErr := generateAssistedQuestion(r.Context(), lobby.ContentRating, topic)
+	generated, genErr := generateAssistedQuestion(r.Context(), lobby.ContentRating, topic, catalog)
 	if genErr != nil {
 		log.Printf("Error generating question for lobby %s: %v", lobby.Code, genErr)
 		writeGenerateQuestionResponse(w, http.StatusInternalServerError, generateQuestionResponse{
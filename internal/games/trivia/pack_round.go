@@ -0,0 +1,330 @@
+package trivia
+
+import (
+	"errors"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/auth"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	"github.com/jgoodhcg/mindmeld/internal/events"
+	"github.com/jgoodhcg/mindmeld/internal/questions"
+	triviatmpl "github.com/jgoodhcg/mindmeld/templates/trivia"
+)
+
+// Round sources stored in trivia_rounds.source.
+const (
+	roundSourcePlayers = "players"
+	roundSourcePacks   = "packs"
+)
+
+const (
+	defaultPackRoundQuestions = 10
+	maxPackRoundQuestions     = 30
+)
+
+// handleGetPackRoundForm renders the host's pack picker for a no-writing round.
+func (g *TriviaGame) handleGetPackRoundForm(w http.ResponseWriter, r *http.Request) {
+	lobby, player, ok := g.requirePackRoundHost(w, r)
+	if !ok {
+		return
+	}
+	g.renderPackRoundForm(w, r, lobby, player.ID, packRoundForm{
+		count: defaultPackRoundQuestions,
+		curve: questions.CurveMixed,
+	}, "")
+}
+
+// handleStartPackRound builds a round straight from unused templates in the
+// chosen packs. Questions have no author, so every player answers every one.
+// It starts the game from the waiting room or follows a finished round.
+func (g *TriviaGame) handleStartPackRound(w http.ResponseWriter, r *http.Request) {
+	lobby, player, ok := g.requirePackRoundHost(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+	form := packRoundForm{
+		packIDs: r.Form["pack_id"],
+		count:   parsePackRoundQuestionCount(r.FormValue("question_count")),
+		curve:   parsePackRoundCurve(r.FormValue("curve")),
+	}
+
+	if len(form.packIDs) == 0 {
+		g.renderPackRoundForm(w, r, lobby, player.ID, form, "Pick at least one pack.")
+		return
+	}
+
+	startingGame := lobby.Phase == "waiting"
+	roundNumber := int32(1)
+	if !startingGame {
+		lastRound, err := g.queries.GetActiveRound(r.Context(), lobby.ID)
+		if err == nil {
+			if lastRound.Phase != "finished" {
+				g.renderPackRoundForm(w, r, lobby, player.ID, form, "Finish the current round first.")
+				return
+			}
+			roundNumber = lastRound.RoundNumber + 1
+		}
+	}
+
+	usedTemplateIDs, err := g.queries.GetUsedTemplatesForLobby(r.Context(), lobby.ID)
+	if err != nil {
+		log.Printf("Error getting used templates: %v", err)
+		http.Error(w, "Failed to start round", http.StatusInternalServerError)
+		return
+	}
+	// Player packs can still fill a round when no curated templates are
+	// installed, so an empty catalog is only logged here.
+	catalog, err := g.loadLobbyCatalog(r.Context(), lobby, player.ID)
+	if errors.Is(err, errEmptyTemplateCatalog) {
+		log.Printf("Lobby %s: %v", lobby.Code, err)
+	} else if err != nil {
+		log.Printf("Error loading question templates: %v", err)
+		http.Error(w, "Failed to start round", http.StatusInternalServerError)
+		return
+	}
+
+	selected := selectPackRoundTemplates(catalog, form.packIDs, usedTemplateIDs, lobby.ContentRating, form.count, form.curve, rand.Shuffle)
+	if len(selected) == 0 {
+		writePackRoundForm(w, r, lobby, catalog, usedTemplateIDs, form, "Those packs have no unused questions left in this lobby.")
+		return
+	}
+
+	ctx := r.Context()
+	tx, err := g.dbPool.Begin(ctx)
+	if err != nil {
+		log.Printf("Error starting transaction: %v", err)
+		http.Error(w, "Failed to start round", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := g.queries.WithTx(tx)
+
+	if startingGame {
+		if err := qtx.UpdateLobbyPhase(ctx, db.UpdateLobbyPhaseParams{
+			ID:    lobby.ID,
+			Phase: "playing",
+		}); err != nil {
+			log.Printf("Error updating lobby phase: %v", err)
+			http.Error(w, "Failed to start round", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	round, err := qtx.CreateTriviaRound(ctx, db.CreateTriviaRoundParams{
+		LobbyID:     lobby.ID,
+		RoundNumber: roundNumber,
+	})
+	if err != nil {
+		log.Printf("Error creating pack round: %v", err)
+		http.Error(w, "Failed to start round", http.StatusInternalServerError)
+		return
+	}
+	if err := qtx.UpdateRoundSource(ctx, db.UpdateRoundSourceParams{
+		ID:     round.ID,
+		Source: roundSourcePacks,
+	}); err != nil {
+		log.Printf("Error setting round source: %v", err)
+		http.Error(w, "Failed to start round", http.StatusInternalServerError)
+		return
+	}
+
+	var firstQuestionID pgtype.UUID
+	for i, tpl := range selected {
+		question, err := qtx.CreatePackQuestion(ctx, db.CreatePackQuestionParams{
+			RoundID:       round.ID,
+			TemplateID:    pgtype.Text{String: tpl.ID, Valid: true},
+			QuestionText:  tpl.QuestionText,
+			CorrectAnswer: tpl.CorrectAnswer,
+			WrongAnswer1:  tpl.WrongAnswer1,
+			WrongAnswer2:  tpl.WrongAnswer2,
+			WrongAnswer3:  tpl.WrongAnswer3,
+			MinRating:     tpl.MinRating,
+			DisplayOrder:  pgtype.Int4{Int32: int32(i + 1), Valid: true},
+		})
+		if err != nil {
+			log.Printf("Error creating pack question: %v", err)
+			http.Error(w, "Failed to start round", http.StatusInternalServerError)
+			return
+		}
+		if i == 0 {
+			firstQuestionID = question.ID
+		}
+		if err := qtx.MarkTemplateUsed(ctx, db.MarkTemplateUsedParams{
+			LobbyID:    lobby.ID,
+			TemplateID: tpl.ID,
+		}); err != nil {
+			log.Printf("Error marking template as used: %v", err)
+			http.Error(w, "Failed to start round", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := qtx.UpdateRoundQuestionState(ctx, db.UpdateRoundQuestionStateParams{
+		ID:                round.ID,
+		CurrentQuestionID: firstQuestionID,
+		QuestionState:     "answering",
+	}); err != nil {
+		log.Printf("Error setting initial question state: %v", err)
+		http.Error(w, "Failed to start round", http.StatusInternalServerError)
+		return
+	}
+	if err := qtx.UpdateRoundPhase(ctx, db.UpdateRoundPhaseParams{
+		ID:    round.ID,
+		Phase: "playing",
+	}); err != nil {
+		log.Printf("Error advancing round phase: %v", err)
+		http.Error(w, "Failed to start round", http.StatusInternalServerError)
+		return
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Error committing transaction: %v", err)
+		http.Error(w, "Failed to start round", http.StatusInternalServerError)
+		return
+	}
+
+	if startingGame {
+		g.eventBus.Publish(ctx, events.Event{
+			Type:      events.EventGameStarted,
+			LobbyCode: lobby.Code,
+			Payload:   events.GameStartedPayload{RoundNumber: roundNumber},
+		})
+	} else {
+		g.eventBus.Publish(ctx, events.Event{
+			Type:      events.EventRoundAdvanced,
+			LobbyCode: lobby.Code,
+			Payload:   events.RoundAdvancedPayload{RoundNumber: roundNumber},
+		})
+	}
+
+	// The form is posted with HTMX, so a plain redirect would be swapped into the form.
+	w.Header().Set("HX-Redirect", "/lobbies/"+lobby.Code)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (g *TriviaGame) requirePackRoundHost(w http.ResponseWriter, r *http.Request) (db.Lobby, db.Player, bool) {
+	code := chi.URLParam(r, "code")
+	player := auth.GetPlayer(r.Context())
+
+	lobby, err := g.queries.GetLobbyByCode(r.Context(), code)
+	if err != nil {
+		http.Error(w, "Lobby not found", http.StatusNotFound)
+		return db.Lobby{}, db.Player{}, false
+	}
+
+	participation, err := g.queries.GetPlayerParticipation(r.Context(), db.GetPlayerParticipationParams{
+		LobbyID:  lobby.ID,
+		PlayerID: player.ID,
+	})
+	if err != nil || !participation.IsHost {
+		http.Error(w, "Only the host can start a pack round", http.StatusForbidden)
+		return db.Lobby{}, db.Player{}, false
+	}
+	return lobby, player, true
+}
+
+// packRoundForm is the host's pack round choice, echoed back when the form is re-rendered.
+type packRoundForm struct {
+	packIDs []string
+	count   int
+	curve   string
+}
+
+// renderPackRoundForm loads the host's catalog and renders the pack picker.
+func (g *TriviaGame) renderPackRoundForm(w http.ResponseWriter, r *http.Request, lobby db.Lobby, hostID pgtype.UUID, form packRoundForm, notice string) {
+	usedTemplateIDs, err := g.queries.GetUsedTemplatesForLobby(r.Context(), lobby.ID)
+	if err != nil {
+		log.Printf("Error getting used templates: %v", err)
+		usedTemplateIDs = []string{}
+	}
+
+	catalog, err := g.loadLobbyCatalog(r.Context(), lobby, hostID)
+	if err != nil {
+		log.Printf("Error loading question templates: %v", err)
+	}
+	writePackRoundForm(w, r, lobby, catalog, usedTemplateIDs, form, notice)
+}
+
+// writePackRoundForm renders the pack picker from an already loaded catalog.
+func writePackRoundForm(w http.ResponseWriter, r *http.Request, lobby db.Lobby, catalog questions.Catalog, usedTemplateIDs []string, form packRoundForm, notice string) {
+	selected := make(map[string]bool, len(form.packIDs))
+	for _, id := range form.packIDs {
+		selected[id] = true
+	}
+
+	sections := catalog.BuildPackSections(usedTemplateIDs, lobby.ContentRating)
+	triviatmpl.PackRoundForm(lobby.Code, sections, selected, form.count, maxPackRoundQuestions, form.curve, notice).Render(r.Context(), w)
+}
+
+// selectPackRoundTemplates picks up to count unused templates from the chosen
+// packs. Each pack is shuffled and the packs are then interleaved, so picking
+// several packs mixes them instead of draining the first one. The difficulty
+// curve then picks from that order.
+func selectPackRoundTemplates(catalog questions.Catalog, packIDs []string, usedIDs []string, lobbyRating int16, count int, curve string, shuffle func(n int, swap func(i, j int))) []questions.Template {
+	chosen := make(map[string]bool, len(packIDs))
+	for _, id := range packIDs {
+		chosen[id] = true
+	}
+
+	byPack := make(map[string][]questions.Template, len(packIDs))
+	var packOrder []string
+	for _, pack := range catalog.Packs {
+		if chosen[pack.ID] && pack.MinRating <= lobbyRating {
+			packOrder = append(packOrder, pack.ID)
+		}
+	}
+	for _, tpl := range catalog.GetAvailableTemplates(usedIDs, lobbyRating) {
+		if chosen[tpl.PackID] {
+			byPack[tpl.PackID] = append(byPack[tpl.PackID], tpl)
+		}
+	}
+	for _, id := range packOrder {
+		templates := byPack[id]
+		shuffle(len(templates), func(i, j int) {
+			templates[i], templates[j] = templates[j], templates[i]
+		})
+	}
+
+	var interleaved []questions.Template
+	for i := 0; ; i++ {
+		added := false
+		for _, id := range packOrder {
+			if i < len(byPack[id]) {
+				interleaved = append(interleaved, byPack[id][i])
+				added = true
+			}
+		}
+		if !added {
+			break
+		}
+	}
+	return questions.ApplyCurve(interleaved, curve, count)
+}
+
+func parsePackRoundQuestionCount(raw string) int {
+	count, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || count < 1 {
+		return defaultPackRoundQuestions
+	}
+	return min(count, maxPackRoundQuestions)
+}
+
+func parsePackRoundCurve(raw string) string {
+	curve := strings.TrimSpace(raw)
+	if !questions.IsCurve(curve) {
+		return questions.CurveMixed
+	}
+	return curve
+}
@@ -0,0 +1,102 @@
+package trivia
+
+import (
+	"testing"
+
+	"github.com/jgoodhcg/mindmeld/internal/questions"
+)
+
+func packRoundCatalog() questions.Catalog {
+	return questions.Catalog{
+		Packs: []questions.Pack{
+			{ID: "a", Name: "A", MinRating: 10},
+			{ID: "b", Name: "B", MinRating: 10},
+			{ID: "adults", Name: "Adults", MinRating: 30},
+		},
+		Templates: []questions.Template{
+			{ID: "a1", PackID: "a", MinRating: 10},
+			{ID: "a2", PackID: "a", MinRating: 10},
+			{ID: "a3", PackID: "a", MinRating: 10},
+			{ID: "a4", PackID: "a", MinRating: 20},
+			{ID: "b1", PackID: "b", MinRating: 10},
+			{ID: "x1", PackID: "adults", MinRating: 30},
+		},
+	}
+}
+
+func noShuffle(int, func(i, j int)) {}
+
+func TestSelectPackRoundTemplatesInterleavesPacks(t *testing.T) {
+	selected := selectPackRoundTemplates(packRoundCatalog(), []string{"a", "b"}, nil, 10, 3, questions.CurveMixed, noShuffle)
+
+	var ids []string
+	for _, tpl := range selected {
+		ids = append(ids, tpl.ID)
+	}
+	want := []string{"a1", "b1", "a2"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, ids)
+		}
+	}
+}
+
+func TestSelectPackRoundTemplatesSkipsUsedAndOverRated(t *testing.T) {
+	selected := selectPackRoundTemplates(packRoundCatalog(), []string{"a", "adults"}, []string{"a1", "a2"}, 10, 10, questions.CurveMixed, noShuffle)
+	if len(selected) != 1 || selected[0].ID != "a3" {
+		t.Fatalf("expected only a3 to remain, got %+v", selected)
+	}
+
+	if got := selectPackRoundTemplates(packRoundCatalog(), []string{"missing"}, nil, 30, 5, questions.CurveMixed, noShuffle); len(got) != 0 {
+		t.Fatalf("expected no templates for unknown pack, got %+v", got)
+	}
+}
+
+func TestSelectPackRoundTemplatesRampsDifficulty(t *testing.T) {
+	catalog := questions.Catalog{
+		Packs: []questions.Pack{{ID: "a", Name: "A", MinRating: 10}},
+		Templates: []questions.Template{
+			{ID: "hard", PackID: "a", MinRating: 10, Difficulty: questions.DifficultyHard},
+			{ID: "medium", PackID: "a", MinRating: 10, Difficulty: questions.DifficultyMedium},
+			{ID: "easy", PackID: "a", MinRating: 10, Difficulty: questions.DifficultyEasy},
+		},
+	}
+
+	selected := selectPackRoundTemplates(catalog, []string{"a"}, nil, 10, 3, questions.CurveRamp, noShuffle)
+	want := []string{"easy", "medium", "hard"}
+	if len(selected) != len(want) {
+		t.Fatalf("expected %d templates, got %+v", len(want), selected)
+	}
+	for i := range want {
+		if selected[i].ID != want[i] {
+			t.Fatalf("position %d: expected %s, got %s", i, want[i], selected[i].ID)
+		}
+	}
+}
+
+func TestParsePackRoundCurve(t *testing.T) {
+	if got := parsePackRoundCurve(" ramp "); got != questions.CurveRamp {
+		t.Fatalf("expected ramp, got %q", got)
+	}
+	if got := parsePackRoundCurve("brutal"); got != questions.CurveMixed {
+		t.Fatalf("expected unknown curve to fall back to mixed, got %q", got)
+	}
+}
+
+func TestParsePackRoundQuestionCount(t *testing.T) {
+	cases := map[string]int{
+		"":    defaultPackRoundQuestions,
+		"abc": defaultPackRoundQuestions,
+		"0":   defaultPackRoundQuestions,
+		"5":   5,
+		"999": maxPackRoundQuestions,
+	}
+	for raw, want := range cases {
+		if got := parsePackRoundQuestionCount(raw); got != want {
+			t.Fatalf("parsePackRoundQuestionCount(%q) = %d, want %d", raw, got, want)
+		}
+	}
+}
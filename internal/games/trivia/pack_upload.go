@@ -0,0 +1,195 @@
+package trivia
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jgoodhcg/mindmeld/internal/auth"
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	"github.com/jgoodhcg/mindmeld/internal/sharecode"
+	"github.com/jgoodhcg/mindmeld/internal/triviacontent"
+)
+
+const (
+	maxPackUploadBytes     = 1 << 20
+	maxPackUploadQuestions = 500
+	// maxUploadErrorLines keeps the modal notice readable for badly broken files.
+	maxUploadErrorLines = 3
+)
+
+// handleUploadPlayerPack imports a CSV or Open Trivia DB JSON file into one of
+// the host's player packs, so a team's existing question bank shows up in the
+// templates modal alongside curated packs.
+func (g *TriviaGame) handleUploadPlayerPack(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	player := auth.GetPlayer(r.Context())
+
+	lobby, err := g.queries.GetLobbyByCode(r.Context(), code)
+	if err != nil {
+		http.Error(w, "Lobby not found", http.StatusNotFound)
+		return
+	}
+
+	participation, err := g.queries.GetPlayerParticipation(r.Context(), db.GetPlayerParticipationParams{
+		LobbyID:  lobby.ID,
+		PlayerID: player.ID,
+	})
+	if err != nil || !participation.IsHost {
+		http.Error(w, "Only the host can upload question packs", http.StatusForbidden)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPackUploadBytes)
+	if err := r.ParseMultipartForm(maxPackUploadBytes); err != nil {
+		g.renderQuestionTemplates(w, r, lobby, player.ID, true, "Upload failed: files must be under 1 MB.")
+		return
+	}
+
+	packName := normalizePlayerPackName(r.FormValue("pack_name"))
+	if packName == "" {
+		g.renderQuestionTemplates(w, r, lobby, player.ID, true, "Upload failed: give the pack a name.")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		g.renderQuestionTemplates(w, r, lobby, player.ID, true, "Upload failed: choose a CSV or JSON file.")
+		return
+	}
+	defer file.Close()
+
+	format := strings.TrimSpace(r.FormValue("format"))
+	if format == "" {
+		format, err = triviacontent.FormatFromPath(header.Filename)
+		if err != nil {
+			g.renderQuestionTemplates(w, r, lobby, player.ID, true, "Upload failed: pick a format or use a .csv or .json file.")
+			return
+		}
+	}
+
+	templates, err := triviacontent.ParseTemplates(format, file, triviacontent.ImportOptions{
+		PackSlug:  "upload",
+		Category:  playerPackCategory,
+		MinRating: lobby.ContentRating,
+	})
+	if err == nil {
+		err = validateUploadedTemplates(templates, lobby.ContentRating, ratingCheckEnabled())
+	}
+	if err != nil {
+		g.renderQuestionTemplates(w, r, lobby, player.ID, true, uploadErrorNotice(err))
+		return
+	}
+
+	ctx := r.Context()
+	tx, err := g.dbPool.Begin(ctx)
+	if err != nil {
+		log.Printf("Error starting transaction: %v", err)
+		http.Error(w, "Failed to upload pack", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := g.queries.WithTx(tx)
+
+	packRating := int16(0)
+	for _, tpl := range templates {
+		packRating = max(packRating, tpl.MinRating)
+	}
+	// Each attempt runs in a savepoint so a share code collision can retry
+	// without aborting the upload transaction.
+	var pack db.PlayerTriviaPack
+	err = sharecode.Insert(func(code string) error {
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer sp.Rollback(ctx)
+		pack, err = g.queries.WithTx(sp).UpsertPlayerTriviaPack(ctx, db.UpsertPlayerTriviaPackParams{
+			OwnerPlayerID: player.ID,
+			Name:          packName,
+			ShareCode:     code,
+			MinRating:     packRating,
+		})
+		if err != nil {
+			return err
+		}
+		return sp.Commit(ctx)
+	})
+	if err != nil {
+		log.Printf("Error upserting uploaded player pack: %v", err)
+		http.Error(w, "Failed to upload pack", http.StatusInternalServerError)
+		return
+	}
+
+	added := 0
+	for _, tpl := range templates {
+		inserted, err := qtx.AddPlayerTriviaPackQuestion(ctx, db.AddPlayerTriviaPackQuestionParams{
+			PackID:        pack.ID,
+			QuestionText:  tpl.QuestionText,
+			CorrectAnswer: tpl.CorrectAnswer,
+			WrongAnswer1:  tpl.WrongAnswer1,
+			WrongAnswer2:  tpl.WrongAnswer2,
+			WrongAnswer3:  tpl.WrongAnswer3,
+			MinRating:     tpl.MinRating,
+			Category:      tpl.Category,
+			Difficulty:    tpl.Difficulty,
+		})
+		if err != nil {
+			log.Printf("Error adding uploaded question: %v", err)
+			http.Error(w, "Failed to upload pack", http.StatusInternalServerError)
+			return
+		}
+		added += int(inserted)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Error committing transaction: %v", err)
+		http.Error(w, "Failed to upload pack", http.StatusInternalServerError)
+		return
+	}
+
+	notice := fmt.Sprintf("Uploaded %q: %d added", pack.Name, added)
+	if skipped := len(templates) - added; skipped > 0 {
+		notice += fmt.Sprintf(", %d already in the pack", skipped)
+	}
+	g.renderQuestionTemplates(w, r, lobby, player.ID, true, notice+".")
+}
+
+// validateUploadedTemplates applies the curated content checks plus upload
+// limits. Questions rated above the lobby would be hidden right after upload,
+// so they are rejected instead. With checkWording, so are questions the
+// rating classifier thinks need a stricter audience than the lobby allows.
+func validateUploadedTemplates(templates []triviacontent.Template, lobbyRating int16, checkWording bool) error {
+	if len(templates) > maxPackUploadQuestions {
+		return fmt.Errorf("file has %d questions; the limit is %d", len(templates), maxPackUploadQuestions)
+	}
+	var errs []error
+	for i, tpl := range templates {
+		if tpl.MinRating > lobbyRating {
+			errs = append(errs, fmt.Errorf("questions[%d] is rated above this lobby's audience setting", i))
+		} else if checkWording {
+			if c := contentrating.Classify(tpl.RatingText()); c.Rating > lobbyRating {
+				errs = append(errs, fmt.Errorf("questions[%d] looks too mature for this lobby's audience setting (%s)", i, strings.Join(c.Above(lobbyRating), "; ")))
+			}
+		}
+	}
+	if err := triviacontent.ValidateTemplates(templates); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// uploadErrorNotice condenses joined validation errors into a short notice.
+func uploadErrorNotice(err error) string {
+	lines := strings.Split(err.Error(), "\n")
+	notice := "Upload failed: " + strings.Join(lines[:min(len(lines), maxUploadErrorLines)], "; ")
+	if extra := len(lines) - maxUploadErrorLines; extra > 0 {
+		notice += fmt.Sprintf(" (and %d more)", extra)
+	}
+	return notice
+}
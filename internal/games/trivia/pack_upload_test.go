@@ -0,0 +1,40 @@
+package trivia
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jgoodhcg/mindmeld/internal/triviacontent"
+)
+
+func TestValidateUploadedTemplatesRejectsRatingAboveLobby(t *testing.T) {
+	templates := []triviacontent.Template{{
+		Slug:          "upload-1",
+		Category:      "Movies",
+		QuestionText:  "Which film won Best Picture in 1998?",
+		CorrectAnswer: "Titanic",
+		WrongAnswer1:  "Good Will Hunting",
+		WrongAnswer2:  "As Good as It Gets",
+		WrongAnswer3:  "L.A. Confidential",
+		MinRating:     30,
+	}}
+
+	err := validateUploadedTemplates(templates, 20, false)
+	if err == nil || !strings.Contains(err.Error(), "rated above") {
+		t.Fatalf("expected rating error, got %v", err)
+	}
+	if err := validateUploadedTemplates(templates, 30, false); err != nil {
+		t.Fatalf("expected adults lobby to accept upload, got %v", err)
+	}
+}
+
+func TestUploadErrorNoticeTruncates(t *testing.T) {
+	err := errors.Join(errors.New("a"), errors.New("b"), errors.New("c"), errors.New("d"), errors.New("e"))
+	if got := uploadErrorNotice(err); got != "Upload failed: a; b; c (and 2 more)" {
+		t.Fatalf("unexpected notice %q", got)
+	}
+	if got := uploadErrorNotice(errors.New("only")); got != "Upload failed: only" {
+		t.Fatalf("unexpected notice %q", got)
+	}
+}
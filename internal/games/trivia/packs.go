@@ -0,0 +1,173 @@
+package trivia
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/auth"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	"github.com/jgoodhcg/mindmeld/internal/questions"
+	"github.com/jgoodhcg/mindmeld/internal/sharecode"
+)
+
+const (
+	maxPlayerPackNameLen = 60
+	playerPackCategory   = "Saved Questions"
+	// playerTemplatePrefix marks template IDs that point at a player pack
+	// question rather than a curated template slug.
+	playerTemplatePrefix = "player-"
+)
+
+// handleSavePlayerPackByCode adds another player's pack to the current player's
+// template sources and re-renders the templates modal.
+func (g *TriviaGame) handleSavePlayerPackByCode(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	player := auth.GetPlayer(r.Context())
+
+	lobby, err := g.queries.GetLobbyByCode(r.Context(), code)
+	if err != nil {
+		http.Error(w, "Lobby not found", http.StatusNotFound)
+		return
+	}
+
+	participation, err := g.queries.GetPlayerParticipation(r.Context(), db.GetPlayerParticipationParams{
+		LobbyID:  lobby.ID,
+		PlayerID: player.ID,
+	})
+	if err != nil {
+		http.Error(w, "Not in lobby", http.StatusForbidden)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	notice := ""
+	pack, err := g.queries.GetPlayerTriviaPackByShareCode(r.Context(), sharecode.Normalize(r.FormValue("share_code")))
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		notice = "No pack found for that code."
+	case err != nil:
+		log.Printf("Error looking up player pack: %v", err)
+		http.Error(w, "Failed to load pack", http.StatusInternalServerError)
+		return
+	case pack.MinRating > lobby.ContentRating:
+		notice = "That pack is rated above this lobby's audience setting."
+	default:
+		if err := g.queries.SavePlayerTriviaPack(r.Context(), db.SavePlayerTriviaPackParams{
+			PlayerID: player.ID,
+			PackID:   pack.ID,
+		}); err != nil {
+			log.Printf("Error saving player pack: %v", err)
+			http.Error(w, "Failed to load pack", http.StatusInternalServerError)
+			return
+		}
+		notice = "Loaded \"" + pack.Name + "\"."
+	}
+
+	g.renderQuestionTemplates(w, r, lobby, player.ID, participation.IsHost, notice)
+}
+
+// saveQuestionToPlayerPack copies a submitted question into the author's named
+// pack, creating the pack on first use. The pack rating rises to the lobby rating
+// the question was written under so it never shows up in a milder lobby.
+func (g *TriviaGame) saveQuestionToPlayerPack(ctx context.Context, playerID pgtype.UUID, packName string, question db.TriviaQuestion) error {
+	packName = normalizePlayerPackName(packName)
+	if packName == "" {
+		return nil
+	}
+
+	var pack db.PlayerTriviaPack
+	err := sharecode.Insert(func(code string) error {
+		var err error
+		pack, err = g.queries.UpsertPlayerTriviaPack(ctx, db.UpsertPlayerTriviaPackParams{
+			OwnerPlayerID: playerID,
+			Name:          packName,
+			ShareCode:     code,
+			MinRating:     question.MinRating,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = g.queries.AddPlayerTriviaPackQuestion(ctx, db.AddPlayerTriviaPackQuestionParams{
+		PackID:        pack.ID,
+		QuestionText:  question.QuestionText,
+		CorrectAnswer: question.CorrectAnswer,
+		WrongAnswer1:  question.WrongAnswer1,
+		WrongAnswer2:  question.WrongAnswer2,
+		WrongAnswer3:  question.WrongAnswer3,
+		MinRating:     question.MinRating,
+	})
+	return err
+}
+
+// loadPlayerPackCatalog reads the player's own and saved packs allowed for a lobby rating.
+func (g *TriviaGame) loadPlayerPackCatalog(ctx context.Context, playerID pgtype.UUID, lobbyRating int16) (questions.Catalog, error) {
+	rows, err := g.queries.ListPlayerTriviaPackQuestions(ctx, db.ListPlayerTriviaPackQuestionsParams{
+		OwnerPlayerID: playerID,
+		MinRating:     lobbyRating,
+	})
+	if err != nil {
+		return questions.Catalog{}, err
+	}
+	return playerPackCatalog(rows), nil
+}
+
+func playerPackCatalog(rows []db.ListPlayerTriviaPackQuestionsRow) questions.Catalog {
+	var catalog questions.Catalog
+	seenPacks := make(map[string]bool)
+	for _, row := range rows {
+		packID := "player-pack-" + row.ShareCode
+		if !seenPacks[packID] {
+			seenPacks[packID] = true
+			description := "Shared with you"
+			if row.IsOwner {
+				description = "Your saved questions"
+			}
+			catalog.Packs = append(catalog.Packs, questions.Pack{
+				ID:          packID,
+				Name:        row.PackName,
+				Description: description,
+				MinRating:   row.PackMinRating,
+				ShareCode:   row.ShareCode,
+			})
+		}
+		category := row.Category
+		if category == "" {
+			category = playerPackCategory
+		}
+		catalog.Templates = append(catalog.Templates, questions.Template{
+			// Stored in used_question_templates.template_id (VARCHAR(50)).
+			ID:            playerTemplatePrefix + row.ID.String(),
+			PackID:        packID,
+			Category:      category,
+			QuestionText:  row.QuestionText,
+			CorrectAnswer: row.CorrectAnswer,
+			WrongAnswer1:  row.WrongAnswer1,
+			WrongAnswer2:  row.WrongAnswer2,
+			WrongAnswer3:  row.WrongAnswer3,
+			MinRating:     row.MinRating,
+			Difficulty:    row.Difficulty,
+		})
+	}
+	return catalog
+}
+
+func normalizePlayerPackName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if len([]rune(name)) > maxPlayerPackNameLen {
+		name = strings.TrimSpace(string([]rune(name)[:maxPlayerPackNameLen]))
+	}
+	return name
+}
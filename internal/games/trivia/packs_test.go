@@ -0,0 +1,56 @@
+package trivia
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+)
+
+func TestPlayerPackCatalogGroupsRowsByPack(t *testing.T) {
+	rows := []db.ListPlayerTriviaPackQuestionsRow{
+		{PackName: "Office Lore", ShareCode: "AAAA1111", PackMinRating: 20, IsOwner: true, ID: pgtype.UUID{Bytes: [16]byte{1}, Valid: true}, QuestionText: "Q1", MinRating: 20},
+		{PackName: "Office Lore", ShareCode: "AAAA1111", PackMinRating: 20, IsOwner: true, ID: pgtype.UUID{Bytes: [16]byte{2}, Valid: true}, QuestionText: "Q2", MinRating: 10},
+		{PackName: "Road Trip", ShareCode: "BBBB2222", PackMinRating: 10, IsOwner: false, ID: pgtype.UUID{Bytes: [16]byte{3}, Valid: true}, QuestionText: "Q3", MinRating: 10, Category: "Movies", Difficulty: "hard"},
+	}
+
+	catalog := playerPackCatalog(rows)
+	if len(catalog.Packs) != 2 {
+		t.Fatalf("expected 2 packs, got %d", len(catalog.Packs))
+	}
+	if catalog.Packs[0].ShareCode != "AAAA1111" || catalog.Packs[0].Description != "Your saved questions" {
+		t.Fatalf("unexpected owned pack: %+v", catalog.Packs[0])
+	}
+	if catalog.Packs[1].Description != "Shared with you" {
+		t.Fatalf("unexpected shared pack: %+v", catalog.Packs[1])
+	}
+	if len(catalog.Templates) != 3 {
+		t.Fatalf("expected 3 templates, got %d", len(catalog.Templates))
+	}
+	if catalog.Templates[0].Category != playerPackCategory {
+		t.Fatalf("expected uncategorized questions under %q, got %q", playerPackCategory, catalog.Templates[0].Category)
+	}
+	if catalog.Templates[2].Category != "Movies" || catalog.Templates[2].Difficulty != "hard" {
+		t.Fatalf("expected imported category and difficulty kept, got %+v", catalog.Templates[2])
+	}
+	for _, tpl := range catalog.Templates {
+		if len(tpl.ID) > 50 || !strings.HasPrefix(tpl.ID, "player-") {
+			t.Fatalf("template ID %q must fit used_question_templates.template_id", tpl.ID)
+		}
+	}
+
+	kidsSections := catalog.BuildPackSections(nil, 10)
+	if len(kidsSections) != 1 || kidsSections[0].Pack.Name != "Road Trip" {
+		t.Fatalf("expected only the mild pack for kids lobbies, got %+v", kidsSections)
+	}
+}
+
+func TestNormalizePlayerPackName(t *testing.T) {
+	if got := normalizePlayerPackName("  Office   Lore "); got != "Office Lore" {
+		t.Fatalf("unexpected pack name %q", got)
+	}
+	if got := normalizePlayerPackName(strings.Repeat("x", 80)); len(got) != maxPlayerPackNameLen {
+		t.Fatalf("expected name capped at %d, got %d", maxPlayerPackNameLen, len(got))
+	}
+}
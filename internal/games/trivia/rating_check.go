@@ -0,0 +1,34 @@
+package trivia
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+// ratingCheckEnabled reports whether submitted questions and uploaded packs
+// go through the rating classifier. It is off unless TRIVIA_RATING_CHECK is
+// set, since a false positive blocks a player mid-round and the word lists
+// cannot tell "Which country produces the most wine?" from edgy content.
+func ratingCheckEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("TRIVIA_RATING_CHECK"))) {
+	case "1", "true", "on", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// submissionRatingNotice returns a player-facing message when the question
+// and answers look too mature for the lobby's audience setting, or "" when
+// they pass.
+func submissionRatingNotice(lobbyRating int16, texts ...string) string {
+	c := contentrating.Classify(texts...)
+	if c.Rating <= lobbyRating {
+		return ""
+	}
+	return fmt.Sprintf("This question looks too mature for this lobby's %s audience setting (%s). Please reword it.",
+		contentrating.Label(lobbyRating), strings.Join(c.Above(lobbyRating), "; "))
+}
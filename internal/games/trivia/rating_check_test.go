@@ -0,0 +1,108 @@
+package trivia
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	"github.com/jgoodhcg/mindmeld/internal/triviacontent"
+)
+
+func TestValidateUploadedTemplatesRejectsMatureWording(t *testing.T) {
+	templates := []triviacontent.Template{{
+		Slug:          "upload-1",
+		Category:      "Drinks",
+		QuestionText:  "Which spirit is distilled from agave?",
+		CorrectAnswer: "Tequila",
+		WrongAnswer1:  "Rum",
+		WrongAnswer2:  "Gin",
+		WrongAnswer3:  "Brandy",
+		MinRating:     10,
+	}}
+
+	if err := validateUploadedTemplates(templates, 10, false); err != nil {
+		t.Fatalf("expected upload to pass with the wording check off, got %v", err)
+	}
+	err := validateUploadedTemplates(templates, 10, true)
+	if err == nil || !strings.Contains(err.Error(), `looks too mature for this lobby's audience setting ("tequila" suggests Polite (20))`) {
+		t.Fatalf("expected classifier error, got %v", err)
+	}
+	if err := validateUploadedTemplates(templates, 20, true); err != nil {
+		t.Fatalf("expected polite lobby to accept upload, got %v", err)
+	}
+}
+
+func TestSubmissionRatingNotice(t *testing.T) {
+	if got := submissionRatingNotice(10, "What is the capital of France?", "Paris", "Lyon", "Nice", "Lille"); got != "" {
+		t.Fatalf("expected clean question to pass, got %q", got)
+	}
+	got := submissionRatingNotice(20, "Which drug is made from coca leaves?", "Cocaine", "Heroin", "Caffeine", "Nicotine")
+	if !strings.Contains(got, "Polite audience setting") || !strings.Contains(got, `"cocaine" suggests Adults (30)`) {
+		t.Fatalf("unexpected notice %q", got)
+	}
+}
+
+func TestRatingCheckEnabled(t *testing.T) {
+	t.Setenv("TRIVIA_RATING_CHECK", "")
+	if ratingCheckEnabled() {
+		t.Fatal("expected check to be off by default")
+	}
+	t.Setenv("TRIVIA_RATING_CHECK", " On ")
+	if !ratingCheckEnabled() {
+		t.Fatal("expected check to be on")
+	}
+}
+
+func TestRejectMatureQuestionKeepsDraft(t *testing.T) {
+	form := url.Values{
+		"template_id":    {"tpl-1"},
+		"question_text":  {"Which drug is made from coca leaves?"},
+		"correct_answer": {"Cocaine"},
+		"wrong_answer_1": {"Heroin"},
+		"wrong_answer_2": {"Caffeine"},
+		"wrong_answer_3": {"Nicotine"},
+		"save_pack_name": {"Pharmacy Night"},
+	}
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/lobbies/ABCD/trivia/questions", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return r
+	}
+	lobby := db.Lobby{Code: "ABCD", ContentRating: 20}
+
+	t.Setenv("TRIVIA_RATING_CHECK", "")
+	w := httptest.NewRecorder()
+	r := newRequest()
+	if rejectMatureQuestion(w, r, lobby, questionDraftFromForm(r)) {
+		t.Fatal("expected the question through with the check off")
+	}
+
+	t.Setenv("TRIVIA_RATING_CHECK", "on")
+	w = httptest.NewRecorder()
+	r = newRequest()
+	if !rejectMatureQuestion(w, r, lobby, questionDraftFromForm(r)) {
+		t.Fatal("expected the question to be rejected")
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the form to come back with 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`id="submit-question-form"`,
+		"too mature for this lobby",
+		`value="tpl-1"`,
+		">Which drug is made from coca leaves?</textarea>",
+		`value="Cocaine"`,
+		`value="Heroin"`,
+		`value="Caffeine"`,
+		`value="Nicotine"`,
+		`value="Pharmacy Night"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected form to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package trivia
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	"github.com/jgoodhcg/mindmeld/internal/events"
+	triviatmpl "github.com/jgoodhcg/mindmeld/templates/trivia"
+)
+
+// RenderScreen builds the big-screen view: submission progress, the current
+// question with its answer count, and the distribution once revealed.
+func (g *TriviaGame) RenderScreen(ctx context.Context, lobby db.Lobby, players []db.GetLobbyPlayersRow) templ.Component {
+	var (
+		activeRound     db.TriviaRound
+		currentQuestion db.TriviaQuestion
+		questionActive  bool
+		progressCount   int
+		progressTotal   int
+		distribution    []events.AnswerStat
+		scoreboard      []db.GetLobbyScoreboardRow
+	)
+	now := time.Now()
+
+	if lobby.Phase == "playing" {
+		var err error
+		activeRound, err = g.queries.GetActiveRound(ctx, lobby.ID)
+		if err != nil {
+			log.Printf("[trivia] failed to get active round for lobby %s screen: %v", lobby.Code, err)
+		}
+
+		switch activeRound.Phase {
+		case "submitting":
+			questions, err := g.queries.GetQuestionsForRound(ctx, activeRound.ID)
+			if err == nil {
+				progressCount = len(questions)
+			}
+			progressTotal = g.countActivePlayers(lobby.Code, players, now, "")
+		case "playing":
+			if activeRound.CurrentQuestionID.Valid {
+				questions, err := g.queries.GetQuestionsForRound(ctx, activeRound.ID)
+				if err == nil {
+					for _, q := range questions {
+						if q.ID == activeRound.CurrentQuestionID {
+							currentQuestion = q
+							questionActive = true
+							break
+						}
+					}
+				}
+			}
+			if questionActive {
+				progressTotal = g.countActivePlayers(lobby.Code, players, now, currentQuestion.Author.String())
+				answers, err := g.queries.GetAnswersForQuestion(ctx, currentQuestion.ID)
+				if err == nil {
+					progressCount = len(answers)
+					distribution = buildAnswerDistributionFromAnswers(currentQuestion, answers)
+				}
+			}
+		case "finished":
+			scoreboard, _ = g.queries.GetLobbyScoreboard(ctx, lobby.ID)
+		}
+	}
+
+	return triviatmpl.Screen(lobby, len(players), activeRound, currentQuestion, questionActive, progressCount, progressTotal, distribution, scoreboard)
+}
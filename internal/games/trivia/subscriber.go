@@ -69,6 +69,7 @@ func (g *TriviaGame) broadcastQuestionSubmitted(ctx context.Context, lobbyCode s
 		}
 		return buf.Bytes()
 	})
+	g.broadcastScreenProgress(ctx, lobbyCode, payload.SubmittedCount, payload.TotalPlayers, "submitted", hub)
 }
 
 func (g *TriviaGame) broadcastRoundAdvanced(ctx context.Context, lobbyCode string, payload events.RoundAdvancedPayload, hub *ws.Hub) {
@@ -85,6 +86,7 @@ func (g *TriviaGame) broadcastAnswerSubmitted(ctx context.Context, lobbyCode str
 	if payload.QuestionComplete {
 		return
 	}
+	g.broadcastScreenProgress(ctx, lobbyCode, payload.AnsweredCount, payload.TotalExpected, "answered", hub)
 
 	lobby, err := queries.GetLobbyByCode(ctx, lobbyCode)
 	if err != nil {
@@ -181,6 +183,15 @@ func (g *TriviaGame) broadcastAnswerSubmitted(ctx context.Context, lobbyCode str
 	})
 }
 
+func (g *TriviaGame) broadcastScreenProgress(ctx context.Context, lobbyCode string, count, total int, verb string, hub *ws.Hub) {
+	var buf bytes.Buffer
+	if err := triviatmpl.ScreenProgress(count, total, verb, true).Render(ctx, &buf); err != nil {
+		log.Printf("[trivia-subscriber] Failed to render screen progress for lobby %s: %v", lobbyCode, err)
+		return
+	}
+	hub.BroadcastSpectators(ctx, lobbyCode, buf.Bytes())
+}
+
 func (g *TriviaGame) broadcastNewRoundCreated(ctx context.Context, lobbyCode string, payload events.NewRoundCreatedPayload, hub *ws.Hub) {
 	log.Printf("[trivia-subscriber] New round created for lobby %s, round %d", lobbyCode, payload.RoundNumber)
 	ws.BroadcastUpdateTrigger(ctx, lobbyCode, hub)
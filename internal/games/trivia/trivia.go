@@ -168,7 +168,11 @@ func (g *TriviaGame) countActivePlayers(lobbyCode string, players []db.GetLobbyP
 // RegisterRoutes registers trivia-specific HTTP routes.
 func (g *TriviaGame) RegisterRoutes(r chi.Router) {
 	r.Post("/start", g.handleStartGame)
+	r.Get("/pack-round", g.handleGetPackRoundForm)
+	r.Post("/pack-round", g.handleStartPackRound)
 	r.Get("/question-templates", g.handleGetQuestionTemplates)
+	r.Post("/question-packs", g.handleSavePlayerPackByCode)
+	r.Post("/question-packs/upload", g.handleUploadPlayerPack)
 	r.Post("/generate-question", g.handleGenerateQuestion)
 	r.Post("/questions", g.handleSubmitQuestion)
 	r.Post("/advance", g.handleAdvanceRound)
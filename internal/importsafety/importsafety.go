@@ -0,0 +1,81 @@
+// Package importsafety guards content import CLIs against writing to the
+// wrong database.
+package importsafety
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ResolveDatabaseURL prefers an explicit flag value and falls back to DATABASE_URL.
+func ResolveDatabaseURL(flagValue string) (string, error) {
+	if strings.TrimSpace(flagValue) != "" {
+		return strings.TrimSpace(flagValue), nil
+	}
+	if strings.TrimSpace(os.Getenv("DATABASE_URL")) != "" {
+		return strings.TrimSpace(os.Getenv("DATABASE_URL")), nil
+	}
+	return "", errors.New("database URL is required (set -database-url or DATABASE_URL)")
+}
+
+// Validate checks that the target env, database URL, and -allow-production agree.
+func Validate(targetEnv string, databaseURL string, allowProduction bool) error {
+	env := NormalizeEnv(targetEnv)
+	isProdLikeURL := IsProductionLikeURL(databaseURL)
+
+	if isProdLikeURL && !allowProduction {
+		return errors.New("refusing to run against production-like DB without -allow-production")
+	}
+	if env == "prod" && !allowProduction {
+		return errors.New("refusing prod import without -allow-production")
+	}
+	if isProdLikeURL && env != "prod" {
+		return errors.New("production-like DB requires -env=prod")
+	}
+	if env == "prod" && !isProdLikeURL {
+		return errors.New("-env=prod provided but database URL looks local; use -env=dev instead")
+	}
+
+	return nil
+}
+
+// NormalizeEnv maps an -env value to "prod" or "dev"; anything unrecognized is dev.
+func NormalizeEnv(env string) string {
+	value := strings.ToLower(strings.TrimSpace(env))
+	switch value {
+	case "prod", "production":
+		return "prod"
+	default:
+		return "dev"
+	}
+}
+
+// IsProductionLikeURL reports whether databaseURL points anywhere but a local host.
+func IsProductionLikeURL(databaseURL string) bool {
+	parsed, err := url.Parse(strings.TrimSpace(databaseURL))
+	if err != nil {
+		return true
+	}
+
+	host := strings.ToLower(strings.TrimSpace(parsed.Hostname()))
+	if host == "" {
+		return true
+	}
+
+	localHosts := map[string]bool{
+		"localhost": true,
+		"127.0.0.1": true,
+		"0.0.0.0":   true,
+		"::1":       true,
+	}
+	if localHosts[host] {
+		return false
+	}
+	if strings.HasSuffix(host, ".local") {
+		return false
+	}
+
+	return true
+}
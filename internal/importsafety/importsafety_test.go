@@ -1,4 +1,4 @@
-package main
+package importsafety
 
 import "testing"
 
@@ -15,35 +15,35 @@ func TestIsProductionLikeURL(t *testing.T) {
 		{name: "invalid url", url: "not-a-url", want: true},
 	}
 	for _, tt := range tests {
-		got := isProductionLikeURL(tt.url)
+		got := IsProductionLikeURL(tt.url)
 		if got != tt.want {
 			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, got)
 		}
 	}
 }
 
-func TestValidateImportSafety(t *testing.T) {
-	if err := validateImportSafety("dev", "postgres://u:p@localhost:5432/db", false); err != nil {
+func TestValidate(t *testing.T) {
+	if err := Validate("dev", "postgres://u:p@localhost:5432/db", false); err != nil {
 		t.Fatalf("dev localhost should pass: %v", err)
 	}
 
-	if err := validateImportSafety("dev", "postgres://u:p@prod-db.example.com:5432/db", false); err == nil {
+	if err := Validate("dev", "postgres://u:p@prod-db.example.com:5432/db", false); err == nil {
 		t.Fatal("expected production-like URL without allow-production to fail")
 	}
 
-	if err := validateImportSafety("dev", "postgres://u:p@prod-db.example.com:5432/db", true); err == nil {
+	if err := Validate("dev", "postgres://u:p@prod-db.example.com:5432/db", true); err == nil {
 		t.Fatal("expected production-like URL with dev env to fail")
 	}
 
-	if err := validateImportSafety("prod", "postgres://u:p@prod-db.example.com:5432/db", false); err == nil {
+	if err := Validate("prod", "postgres://u:p@prod-db.example.com:5432/db", false); err == nil {
 		t.Fatal("expected prod env without allow-production to fail")
 	}
 
-	if err := validateImportSafety("prod", "postgres://u:p@prod-db.example.com:5432/db", true); err != nil {
+	if err := Validate("prod", "postgres://u:p@prod-db.example.com:5432/db", true); err != nil {
 		t.Fatalf("prod env with allow-production should pass: %v", err)
 	}
 
-	if err := validateImportSafety("prod", "postgres://u:p@localhost:5432/db", true); err == nil {
+	if err := Validate("prod", "postgres://u:p@localhost:5432/db", true); err == nil {
 		t.Fatal("expected prod env with local URL to fail")
 	}
 }
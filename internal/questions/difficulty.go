@@ -0,0 +1,205 @@
+package questions
+
+import (
+	"sort"
+)
+
+// Difficulty labels on templates; empty means unrated.
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+// Difficulty curves for rounds built from templates.
+const (
+	// CurveMixed keeps the selection order and ignores difficulty.
+	CurveMixed = "mixed"
+	// CurveRamp goes from easy through medium to hard across the round.
+	CurveRamp = "ramp"
+	// CurveEasy, CurveMedium, and CurveHard prefer one level and fall back to
+	// the nearest level when a pack runs out.
+	CurveEasy   = "easy"
+	CurveMedium = "medium"
+	CurveHard   = "hard"
+)
+
+// Sort orders for the templates modal.
+const (
+	SortDefault = ""
+	SortEasiest = "easiest"
+	SortHardest = "hardest"
+)
+
+// MinLearnedAnswers is how many answers a template needs before its observed
+// correctness rate replaces the authored difficulty.
+const MinLearnedAnswers = 8
+
+// Correctness rates separating learned difficulty levels.
+const (
+	easyCorrectRate = 0.7
+	hardCorrectRate = 0.4
+)
+
+// AnswerStat is the answer history for questions played from one template.
+type AnswerStat struct {
+	Answers int
+	Correct int
+}
+
+// DifficultyFromCorrectRate maps the share of correct answers to a difficulty.
+func DifficultyFromCorrectRate(rate float64) string {
+	switch {
+	case rate >= easyCorrectRate:
+		return DifficultyEasy
+	case rate < hardCorrectRate:
+		return DifficultyHard
+	default:
+		return DifficultyMedium
+	}
+}
+
+// LearnedDifficulty is the difficulty observed from answers, or empty when the
+// template has not been answered enough times.
+func (t Template) LearnedDifficulty() string {
+	if t.Stats.Answers < MinLearnedAnswers {
+		return ""
+	}
+	return DifficultyFromCorrectRate(float64(t.Stats.Correct) / float64(t.Stats.Answers))
+}
+
+// EffectiveDifficulty prefers learned difficulty over the authored label.
+func (t Template) EffectiveDifficulty() string {
+	if learned := t.LearnedDifficulty(); learned != "" {
+		return learned
+	}
+	return t.Difficulty
+}
+
+// WithAnswerStats returns a copy of the catalog with answer history attached
+// to templates by ID.
+func (c Catalog) WithAnswerStats(stats map[string]AnswerStat) Catalog {
+	templates := make([]Template, len(c.Templates))
+	for i, t := range c.Templates {
+		t.Stats = stats[t.ID]
+		templates[i] = t
+	}
+	return Catalog{Packs: c.Packs, Templates: templates}
+}
+
+// FilterByDifficulty keeps templates whose effective difficulty matches level.
+// An empty level keeps everything.
+func (c Catalog) FilterByDifficulty(level string) Catalog {
+	if level == "" {
+		return c
+	}
+	templates := make([]Template, 0, len(c.Templates))
+	for _, t := range c.Templates {
+		if t.EffectiveDifficulty() == level {
+			templates = append(templates, t)
+		}
+	}
+	return Catalog{Packs: c.Packs, Templates: templates}
+}
+
+// SortSectionsByDifficulty reorders templates inside each category. Unrated
+// templates sort after rated ones in either direction.
+func SortSectionsByDifficulty(sections []PackSection, order string) {
+	if order != SortEasiest && order != SortHardest {
+		return
+	}
+	for _, section := range sections {
+		for _, templates := range section.TemplatesByCategory {
+			sort.SliceStable(templates, func(i, j int) bool {
+				a, b := difficultyRank(templates[i]), difficultyRank(templates[j])
+				if a == 0 || b == 0 {
+					return b == 0 && a != 0
+				}
+				if order == SortHardest {
+					return a > b
+				}
+				return a < b
+			})
+		}
+	}
+}
+
+// ApplyCurve picks count templates from ordered following a difficulty curve.
+// Unrated templates count as medium. Within a level, earlier templates in
+// ordered win, so callers keep control of pack mixing and randomness.
+func ApplyCurve(ordered []Template, curve string, count int) []Template {
+	count = min(count, len(ordered))
+	if curve == CurveMixed || curve == "" {
+		return append([]Template(nil), ordered[:count]...)
+	}
+
+	remaining := append([]Template(nil), ordered...)
+	selected := make([]Template, 0, count)
+	for i := range count {
+		target := curveTarget(curve, i, count)
+		best, bestDist := -1, 0
+		for j, t := range remaining {
+			dist := abs(curveLevel(t) - target)
+			if best == -1 || dist < bestDist {
+				best, bestDist = j, dist
+				if dist == 0 {
+					break
+				}
+			}
+		}
+		selected = append(selected, remaining[best])
+		remaining = append(remaining[:best], remaining[best+1:]...)
+	}
+	return selected
+}
+
+// IsCurve reports whether value is a supported difficulty curve.
+func IsCurve(value string) bool {
+	switch value {
+	case CurveMixed, CurveRamp, CurveEasy, CurveMedium, CurveHard:
+		return true
+	}
+	return false
+}
+
+func curveTarget(curve string, position int, count int) int {
+	switch curve {
+	case CurveEasy:
+		return 1
+	case CurveHard:
+		return 3
+	case CurveRamp:
+		return 1 + position*3/count
+	default:
+		return 2
+	}
+}
+
+// curveLevel places a template on the 1 (easy) to 3 (hard) scale.
+func curveLevel(t Template) int {
+	if rank := difficultyRank(t); rank != 0 {
+		return rank
+	}
+	return 2
+}
+
+// difficultyRank is 1-3 for rated templates and 0 for unrated ones.
+func difficultyRank(t Template) int {
+	switch t.EffectiveDifficulty() {
+	case DifficultyEasy:
+		return 1
+	case DifficultyMedium:
+		return 2
+	case DifficultyHard:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
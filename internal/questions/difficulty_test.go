@@ -0,0 +1,101 @@
+package questions
+
+import "testing"
+
+func TestEffectiveDifficultyPrefersLearned(t *testing.T) {
+	tpl := Template{ID: "t", Difficulty: DifficultyEasy}
+	if got := tpl.EffectiveDifficulty(); got != DifficultyEasy {
+		t.Fatalf("expected authored difficulty without stats, got %q", got)
+	}
+
+	tpl.Stats = AnswerStat{Answers: MinLearnedAnswers - 1, Correct: 0}
+	if got := tpl.EffectiveDifficulty(); got != DifficultyEasy {
+		t.Fatalf("expected authored difficulty below the answer threshold, got %q", got)
+	}
+
+	tpl.Stats = AnswerStat{Answers: 10, Correct: 2}
+	if got := tpl.EffectiveDifficulty(); got != DifficultyHard {
+		t.Fatalf("expected learned hard difficulty, got %q", got)
+	}
+}
+
+func TestDifficultyFromCorrectRate(t *testing.T) {
+	cases := map[float64]string{
+		1.0:  DifficultyEasy,
+		0.7:  DifficultyEasy,
+		0.55: DifficultyMedium,
+		0.4:  DifficultyMedium,
+		0.39: DifficultyHard,
+		0:    DifficultyHard,
+	}
+	for rate, want := range cases {
+		if got := DifficultyFromCorrectRate(rate); got != want {
+			t.Fatalf("DifficultyFromCorrectRate(%v) = %q, want %q", rate, got, want)
+		}
+	}
+}
+
+func curveTemplates() []Template {
+	return []Template{
+		{ID: "h1", Difficulty: DifficultyHard},
+		{ID: "m1", Difficulty: DifficultyMedium},
+		{ID: "e1", Difficulty: DifficultyEasy},
+		{ID: "u1"},
+		{ID: "h2", Difficulty: DifficultyHard},
+		{ID: "e2", Difficulty: DifficultyEasy},
+	}
+}
+
+func templateIDs(templates []Template) []string {
+	ids := make([]string, len(templates))
+	for i, t := range templates {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+func assertIDs(t *testing.T, got []Template, want ...string) {
+	t.Helper()
+	ids := templateIDs(got)
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, ids)
+		}
+	}
+}
+
+func TestApplyCurveRampGoesEasyToHard(t *testing.T) {
+	assertIDs(t, ApplyCurve(curveTemplates(), CurveRamp, 6), "e1", "e2", "m1", "u1", "h1", "h2")
+}
+
+func TestApplyCurveFallsBackToNearestLevel(t *testing.T) {
+	// Two easy templates exist, so the third pick falls back to medium.
+	assertIDs(t, ApplyCurve(curveTemplates(), CurveEasy, 3), "e1", "e2", "m1")
+	assertIDs(t, ApplyCurve(curveTemplates(), CurveMixed, 2), "h1", "m1")
+	assertIDs(t, ApplyCurve(curveTemplates(), CurveHard, 10), "h1", "h2", "m1", "u1", "e1", "e2")
+}
+
+func TestFilterAndSortByDifficulty(t *testing.T) {
+	catalog := Catalog{
+		Packs:     []Pack{{ID: "p", MinRating: 10}},
+		Templates: curveTemplates(),
+	}
+	for i := range catalog.Templates {
+		catalog.Templates[i].PackID = "p"
+		catalog.Templates[i].Category = "General"
+	}
+
+	if got := catalog.FilterByDifficulty(DifficultyHard); len(got.Templates) != 2 {
+		t.Fatalf("expected 2 hard templates, got %v", templateIDs(got.Templates))
+	}
+
+	sections := catalog.BuildPackSections(nil, 10)
+	SortSectionsByDifficulty(sections, SortHardest)
+	assertIDs(t, sections[0].TemplatesByCategory["General"], "h1", "h2", "m1", "e1", "e2", "u1")
+
+	SortSectionsByDifficulty(sections, SortEasiest)
+	assertIDs(t, sections[0].TemplatesByCategory["General"], "e1", "e2", "m1", "h1", "h2", "u1")
+}
@@ -2,8 +2,26 @@ package questions
 
 import "testing"
 
+func testCatalog() Catalog {
+	return Catalog{
+		Packs: []Pack{
+			{ID: "work-essentials", Name: "Work Essentials", MinRating: 20},
+			{ID: "quick-brain-boost", Name: "Quick Brain Boost", MinRating: 10},
+			{ID: "world-snapshot", Name: "World Snapshot", MinRating: 10},
+		},
+		Templates: []Template{
+			{ID: "work-001", PackID: "work-essentials", Category: "Meetings & Process", MinRating: 20},
+			{ID: "work-002", PackID: "work-essentials", Category: "Product Delivery", MinRating: 20},
+			{ID: "quick-001", PackID: "quick-brain-boost", Category: "Science & Math", MinRating: 10},
+			{ID: "quick-002", PackID: "quick-brain-boost", Category: "Everyday Facts", MinRating: 10},
+			{ID: "quick-003", PackID: "quick-brain-boost", Category: "Science & Math", MinRating: 10},
+			{ID: "world-001", PackID: "world-snapshot", Category: "History", MinRating: 10},
+		},
+	}
+}
+
 func TestGetAvailableTemplatesFiltersByRatingAndUsedIDs(t *testing.T) {
-	available := GetAvailableTemplates([]string{"quick-001", "work-001"}, 10)
+	available := testCatalog().GetAvailableTemplates([]string{"quick-001", "work-001"}, 10)
 	if len(available) == 0 {
 		t.Fatalf("expected kids-safe templates, got none")
 	}
@@ -19,22 +37,35 @@ func TestGetAvailableTemplatesFiltersByRatingAndUsedIDs(t *testing.T) {
 }
 
 func TestBuildPackSectionsHonorsPackRating(t *testing.T) {
-	kidsSections := BuildPackSections(nil, 10)
+	catalog := testCatalog()
+
+	kidsSections := catalog.BuildPackSections(nil, 10)
 	if len(kidsSections) != 2 {
 		t.Fatalf("expected 2 kids-safe packs, got %d", len(kidsSections))
 	}
-	if kidsSections[0].Pack.ID != PackQuickBrain {
-		t.Fatalf("expected first kids pack %s, got %s", PackQuickBrain, kidsSections[0].Pack.ID)
+	if kidsSections[0].Pack.ID != "quick-brain-boost" {
+		t.Fatalf("expected first kids pack quick-brain-boost, got %s", kidsSections[0].Pack.ID)
 	}
-	if kidsSections[1].Pack.ID != PackWorldSnapshot {
-		t.Fatalf("expected second kids pack %s, got %s", PackWorldSnapshot, kidsSections[1].Pack.ID)
+	if kidsSections[1].Pack.ID != "world-snapshot" {
+		t.Fatalf("expected second kids pack world-snapshot, got %s", kidsSections[1].Pack.ID)
 	}
 
-	workSections := BuildPackSections(nil, 20)
-	if len(workSections) != len(AllPacks) {
+	workSections := catalog.BuildPackSections(nil, 20)
+	if len(workSections) != len(catalog.Packs) {
 		t.Fatalf("expected all packs for work rating, got %d", len(workSections))
 	}
-	if workSections[0].Pack.ID != PackWorkEssentials {
-		t.Fatalf("expected first work pack %s, got %s", PackWorkEssentials, workSections[0].Pack.ID)
+	if workSections[0].Pack.ID != "work-essentials" {
+		t.Fatalf("expected first work pack work-essentials, got %s", workSections[0].Pack.ID)
+	}
+}
+
+func TestBuildPackSectionsOrdersCategoriesByCatalogOrder(t *testing.T) {
+	sections := testCatalog().BuildPackSections(nil, 10)
+	got := sections[0].Categories
+	if len(got) != 2 || got[0] != "Science & Math" || got[1] != "Everyday Facts" {
+		t.Fatalf("unexpected category order: %v", got)
+	}
+	if sections[0].TemplateCount != 3 {
+		t.Fatalf("expected 3 templates in first section, got %d", sections[0].TemplateCount)
 	}
 }
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jgoodhcg/mindmeld/internal/auth"
+	"github.com/jgoodhcg/mindmeld/internal/games/cluster"
+	"github.com/jgoodhcg/mindmeld/internal/sharecode"
+	clustertmpl "github.com/jgoodhcg/mindmeld/templates/cluster"
+)
+
+// handleClusterProfile shows the current player's Cluster profile across every lobby.
+func (s *Server) handleClusterProfile(w http.ResponseWriter, r *http.Request) {
+	player := auth.GetPlayer(r.Context())
+	profile, err := cluster.LoadPlayerProfile(r.Context(), s.dbPool, player.ID, pgtype.UUID{})
+	if err != nil {
+		log.Printf("Error building cluster profile for player %s: %v", player.ID.String(), err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	shareCode, err := cluster.GetProfileShareCode(r.Context(), s.dbPool, player.ID)
+	if err != nil {
+		log.Printf("Error getting cluster profile share code for player %s: %v", player.ID.String(), err)
+	}
+
+	clustertmpl.ProfilePage(profile, shareCode, true).Render(r.Context(), w)
+}
+
+// handleShareClusterProfile creates the current player's profile link and opens it.
+func (s *Server) handleShareClusterProfile(w http.ResponseWriter, r *http.Request) {
+	player := auth.GetPlayer(r.Context())
+	shareCode, err := cluster.EnsureProfileShareCode(r.Context(), s.dbPool, player.ID)
+	if err != nil {
+		log.Printf("Error sharing cluster profile for player %s: %v", player.ID.String(), err)
+		http.Error(w, "Failed to share profile", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/cluster/profiles/"+shareCode, http.StatusSeeOther)
+}
+
+// handleSharedClusterProfile shows a profile card through its share link.
+func (s *Server) handleSharedClusterProfile(w http.ResponseWriter, r *http.Request) {
+	shareCode := sharecode.Normalize(chi.URLParam(r, "shareCode"))
+	ownerID, err := cluster.GetProfileOwner(r.Context(), s.dbPool, shareCode)
+	if errors.Is(err, pgx.ErrNoRows) {
+		http.Error(w, "Profile not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Error getting cluster profile %s: %v", shareCode, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	profile, err := cluster.LoadPlayerProfile(r.Context(), s.dbPool, ownerID, pgtype.UUID{})
+	if err != nil {
+		log.Printf("Error building cluster profile %s: %v", shareCode, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	player := auth.GetPlayer(r.Context())
+	owner := player.ID.Valid && player.ID == ownerID
+	clustertmpl.ProfilePage(profile, shareCode, owner).Render(r.Context(), w)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/jgoodhcg/mindmeld/internal/games/cluster"
 	"github.com/jgoodhcg/mindmeld/templates"
 )
 
@@ -34,7 +35,11 @@ func (s *Server) handleTriviaHome(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) handleClusterHome(w http.ResponseWriter, r *http.Request) {
-	templates.ClusterHome().Render(r.Context(), w)
+	themes, err := cluster.ListPromptThemes(r.Context(), s.dbPool)
+	if err != nil {
+		log.Printf("Error listing cluster themes: %v", err)
+	}
+	templates.ClusterHome(themes).Render(r.Context(), w)
 }
 
 func (s *Server) handleJoinByCode(w http.ResponseWriter, r *http.Request) {
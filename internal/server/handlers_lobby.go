@@ -11,9 +11,11 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5"
 	"github.com/jgoodhcg/mindmeld/internal/auth"
+	"github.com/jgoodhcg/mindmeld/internal/contentlocale"
 	"github.com/jgoodhcg/mindmeld/internal/contentrating"
 	"github.com/jgoodhcg/mindmeld/internal/db"
 	"github.com/jgoodhcg/mindmeld/internal/events"
+	"github.com/jgoodhcg/mindmeld/internal/games"
 	"github.com/jgoodhcg/mindmeld/internal/lobbyview"
 	"github.com/jgoodhcg/mindmeld/templates"
 )
@@ -32,6 +34,11 @@ func (s *Server) handleCreateLobby(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid content rating", http.StatusBadRequest)
 		return
 	}
+	locale, err := contentlocale.Parse(r.FormValue("locale"))
+	if err != nil {
+		http.Error(w, "Invalid locale", http.StatusBadRequest)
+		return
+	}
 
 	if lobbyName == "" || nickname == "" {
 		http.Error(w, "Lobby name and nickname are required", http.StatusBadRequest)
@@ -45,12 +52,25 @@ func (s *Server) handleCreateLobby(w http.ResponseWriter, r *http.Request) {
 	player := auth.GetPlayer(r.Context())
 	code := generateCode()
 
+	// The lobby, its host and any game options are created together so a
+	// failure part way through leaves nothing behind.
+	ctx := r.Context()
+	tx, err := s.dbPool.Begin(ctx)
+	if err != nil {
+		log.Printf("Error starting lobby creation: %v", err)
+		http.Error(w, "Failed to create lobby", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+
 	// Create Lobby
-	lobby, err := s.queries.CreateLobby(r.Context(), db.CreateLobbyParams{
+	lobby, err := qtx.CreateLobby(ctx, db.CreateLobbyParams{
 		Code:          code,
 		Name:          lobbyName,
 		GameType:      gameType,
 		ContentRating: contentRating,
+		Locale:        locale,
 	})
 	if err != nil {
 		log.Printf("Error creating lobby: %v", err)
@@ -59,7 +79,7 @@ func (s *Server) handleCreateLobby(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Add Host Player
-	_, err = s.queries.AddPlayerToLobby(r.Context(), db.AddPlayerToLobbyParams{
+	_, err = qtx.AddPlayerToLobby(ctx, db.AddPlayerToLobbyParams{
 		LobbyID:  lobby.ID,
 		PlayerID: player.ID,
 		Nickname: nickname,
@@ -71,8 +91,24 @@ func (s *Server) handleCreateLobby(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if game, ok := s.games.Get(gameType); ok {
+		if configurer, ok := game.(games.LobbyConfigurer); ok {
+			if err := configurer.ConfigureLobby(ctx, tx, lobby, r.Form); err != nil {
+				log.Printf("Error configuring %s lobby %s: %v", gameType, lobby.Code, err)
+				http.Error(w, "Failed to configure lobby", http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Error committing lobby %s: %v", lobby.Code, err)
+		http.Error(w, "Failed to create lobby", http.StatusInternalServerError)
+		return
+	}
+
 	// Publish event for real-time updates (host joining counts as a player join)
-	s.eventBus.Publish(r.Context(), events.Event{
+	s.eventBus.Publish(ctx, events.Event{
 		Type:      events.EventPlayerJoined,
 		LobbyCode: code,
 		Payload: events.PlayerJoinedPayload{
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/a-h/templ"
+	"github.com/coder/websocket"
+	"github.com/go-chi/chi/v5"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	"github.com/jgoodhcg/mindmeld/internal/games"
+	"github.com/jgoodhcg/mindmeld/templates"
+)
+
+// handleLobbyScreen renders the read-only big-screen page for a lobby.
+// Anyone with the code can open it; it shows nothing a player could not see.
+func (s *Server) handleLobbyScreen(w http.ResponseWriter, r *http.Request) {
+	lobby, content, ok := s.renderScreenContent(w, r)
+	if !ok {
+		return
+	}
+	templates.LobbyScreen(lobby, content).Render(r.Context(), w)
+}
+
+// handleGetScreenContent returns the screen content fragment for live refreshes.
+func (s *Server) handleGetScreenContent(w http.ResponseWriter, r *http.Request) {
+	_, content, ok := s.renderScreenContent(w, r)
+	if !ok {
+		return
+	}
+	content.Render(r.Context(), w)
+}
+
+func (s *Server) renderScreenContent(w http.ResponseWriter, r *http.Request) (db.Lobby, templ.Component, bool) {
+	code := chi.URLParam(r, "code")
+
+	lobby, err := s.queries.GetLobbyByCode(r.Context(), code)
+	if err != nil {
+		http.Error(w, "Lobby not found", http.StatusNotFound)
+		return db.Lobby{}, nil, false
+	}
+
+	players, err := s.queries.GetLobbyPlayers(r.Context(), lobby.ID)
+	if err != nil {
+		log.Printf("Error fetching lobby players: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return db.Lobby{}, nil, false
+	}
+
+	game, ok := s.games.Get(lobby.GameType)
+	if !ok {
+		log.Printf("Unknown game type: %s", lobby.GameType)
+		http.Error(w, "Unknown game type", http.StatusInternalServerError)
+		return db.Lobby{}, nil, false
+	}
+
+	var gameScreen templ.Component
+	if renderer, ok := game.(games.ScreenRenderer); ok {
+		gameScreen = renderer.RenderScreen(r.Context(), lobby, players)
+	}
+	return lobby, templates.ScreenContent(lobby, gameScreen), true
+}
+
+// handleScreenWebSocket upgrades a big-screen connection and registers it as
+// a spectator. Spectators need no player identity and do not affect presence.
+func (s *Server) handleScreenWebSocket(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+
+	if _, err := s.queries.GetLobbyByCode(r.Context(), code); err != nil {
+		http.Error(w, "Lobby not found", http.StatusNotFound)
+		return
+	}
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		log.Printf("[ws] Failed to accept spectator connection: %v", err)
+		return
+	}
+
+	s.hub.RegisterSpectator(code, conn)
+	defer func() {
+		s.hub.UnregisterSpectator(code, conn)
+		conn.Close(websocket.StatusNormalClosure, "connection closed")
+	}()
+
+	for {
+		if _, _, err := conn.Read(r.Context()); err != nil {
+			log.Printf("[ws] Spectator connection closed for lobby %s: %v", code, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jgoodhcg/mindmeld/internal/auth"
+	"github.com/jgoodhcg/mindmeld/internal/db"
+	"github.com/jgoodhcg/mindmeld/internal/sharecode"
+	"github.com/jgoodhcg/mindmeld/internal/triviacontent"
+	"github.com/jgoodhcg/mindmeld/templates"
+)
+
+// handleTriviaPackInvite shows a shared player pack so the visitor can save it.
+func (s *Server) handleTriviaPackInvite(w http.ResponseWriter, r *http.Request) {
+	s.renderTriviaPackInvite(w, r, false)
+}
+
+// handleSaveTriviaPack adds a shared player pack to the current player's template sources.
+func (s *Server) handleSaveTriviaPack(w http.ResponseWriter, r *http.Request) {
+	s.renderTriviaPackInvite(w, r, true)
+}
+
+func (s *Server) renderTriviaPackInvite(w http.ResponseWriter, r *http.Request, save bool) {
+	shareCode := sharecode.Normalize(chi.URLParam(r, "shareCode"))
+	pack, err := s.queries.GetPlayerTriviaPackByShareCode(r.Context(), shareCode)
+	if errors.Is(err, pgx.ErrNoRows) {
+		http.Error(w, "Pack not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Error getting trivia pack %s: %v", shareCode, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if save {
+		player := auth.GetPlayer(r.Context())
+		if err := s.queries.SavePlayerTriviaPack(r.Context(), db.SavePlayerTriviaPackParams{
+			PlayerID: player.ID,
+			PackID:   pack.ID,
+		}); err != nil {
+			log.Printf("Error saving trivia pack %s: %v", shareCode, err)
+			http.Error(w, "Failed to save pack", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	count, err := s.queries.CountPlayerTriviaPackQuestions(r.Context(), pack.ID)
+	if err != nil {
+		log.Printf("Error counting trivia pack questions %s: %v", shareCode, err)
+	}
+
+	templates.TriviaPackInvite(pack, count, save).Render(r.Context(), w)
+}
+
+// handleExportTriviaPack downloads a shared player pack as CSV or Open Trivia DB JSON.
+func (s *Server) handleExportTriviaPack(w http.ResponseWriter, r *http.Request) {
+	shareCode := sharecode.Normalize(chi.URLParam(r, "shareCode"))
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format == "" {
+		format = triviacontent.FormatCSV
+	}
+	var contentType, extension string
+	switch format {
+	case triviacontent.FormatCSV:
+		contentType, extension = "text/csv; charset=utf-8", ".csv"
+	case triviacontent.FormatOpenTDB:
+		contentType, extension = "application/json", ".json"
+	default:
+		http.Error(w, "Unsupported format", http.StatusBadRequest)
+		return
+	}
+
+	pack, err := s.queries.GetPlayerTriviaPackByShareCode(r.Context(), shareCode)
+	if errors.Is(err, pgx.ErrNoRows) {
+		http.Error(w, "Pack not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("Error getting trivia pack %s: %v", shareCode, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	rows, err := s.queries.ListPlayerTriviaPackQuestionsByPack(r.Context(), pack.ID)
+	if err != nil {
+		log.Printf("Error listing trivia pack questions %s: %v", shareCode, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	exported := make([]triviacontent.Template, 0, len(rows))
+	for _, row := range rows {
+		exported = append(exported, triviacontent.Template{
+			Slug:          "player-" + row.ID.String(),
+			Category:      row.Category,
+			QuestionText:  row.QuestionText,
+			CorrectAnswer: row.CorrectAnswer,
+			WrongAnswer1:  row.WrongAnswer1,
+			WrongAnswer2:  row.WrongAnswer2,
+			WrongAnswer3:  row.WrongAnswer3,
+			MinRating:     row.MinRating,
+			Difficulty:    row.Difficulty,
+		})
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="trivia-pack-`+pack.ShareCode+extension+`"`)
+	if err := triviacontent.WriteTemplates(format, w, exported); err != nil {
+		log.Printf("Error writing trivia pack export %s: %v", shareCode, err)
+	}
+}
@@ -21,17 +21,26 @@ func (s *Server) routes() {
 	s.router.Get("/", s.handlePlatform)
 	s.router.Get("/trivia", s.handleTriviaHome)
 	s.router.Post("/trivia/join", s.handleJoinByCode)
+	s.router.Get("/trivia/packs/{shareCode}", s.handleTriviaPackInvite)
+	s.router.Post("/trivia/packs/{shareCode}", s.handleSaveTriviaPack)
+	s.router.Get("/trivia/packs/{shareCode}/export", s.handleExportTriviaPack)
 	s.router.Get("/cluster", s.handleClusterHome)
 	s.router.Post("/cluster/join", s.handleJoinByCodeTo("/cluster"))
+	s.router.Get("/cluster/profile", s.handleClusterProfile)
+	s.router.Post("/cluster/profile/share", s.handleShareClusterProfile)
+	s.router.Get("/cluster/profiles/{shareCode}", s.handleSharedClusterProfile)
 	s.router.Post("/lobbies", s.handleCreateLobby)
 	s.router.Get("/lobbies/{code}", s.handleLobbyRoom)
 	s.router.Get("/lobbies/{code}/content", s.handleGetGameContent)
+	s.router.Get("/lobbies/{code}/screen", s.handleLobbyScreen)
+	s.router.Get("/lobbies/{code}/screen/content", s.handleGetScreenContent)
 	s.router.Post("/lobbies/{code}/join", s.handleJoinLobby)
 	s.router.Post("/lobbies/{code}/content-rating", s.handleUpdateLobbyContentRating)
 	s.router.Post("/lobbies/{code}/host-transfer", s.handleTransferHost)
 
 	// WebSocket for real-time updates
 	s.router.Get("/lobbies/{code}/ws", s.handleWebSocket)
+	s.router.Get("/lobbies/{code}/screen/ws", s.handleScreenWebSocket)
 
 	// Dynamic game routes: /lobbies/{code}/{game_slug}/...
 	// These paths are action namespaces, not the canonical lobby URL.
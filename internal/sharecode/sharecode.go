@@ -0,0 +1,58 @@
+// Package sharecode generates the short codes players type or paste to open
+// a shared trivia pack or Cluster profile.
+package sharecode
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// maxAttempts bounds collision retries. Codes are 32 bits, so needing even a
+// second attempt is rare.
+const maxAttempts = 5
+
+// uniqueViolation is the Postgres SQLSTATE for a unique constraint failure.
+const uniqueViolation = "23505"
+
+// New returns a random eight-character uppercase hex code.
+func New() (string, error) {
+	bytes := make([]byte, 4)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("generate share code: %w", err)
+	}
+	return strings.ToUpper(hex.EncodeToString(bytes)), nil
+}
+
+// Normalize uppercases and trims a share code typed by a player.
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Insert calls insert with a fresh code, retrying with a new one while it
+// fails on a unique violation. Callers handle their own conflict targets with
+// ON CONFLICT, so a unique violation here means the code collided. Inside a
+// transaction, insert must run in a savepoint so a failed attempt does not
+// abort the transaction.
+func Insert(insert func(code string) error) error {
+	var err error
+	for range maxAttempts {
+		code, genErr := New()
+		if genErr != nil {
+			return genErr
+		}
+		if err = insert(code); !isUniqueViolation(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("no unused share code after %d attempts: %w", maxAttempts, err)
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,56 @@
+package sharecode
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestNew(t *testing.T) {
+	code, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^[0-9A-F]{8}$`).MatchString(code) {
+		t.Fatalf("unexpected code %q", code)
+	}
+}
+
+func TestInsertRetriesOnUniqueViolation(t *testing.T) {
+	var codes []string
+	err := Insert(func(code string) error {
+		codes = append(codes, code)
+		if len(codes) < 3 {
+			return &pgconn.PgError{Code: uniqueViolation}
+		}
+		return nil
+	})
+	if err != nil || len(codes) != 3 {
+		t.Fatalf("expected success on third attempt, got %v after %d", err, len(codes))
+	}
+}
+
+func TestInsertStopsOnOtherErrors(t *testing.T) {
+	boom := errors.New("boom")
+	attempts := 0
+	err := Insert(func(string) error {
+		attempts++
+		return boom
+	})
+	if !errors.Is(err, boom) || attempts != 1 {
+		t.Fatalf("expected one attempt returning boom, got %v after %d", err, attempts)
+	}
+}
+
+func TestInsertGivesUp(t *testing.T) {
+	attempts := 0
+	err := Insert(func(string) error {
+		attempts++
+		return &pgconn.PgError{Code: uniqueViolation}
+	})
+	if err == nil || attempts != maxAttempts || !isUniqueViolation(err) {
+		t.Fatalf("expected to give up after %d attempts, got %v after %d", maxAttempts, err, attempts)
+	}
+}
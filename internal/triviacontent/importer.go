@@ -0,0 +1,231 @@
+package triviacontent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const idNamespace = "0d6f8a43-3b3e-4c55-9a4f-1c2b7e9d5a61"
+
+type EntityPlan struct {
+	DesiredCount         int
+	ManagedExistingCount int
+	CreateCount          int
+	UpsertCount          int
+	ReactivateCount      int
+	DeactivateCount      int
+}
+
+type ImportPlan struct {
+	Packs     EntityPlan
+	Templates EntityPlan
+}
+
+func PackUUID(slug string) uuid.UUID {
+	return deterministicUUID("pack:" + slug)
+}
+
+func TemplateUUID(slug string) uuid.UUID {
+	return deterministicUUID("template:" + slug)
+}
+
+func Analyze(ctx context.Context, pool *pgxpool.Pool, lib Library) (ImportPlan, error) {
+	packIDs, templateIDs := desiredIDs(lib)
+
+	existingPacks, err := fetchState(ctx, pool, "trivia_template_packs", lib.CreatedByLabel)
+	if err != nil {
+		return ImportPlan{}, err
+	}
+	existingTemplates, err := fetchState(ctx, pool, "trivia_templates", lib.CreatedByLabel)
+	if err != nil {
+		return ImportPlan{}, err
+	}
+
+	return ImportPlan{
+		Packs:     buildEntityPlan(packIDs, existingPacks),
+		Templates: buildEntityPlan(templateIDs, existingTemplates),
+	}, nil
+}
+
+func Import(ctx context.Context, pool *pgxpool.Pool, lib Library) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	packIDs, templateIDs := desiredIDs(lib)
+
+	for i, pack := range lib.Packs {
+		provenance, err := json.Marshal(map[string]string{
+			"source":  "trivia-content-import",
+			"slug":    pack.Slug,
+			"version": lib.Version,
+		})
+		if err != nil {
+			return fmt.Errorf("pack %s provenance: %w", pack.Slug, err)
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO trivia_template_packs (
+				id, slug, name, description, min_rating, display_order,
+				created_by_label, provenance, is_active
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8::jsonb, TRUE)
+			ON CONFLICT (id) DO UPDATE SET
+				slug = EXCLUDED.slug,
+				name = EXCLUDED.name,
+				description = EXCLUDED.description,
+				min_rating = EXCLUDED.min_rating,
+				display_order = EXCLUDED.display_order,
+				created_by_label = EXCLUDED.created_by_label,
+				provenance = EXCLUDED.provenance,
+				is_active = TRUE
+		`, packIDs[i], pack.Slug, pack.Name, pack.Description, pack.MinRating, i, lib.CreatedByLabel, string(provenance)); err != nil {
+			return fmt.Errorf("upsert pack %s: %w", pack.Slug, err)
+		}
+	}
+
+	for i, tpl := range lib.Templates {
+		provenance, err := json.Marshal(map[string]string{
+			"source":  "trivia-content-import",
+			"slug":    tpl.Slug,
+			"version": lib.Version,
+		})
+		if err != nil {
+			return fmt.Errorf("template %s provenance: %w", tpl.Slug, err)
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO trivia_templates (
+				id, slug, pack_id, category, question_text, correct_answer,
+				wrong_answer_1, wrong_answer_2, wrong_answer_3, min_rating, difficulty, display_order,
+				created_by_label, provenance, is_active
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14::jsonb, TRUE)
+			ON CONFLICT (id) DO UPDATE SET
+				slug = EXCLUDED.slug,
+				pack_id = EXCLUDED.pack_id,
+				category = EXCLUDED.category,
+				question_text = EXCLUDED.question_text,
+				correct_answer = EXCLUDED.correct_answer,
+				wrong_answer_1 = EXCLUDED.wrong_answer_1,
+				wrong_answer_2 = EXCLUDED.wrong_answer_2,
+				wrong_answer_3 = EXCLUDED.wrong_answer_3,
+				min_rating = EXCLUDED.min_rating,
+				difficulty = EXCLUDED.difficulty,
+				display_order = EXCLUDED.display_order,
+				created_by_label = EXCLUDED.created_by_label,
+				provenance = EXCLUDED.provenance,
+				is_active = TRUE
+		`, templateIDs[i], tpl.Slug, PackUUID(tpl.PackSlug), tpl.Category, tpl.QuestionText, tpl.CorrectAnswer,
+			tpl.WrongAnswer1, tpl.WrongAnswer2, tpl.WrongAnswer3, tpl.MinRating, tpl.Difficulty, i,
+			lib.CreatedByLabel, string(provenance)); err != nil {
+			return fmt.Errorf("upsert template %s: %w", tpl.Slug, err)
+		}
+	}
+
+	if err := deactivateStale(ctx, tx, "trivia_templates", lib.CreatedByLabel, templateIDs); err != nil {
+		return err
+	}
+	if err := deactivateStale(ctx, tx, "trivia_template_packs", lib.CreatedByLabel, packIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// deactivateStale hides rows owned by label that are no longer in the library.
+// table is always one of the package's own table names, never user input.
+func deactivateStale(ctx context.Context, tx pgx.Tx, table string, label string, keepIDs []uuid.UUID) error {
+	if len(keepIDs) == 0 {
+		_, err := tx.Exec(ctx, `UPDATE `+table+` SET is_active = FALSE WHERE created_by_label = $1`, label)
+		return err
+	}
+	_, err := tx.Exec(ctx, `
+		UPDATE `+table+`
+		SET is_active = FALSE
+		WHERE created_by_label = $1
+		  AND NOT (id = ANY($2::uuid[]))
+	`, label, keepIDs)
+	return err
+}
+
+func desiredIDs(lib Library) ([]uuid.UUID, []uuid.UUID) {
+	packIDs := make([]uuid.UUID, 0, len(lib.Packs))
+	for _, pack := range lib.Packs {
+		packIDs = append(packIDs, PackUUID(pack.Slug))
+	}
+
+	templateIDs := make([]uuid.UUID, 0, len(lib.Templates))
+	for _, tpl := range lib.Templates {
+		templateIDs = append(templateIDs, TemplateUUID(tpl.Slug))
+	}
+
+	return packIDs, templateIDs
+}
+
+func fetchState(ctx context.Context, pool *pgxpool.Pool, table string, label string) (map[uuid.UUID]bool, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT id, is_active
+		FROM `+table+`
+		WHERE created_by_label = $1
+	`, label)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		var isActive bool
+		if err := rows.Scan(&id, &isActive); err != nil {
+			return nil, err
+		}
+		items[id] = isActive
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func buildEntityPlan(desiredIDs []uuid.UUID, existing map[uuid.UUID]bool) EntityPlan {
+	desiredSet := make(map[uuid.UUID]bool, len(desiredIDs))
+	plan := EntityPlan{
+		DesiredCount:         len(desiredIDs),
+		ManagedExistingCount: len(existing),
+	}
+
+	for _, id := range desiredIDs {
+		desiredSet[id] = true
+		if isActive, ok := existing[id]; ok {
+			plan.UpsertCount++
+			if !isActive {
+				plan.ReactivateCount++
+			}
+			continue
+		}
+		plan.CreateCount++
+	}
+
+	for id, isActive := range existing {
+		if desiredSet[id] {
+			continue
+		}
+		if isActive {
+			plan.DeactivateCount++
+		}
+	}
+
+	return plan
+}
+
+func deterministicUUID(value string) uuid.UUID {
+	ns := uuid.MustParse(idNamespace)
+	normalized := strings.TrimSpace(strings.ToLower(value))
+	return uuid.NewSHA1(ns, []byte(normalized))
+}
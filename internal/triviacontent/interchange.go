@@ -0,0 +1,395 @@
+package triviacontent
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Interchange formats for moving template packs in and out of spreadsheets
+// and Open Trivia DB style JSON dumps.
+const (
+	FormatCSV     = "csv"
+	FormatOpenTDB = "opentdb"
+)
+
+// ImportOptions fills fields that external formats do not carry.
+type ImportOptions struct {
+	PackSlug string
+	// Category is used for rows without a category.
+	Category string
+	// MinRating is used for rows without a min_rating.
+	MinRating int16
+}
+
+// FormatFromPath infers the interchange format from a file extension.
+func FormatFromPath(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".json":
+		return FormatOpenTDB, nil
+	default:
+		return "", fmt.Errorf("cannot infer format from %q (want .csv or .json)", path)
+	}
+}
+
+// ParseTemplates reads templates from CSV or Open Trivia DB JSON. Rows without
+// a slug get one derived from the pack slug and question text, so importing the
+// same file twice yields the same slugs.
+func ParseTemplates(format string, r io.Reader, opts ImportOptions) ([]Template, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case FormatCSV:
+		return parseCSVTemplates(r, opts)
+	case FormatOpenTDB:
+		return parseOpenTDBTemplates(r, opts)
+	default:
+		return nil, fmt.Errorf("unsupported format %q (want csv|opentdb)", format)
+	}
+}
+
+// WriteTemplates writes templates in CSV or Open Trivia DB JSON.
+func WriteTemplates(format string, w io.Writer, templates []Template) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case FormatCSV:
+		return writeCSVTemplates(w, templates)
+	case FormatOpenTDB:
+		return writeOpenTDBTemplates(w, templates)
+	default:
+		return fmt.Errorf("unsupported format %q (want csv|opentdb)", format)
+	}
+}
+
+// TemplateSlug derives a stable slug for an imported question.
+func TemplateSlug(packSlug string, questionText string) string {
+	sum := sha1.Sum([]byte(normalizeQuestionText(questionText)))
+	prefix := packSlug
+	if len(prefix) > maxSlugLen-9 {
+		prefix = strings.TrimRight(prefix[:maxSlugLen-9], "-_")
+	}
+	return prefix + "-" + hex.EncodeToString(sum[:4])
+}
+
+var csvColumnAliases = map[string]string{
+	"question":           "question",
+	"question_text":      "question",
+	"correct_answer":     "correct_answer",
+	"incorrect_answer_1": "incorrect_answer_1",
+	"incorrect_answer_2": "incorrect_answer_2",
+	"incorrect_answer_3": "incorrect_answer_3",
+	"wrong_answer_1":     "incorrect_answer_1",
+	"wrong_answer_2":     "incorrect_answer_2",
+	"wrong_answer_3":     "incorrect_answer_3",
+	"incorrect_answers":  "incorrect_answers",
+	"category":           "category",
+	"difficulty":         "difficulty",
+	"min_rating":         "min_rating",
+	"slug":               "slug",
+}
+
+var csvExportHeader = []string{
+	"slug", "category", "difficulty", "min_rating", "question",
+	"correct_answer", "incorrect_answer_1", "incorrect_answer_2", "incorrect_answer_3",
+}
+
+func parseCSVTemplates(r io.Reader, opts ImportOptions) ([]Template, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv: missing header row")
+		}
+		return nil, fmt.Errorf("csv: read header: %w", err)
+	}
+
+	// Unknown columns are ignored so spreadsheets can keep their own notes.
+	colIndex := make(map[string]int, len(header))
+	for i, col := range header {
+		if name, ok := csvColumnAliases[normalizeSourceHeader(col)]; ok {
+			if _, exists := colIndex[name]; exists {
+				return nil, fmt.Errorf("csv: duplicate column %q", name)
+			}
+			colIndex[name] = i
+		}
+	}
+	var missing []string
+	for _, key := range []string{"question", "correct_answer"} {
+		if _, ok := colIndex[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	_, hasSplit := colIndex["incorrect_answer_1"]
+	_, hasList := colIndex["incorrect_answers"]
+	if !hasSplit && !hasList {
+		missing = append(missing, "incorrect_answer_1..3 or incorrect_answers")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("csv: missing required columns: %s", strings.Join(missing, ", "))
+	}
+
+	var (
+		templates []Template
+		errs      []error
+	)
+	for rowNum := 2; ; rowNum++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("csv:%d: read row: %w", rowNum, err))
+			continue
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		fields := make(map[string]string, len(colIndex))
+		for name, i := range colIndex {
+			if i < len(record) {
+				fields[name] = strings.TrimSpace(record[i])
+			}
+		}
+
+		incorrect := []string{fields["incorrect_answer_1"], fields["incorrect_answer_2"], fields["incorrect_answer_3"]}
+		if hasList && !hasSplit {
+			incorrect = splitPipeList(fields["incorrect_answers"])
+		}
+		tpl, rowErr := buildImportedTemplate(importedRow{
+			slug:          fields["slug"],
+			category:      fields["category"],
+			difficulty:    fields["difficulty"],
+			minRating:     fields["min_rating"],
+			question:      fields["question"],
+			correctAnswer: fields["correct_answer"],
+			incorrect:     compactStrings(incorrect),
+		}, opts)
+		if rowErr != nil {
+			errs = append(errs, fmt.Errorf("csv:%d: %w", rowNum, rowErr))
+			continue
+		}
+		templates = append(templates, tpl)
+	}
+
+	if len(errs) > 0 {
+		return templates, errors.Join(errs...)
+	}
+	return templates, nil
+}
+
+type openTDBDocument struct {
+	ResponseCode int               `json:"response_code"`
+	Results      []openTDBQuestion `json:"results"`
+}
+
+type openTDBQuestion struct {
+	Type             string   `json:"type"`
+	Difficulty       string   `json:"difficulty"`
+	Category         string   `json:"category"`
+	Question         string   `json:"question"`
+	CorrectAnswer    string   `json:"correct_answer"`
+	IncorrectAnswers []string `json:"incorrect_answers"`
+}
+
+func parseOpenTDBTemplates(r io.Reader, opts ImportOptions) ([]Template, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Accept both the API response envelope and a bare array of results.
+	var results []openTDBQuestion
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &results); err != nil {
+			return nil, fmt.Errorf("opentdb: %w", err)
+		}
+	} else {
+		var doc openTDBDocument
+		if err := json.Unmarshal(trimmed, &doc); err != nil {
+			return nil, fmt.Errorf("opentdb: %w", err)
+		}
+		if doc.ResponseCode != 0 {
+			return nil, fmt.Errorf("opentdb: response_code %d", doc.ResponseCode)
+		}
+		results = doc.Results
+	}
+
+	var (
+		templates []Template
+		errs      []error
+	)
+	for i, q := range results {
+		if q.Type != "" && q.Type != "multiple" {
+			errs = append(errs, fmt.Errorf("opentdb results[%d]: unsupported type %q (want multiple)", i, q.Type))
+			continue
+		}
+		incorrect := make([]string, 0, len(q.IncorrectAnswers))
+		for _, answer := range q.IncorrectAnswers {
+			incorrect = append(incorrect, html.UnescapeString(answer))
+		}
+		tpl, rowErr := buildImportedTemplate(importedRow{
+			category:      html.UnescapeString(q.Category),
+			difficulty:    q.Difficulty,
+			question:      html.UnescapeString(q.Question),
+			correctAnswer: html.UnescapeString(q.CorrectAnswer),
+			incorrect:     compactStrings(incorrect),
+		}, opts)
+		if rowErr != nil {
+			errs = append(errs, fmt.Errorf("opentdb results[%d]: %w", i, rowErr))
+			continue
+		}
+		templates = append(templates, tpl)
+	}
+
+	if len(errs) > 0 {
+		return templates, errors.Join(errs...)
+	}
+	return templates, nil
+}
+
+type importedRow struct {
+	slug          string
+	category      string
+	difficulty    string
+	minRating     string
+	question      string
+	correctAnswer string
+	incorrect     []string
+}
+
+func buildImportedTemplate(row importedRow, opts ImportOptions) (Template, error) {
+	var errs []error
+	if row.question == "" {
+		errs = append(errs, errors.New("question is required"))
+	}
+	if row.correctAnswer == "" {
+		errs = append(errs, errors.New("correct_answer is required"))
+	}
+	if len(row.incorrect) != 3 {
+		errs = append(errs, fmt.Errorf("needs exactly 3 incorrect answers, got %d", len(row.incorrect)))
+	}
+
+	difficulty, err := ParseDifficulty(row.difficulty)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	minRating := opts.MinRating
+	if row.minRating != "" {
+		parsed, err := ParseMinRating(row.minRating)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		minRating = parsed
+	}
+
+	category := row.category
+	if category == "" {
+		category = opts.Category
+	}
+
+	if len(errs) > 0 {
+		return Template{}, errors.Join(errs...)
+	}
+
+	slug := row.slug
+	if slug == "" {
+		slug = TemplateSlug(opts.PackSlug, row.question)
+	}
+	return Template{
+		Slug:          slug,
+		PackSlug:      opts.PackSlug,
+		Category:      category,
+		QuestionText:  row.question,
+		CorrectAnswer: row.correctAnswer,
+		WrongAnswer1:  row.incorrect[0],
+		WrongAnswer2:  row.incorrect[1],
+		WrongAnswer3:  row.incorrect[2],
+		MinRating:     minRating,
+		Difficulty:    difficulty,
+	}, nil
+}
+
+func writeCSVTemplates(w io.Writer, templates []Template) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvExportHeader); err != nil {
+		return err
+	}
+	for _, tpl := range templates {
+		if err := writer.Write([]string{
+			tpl.Slug,
+			tpl.Category,
+			tpl.Difficulty,
+			fmt.Sprintf("%d", tpl.MinRating),
+			tpl.QuestionText,
+			tpl.CorrectAnswer,
+			tpl.WrongAnswer1,
+			tpl.WrongAnswer2,
+			tpl.WrongAnswer3,
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// openTDBEscaper matches the entities the Open Trivia DB API emits by default.
+var openTDBEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&#039;",
+)
+
+// writeOpenTDBTemplates HTML-escapes text the same way the Open Trivia DB API
+// does by default, so dumps round-trip through ParseTemplates.
+func writeOpenTDBTemplates(w io.Writer, templates []Template) error {
+	doc := openTDBDocument{Results: make([]openTDBQuestion, 0, len(templates))}
+	for _, tpl := range templates {
+		doc.Results = append(doc.Results, openTDBQuestion{
+			Type:          "multiple",
+			Difficulty:    tpl.Difficulty,
+			Category:      openTDBEscaper.Replace(tpl.Category),
+			Question:      openTDBEscaper.Replace(tpl.QuestionText),
+			CorrectAnswer: openTDBEscaper.Replace(tpl.CorrectAnswer),
+			IncorrectAnswers: []string{
+				openTDBEscaper.Replace(tpl.WrongAnswer1),
+				openTDBEscaper.Replace(tpl.WrongAnswer2),
+				openTDBEscaper.Replace(tpl.WrongAnswer3),
+			},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+func splitPipeList(raw string) []string {
+	return strings.Split(raw, "|")
+}
+
+func compactStrings(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		result = append(result, value)
+	}
+	return result
+}
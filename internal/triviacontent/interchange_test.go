@@ -0,0 +1,146 @@
+package triviacontent
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseTemplatesCSV(t *testing.T) {
+	input := "Question,Correct Answer,Incorrect Answer 1,Incorrect Answer 2,Incorrect Answer 3,Category,Difficulty,Notes\n" +
+		"Which HTTP status code means \"Not Found\"?,404,401,403,500,Web,Easy,keep\n" +
+		"What does DNS stand for?,Domain Name System,Data Network Service,Dynamic Name Server,Domain Node Stack,,hard,\n"
+
+	templates, err := ParseTemplates(FormatCSV, strings.NewReader(input), ImportOptions{
+		PackSlug:  "team-bank",
+		Category:  "General",
+		MinRating: 20,
+	})
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(templates) != 2 {
+		t.Fatalf("expected 2 templates, got %d", len(templates))
+	}
+
+	first := templates[0]
+	if first.QuestionText != `Which HTTP status code means "Not Found"?` || first.CorrectAnswer != "404" {
+		t.Fatalf("unexpected first template: %+v", first)
+	}
+	if first.Category != "Web" || first.Difficulty != DifficultyEasy || first.MinRating != 20 || first.PackSlug != "team-bank" {
+		t.Fatalf("unexpected first template metadata: %+v", first)
+	}
+	if templates[1].Category != "General" || templates[1].Difficulty != DifficultyHard {
+		t.Fatalf("expected fallback category and parsed difficulty, got %+v", templates[1])
+	}
+	if first.Slug != TemplateSlug("team-bank", first.QuestionText) || !isSlug(first.Slug) {
+		t.Fatalf("unexpected slug %q", first.Slug)
+	}
+	if err := ValidateTemplates(templates); err != nil {
+		t.Fatalf("validate parsed templates: %v", err)
+	}
+}
+
+func TestParseTemplatesCSVRejectsWrongOptionCount(t *testing.T) {
+	input := "question,correct_answer,incorrect_answers\n" +
+		"Is the sky blue?,Yes,No\n"
+
+	_, err := ParseTemplates(FormatCSV, strings.NewReader(input), ImportOptions{PackSlug: "p", MinRating: 20})
+	if err == nil || !strings.Contains(err.Error(), "exactly 3 incorrect answers") {
+		t.Fatalf("expected option count error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "csv:2") {
+		t.Fatalf("expected row number in error, got %v", err)
+	}
+}
+
+func TestParseTemplatesOpenTDB(t *testing.T) {
+	input := `{"response_code":0,"results":[
+		{"type":"multiple","difficulty":"medium","category":"Science &amp; Nature","question":"What is &quot;H2O&quot;?","correct_answer":"Water","incorrect_answers":["Salt","Sugar","Sand"]},
+		{"type":"boolean","difficulty":"easy","category":"Science","question":"The sun is a star.","correct_answer":"True","incorrect_answers":["False"]}
+	]}`
+
+	templates, err := ParseTemplates(FormatOpenTDB, strings.NewReader(input), ImportOptions{PackSlug: "otdb", MinRating: 10})
+	if err == nil || !strings.Contains(err.Error(), "results[1]") {
+		t.Fatalf("expected boolean question to be rejected, got %v", err)
+	}
+	if len(templates) != 1 {
+		t.Fatalf("expected 1 valid template, got %d", len(templates))
+	}
+	if templates[0].QuestionText != `What is "H2O"?` || templates[0].Category != "Science & Nature" {
+		t.Fatalf("expected HTML entities decoded, got %+v", templates[0])
+	}
+	if templates[0].Difficulty != DifficultyMedium || templates[0].MinRating != 10 {
+		t.Fatalf("unexpected metadata: %+v", templates[0])
+	}
+}
+
+func TestValidateTemplatesRejectsDuplicateQuestions(t *testing.T) {
+	tpl := Template{
+		Slug:          "a",
+		Category:      "General",
+		QuestionText:  "What is 2 + 2?",
+		CorrectAnswer: "4",
+		WrongAnswer1:  "3",
+		WrongAnswer2:  "5",
+		WrongAnswer3:  "22",
+		MinRating:     10,
+	}
+	dup := tpl
+	dup.Slug = "b"
+	dup.QuestionText = "  what is 2 +   2? "
+
+	err := ValidateTemplates([]Template{tpl, dup})
+	if err == nil || !strings.Contains(err.Error(), "duplicates question text") {
+		t.Fatalf("expected duplicate question error, got %v", err)
+	}
+}
+
+func TestWriteTemplatesRoundTrip(t *testing.T) {
+	templates := []Template{{
+		Slug:          "bank-q1",
+		PackSlug:      "bank",
+		Category:      "Tech & Web",
+		QuestionText:  `Which tag makes text <b>bold</b>?`,
+		CorrectAnswer: "<b>",
+		WrongAnswer1:  "<i>",
+		WrongAnswer2:  "<u>",
+		WrongAnswer3:  "<p>",
+		MinRating:     20,
+		Difficulty:    DifficultyEasy,
+	}}
+
+	for _, format := range []string{FormatCSV, FormatOpenTDB} {
+		var buf bytes.Buffer
+		if err := WriteTemplates(format, &buf, templates); err != nil {
+			t.Fatalf("%s: write: %v", format, err)
+		}
+		got, err := ParseTemplates(format, &buf, ImportOptions{PackSlug: "bank", MinRating: 20})
+		if err != nil {
+			t.Fatalf("%s: parse: %v", format, err)
+		}
+		if len(got) != 1 {
+			t.Fatalf("%s: expected 1 template, got %d", format, len(got))
+		}
+		want := templates[0]
+		if format == FormatOpenTDB {
+			// Open Trivia DB has no slug field, so the derived slug is used.
+			want.Slug = TemplateSlug("bank", want.QuestionText)
+		}
+		if got[0] != want {
+			t.Fatalf("%s: round trip mismatch:\n got %+v\nwant %+v", format, got[0], want)
+		}
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	if format, err := FormatFromPath("bank.CSV"); err != nil || format != FormatCSV {
+		t.Fatalf("expected csv, got %q (%v)", format, err)
+	}
+	if format, err := FormatFromPath("dump.json"); err != nil || format != FormatOpenTDB {
+		t.Fatalf("expected opentdb, got %q (%v)", format, err)
+	}
+	if _, err := FormatFromPath("bank.xlsx"); err == nil {
+		t.Fatal("expected error for unknown extension")
+	}
+}
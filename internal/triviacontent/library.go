@@ -0,0 +1,267 @@
+package triviacontent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+// maxSlugLen matches used_question_templates.template_id, which stores template slugs.
+const maxSlugLen = 50
+
+// maxAnswerLen matches the VARCHAR(200) answer columns on template and question tables.
+const maxAnswerLen = 200
+
+type Library struct {
+	Version        string     `json:"version"`
+	CreatedByLabel string     `json:"created_by_label"`
+	Packs          []Pack     `json:"packs"`
+	Templates      []Template `json:"templates"`
+}
+
+type Pack struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MinRating   int16  `json:"min_rating"`
+}
+
+type Template struct {
+	Slug          string `json:"slug"`
+	PackSlug      string `json:"pack_slug"`
+	Category      string `json:"category"`
+	QuestionText  string `json:"question_text"`
+	CorrectAnswer string `json:"correct_answer"`
+	WrongAnswer1  string `json:"wrong_answer_1"`
+	WrongAnswer2  string `json:"wrong_answer_2"`
+	WrongAnswer3  string `json:"wrong_answer_3"`
+	MinRating     int16  `json:"min_rating"`
+	Difficulty    string `json:"difficulty,omitempty"`
+}
+
+// Difficulty values accepted on templates; empty means unrated.
+const (
+	DifficultyEasy   = "easy"
+	DifficultyMedium = "medium"
+	DifficultyHard   = "hard"
+)
+
+type Report struct {
+	PackCount               int
+	TemplateCount           int
+	TemplateCountByRating   map[int16]int
+	TemplateCountByCategory map[string]int
+}
+
+func Load(path string) (Library, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Library{}, err
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.DisallowUnknownFields()
+
+	var lib Library
+	if err := dec.Decode(&lib); err != nil {
+		return Library{}, err
+	}
+
+	return lib, nil
+}
+
+func SaveLibrary(path string, lib Library) error {
+	out, err := json.MarshalIndent(lib, "", "  ")
+	if err != nil {
+		return err
+	}
+	out = append(out, '\n')
+	return os.WriteFile(path, out, 0o644)
+}
+
+func Validate(lib Library) (Report, error) {
+	var errs []error
+	report := Report{
+		TemplateCountByRating: map[int16]int{
+			contentrating.Kids:   0,
+			contentrating.Work:   0,
+			contentrating.Adults: 0,
+		},
+		TemplateCountByCategory: make(map[string]int),
+	}
+
+	if strings.TrimSpace(lib.Version) == "" {
+		errs = append(errs, errors.New("version is required"))
+	}
+	if strings.TrimSpace(lib.CreatedByLabel) == "" {
+		errs = append(errs, errors.New("created_by_label is required"))
+	}
+	if len(lib.Packs) == 0 {
+		errs = append(errs, errors.New("at least one pack is required"))
+	}
+	if len(lib.Templates) == 0 {
+		errs = append(errs, errors.New("at least one template is required"))
+	}
+
+	packBySlug := make(map[string]Pack, len(lib.Packs))
+	for i, pack := range lib.Packs {
+		prefix := fmt.Sprintf("packs[%d]", i)
+		if !isSlug(pack.Slug) {
+			errs = append(errs, fmt.Errorf("%s has invalid slug: %q", prefix, pack.Slug))
+		}
+		if _, exists := packBySlug[pack.Slug]; exists {
+			errs = append(errs, fmt.Errorf("duplicate pack slug %q", pack.Slug))
+		}
+		if strings.TrimSpace(pack.Name) == "" {
+			errs = append(errs, fmt.Errorf("%s has empty name", prefix))
+		}
+		if !contentrating.IsValid(pack.MinRating) {
+			errs = append(errs, fmt.Errorf("%s has invalid min_rating %d", prefix, pack.MinRating))
+		}
+		packBySlug[pack.Slug] = pack
+	}
+
+	templateSeen := make(map[string]bool, len(lib.Templates))
+	questionSeen := make(map[string]bool, len(lib.Templates))
+	packTemplateCount := make(map[string]int, len(lib.Packs))
+	for i, tpl := range lib.Templates {
+		prefix := fmt.Sprintf("templates[%d]", i)
+		if !isSlug(tpl.Slug) {
+			errs = append(errs, fmt.Errorf("%s has invalid slug: %q", prefix, tpl.Slug))
+		}
+		if templateSeen[tpl.Slug] {
+			errs = append(errs, fmt.Errorf("duplicate template slug %q", tpl.Slug))
+		}
+		templateSeen[tpl.Slug] = true
+
+		questionKey := normalizeQuestionText(tpl.QuestionText)
+		if questionKey != "" && questionSeen[questionKey] {
+			errs = append(errs, fmt.Errorf("%s duplicates question text %q", prefix, tpl.QuestionText))
+		}
+		questionSeen[questionKey] = true
+
+		errs = append(errs, validateTemplateFields(prefix, tpl)...)
+
+		pack, ok := packBySlug[tpl.PackSlug]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s references missing pack slug %q", prefix, tpl.PackSlug))
+			continue
+		}
+		packTemplateCount[pack.Slug]++
+
+		effectiveRating := max(tpl.MinRating, pack.MinRating)
+		for _, rating := range []int16{contentrating.Kids, contentrating.Work, contentrating.Adults} {
+			if effectiveRating <= rating {
+				report.TemplateCountByRating[rating]++
+			}
+		}
+		report.TemplateCountByCategory[strings.TrimSpace(tpl.Category)]++
+	}
+
+	for _, pack := range lib.Packs {
+		if packTemplateCount[pack.Slug] == 0 {
+			errs = append(errs, fmt.Errorf("pack %q has no templates", pack.Slug))
+		}
+	}
+
+	report.PackCount = len(packBySlug)
+	report.TemplateCount = len(templateSeen)
+
+	if len(errs) > 0 {
+		return report, errors.Join(errs...)
+	}
+	return report, nil
+}
+
+// ValidateTemplates checks template content without a surrounding library,
+// for uploads that are not tied to curated packs.
+func ValidateTemplates(templates []Template) error {
+	var errs []error
+	if len(templates) == 0 {
+		errs = append(errs, errors.New("at least one question is required"))
+	}
+	questionSeen := make(map[string]bool, len(templates))
+	for i, tpl := range templates {
+		prefix := fmt.Sprintf("questions[%d]", i)
+		questionKey := normalizeQuestionText(tpl.QuestionText)
+		if questionKey != "" && questionSeen[questionKey] {
+			errs = append(errs, fmt.Errorf("%s duplicates question text %q", prefix, tpl.QuestionText))
+		}
+		questionSeen[questionKey] = true
+		errs = append(errs, validateTemplateFields(prefix, tpl)...)
+	}
+	return errors.Join(errs...)
+}
+
+func validateTemplateFields(prefix string, tpl Template) []error {
+	var errs []error
+	if strings.TrimSpace(tpl.Category) == "" {
+		errs = append(errs, fmt.Errorf("%s has empty category", prefix))
+	}
+	if strings.TrimSpace(tpl.QuestionText) == "" {
+		errs = append(errs, fmt.Errorf("%s has empty question_text", prefix))
+	}
+	if err := validateAnswers(tpl); err != nil {
+		errs = append(errs, fmt.Errorf("%s %w", prefix, err))
+	}
+	if !contentrating.IsValid(tpl.MinRating) {
+		errs = append(errs, fmt.Errorf("%s has invalid min_rating %d", prefix, tpl.MinRating))
+	}
+	if difficulty, err := ParseDifficulty(tpl.Difficulty); err != nil || difficulty != tpl.Difficulty {
+		errs = append(errs, fmt.Errorf("%s has invalid difficulty %q", prefix, tpl.Difficulty))
+	}
+	return errs
+}
+
+// ParseDifficulty normalizes a difficulty label; empty input stays empty.
+func ParseDifficulty(raw string) (string, error) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	switch value {
+	case "", DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid difficulty %q (want easy|medium|hard)", raw)
+	}
+}
+
+func normalizeQuestionText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+func validateAnswers(tpl Template) error {
+	answers := []string{tpl.CorrectAnswer, tpl.WrongAnswer1, tpl.WrongAnswer2, tpl.WrongAnswer3}
+	seen := make(map[string]bool, len(answers))
+	for _, answer := range answers {
+		trimmed := strings.TrimSpace(answer)
+		if trimmed == "" {
+			return errors.New("has empty answers")
+		}
+		if len([]rune(trimmed)) > maxAnswerLen {
+			return fmt.Errorf("has answer longer than %d characters", maxAnswerLen)
+		}
+		key := strings.ToLower(trimmed)
+		if seen[key] {
+			return fmt.Errorf("has duplicate answer %q", trimmed)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func isSlug(value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" || len(value) > maxSlugLen {
+		return false
+	}
+	for _, r := range value {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			continue
+		}
+		return false
+	}
+	return true
+}
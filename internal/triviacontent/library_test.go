@@ -0,0 +1,90 @@
+package triviacontent
+
+import (
+	"strings"
+	"testing"
+)
+
+func testLibrary() Library {
+	return Library{
+		Version:        "v1",
+		CreatedByLabel: "trivia-library-test",
+		Packs: []Pack{
+			{Slug: "quick-brain-boost", Name: "Quick Brain Boost", MinRating: 10},
+			{Slug: "work-essentials", Name: "Work Essentials", MinRating: 20},
+		},
+		Templates: []Template{
+			{
+				Slug:          "quick-001",
+				PackSlug:      "quick-brain-boost",
+				Category:      "Science & Math",
+				QuestionText:  "What is 7 x 8?",
+				CorrectAnswer: "56",
+				WrongAnswer1:  "54",
+				WrongAnswer2:  "58",
+				WrongAnswer3:  "64",
+				MinRating:     10,
+			},
+			{
+				Slug:          "work-001",
+				PackSlug:      "work-essentials",
+				Category:      "Meetings & Process",
+				QuestionText:  "What does the A in RACI stand for?",
+				CorrectAnswer: "Accountable",
+				WrongAnswer1:  "Approved",
+				WrongAnswer2:  "Assigned",
+				WrongAnswer3:  "Available",
+				MinRating:     10,
+			},
+		},
+	}
+}
+
+func TestValidateSuccess(t *testing.T) {
+	report, err := Validate(testLibrary())
+	if err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if report.PackCount != 2 || report.TemplateCount != 2 {
+		t.Fatalf("unexpected counts: %+v", report)
+	}
+	// work-001 is rated 10 but its pack is 20, so kids lobbies only see quick-001.
+	if report.TemplateCountByRating[10] != 1 || report.TemplateCountByRating[20] != 2 {
+		t.Fatalf("unexpected rating counts: %+v", report.TemplateCountByRating)
+	}
+}
+
+func TestValidateFailsOnUnknownPackAndDuplicateAnswers(t *testing.T) {
+	lib := testLibrary()
+	lib.Templates[0].PackSlug = "missing-pack"
+	lib.Templates[1].WrongAnswer3 = "accountable"
+
+	_, err := Validate(lib)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if !strings.Contains(err.Error(), "missing pack slug") {
+		t.Fatalf("expected missing pack error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "duplicate answer") {
+		t.Fatalf("expected duplicate answer error, got %v", err)
+	}
+}
+
+func TestValidateRejectsSlugsLongerThanTemplateIDColumn(t *testing.T) {
+	lib := testLibrary()
+	lib.Templates[0].Slug = strings.Repeat("a", maxSlugLen+1)
+
+	if _, err := Validate(lib); err == nil {
+		t.Fatal("expected overlong slug to fail")
+	}
+}
+
+func TestTemplateUUIDDeterministic(t *testing.T) {
+	if TemplateUUID("quick-001") != TemplateUUID("quick-001") {
+		t.Fatal("expected deterministic template UUIDs to match")
+	}
+	if TemplateUUID("quick-001") == PackUUID("quick-001") {
+		t.Fatal("expected pack and template UUIDs to use separate name spaces")
+	}
+}
@@ -0,0 +1,29 @@
+package triviacontent
+
+import (
+	"strings"
+
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+// RatingText is the player-visible text of a template, for the content
+// rating checks.
+func (tpl Template) RatingText() string {
+	return strings.Join([]string{tpl.QuestionText, tpl.CorrectAnswer, tpl.WrongAnswer1, tpl.WrongAnswer2, tpl.WrongAnswer3}, " ")
+}
+
+// RatingItems lists the packs and templates in lib for the content rating
+// checks. Templates are checked at their effective rating, the stricter of
+// their own and their pack's, since that is what lobbies filter on.
+func RatingItems(lib Library) []contentrating.Item {
+	packRating := make(map[string]int16, len(lib.Packs))
+	items := make([]contentrating.Item, 0, len(lib.Packs)+len(lib.Templates))
+	for _, pack := range lib.Packs {
+		packRating[pack.Slug] = pack.MinRating
+		items = append(items, contentrating.Item{ID: "pack " + pack.Slug, Rating: pack.MinRating, Text: pack.Name + " " + pack.Description})
+	}
+	for _, tpl := range lib.Templates {
+		items = append(items, contentrating.Item{ID: tpl.Slug, Rating: max(tpl.MinRating, packRating[tpl.PackSlug]), Text: tpl.RatingText()})
+	}
+	return items
+}
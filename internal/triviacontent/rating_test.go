@@ -0,0 +1,40 @@
+package triviacontent
+
+import (
+	"testing"
+
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+func TestRatingItemsUseEffectiveRating(t *testing.T) {
+	lib := Library{
+		Packs: []Pack{{Slug: "after-hours", Name: "After Hours", Description: "Cocktail trivia", MinRating: 20}},
+		Templates: []Template{{
+			Slug:          "after-hours-gin",
+			PackSlug:      "after-hours",
+			QuestionText:  "Which spirit goes in a martini?",
+			CorrectAnswer: "Gin",
+			WrongAnswer1:  "Whiskey",
+			WrongAnswer2:  "Rum",
+			WrongAnswer3:  "Sake",
+			MinRating:     10,
+		}},
+	}
+
+	items := RatingItems(lib)
+	if len(items) != 2 {
+		t.Fatalf("expected pack and template items, got %+v", items)
+	}
+	if items[0].ID != "pack after-hours" || items[1].Rating != 20 {
+		t.Fatalf("unexpected items %+v", items)
+	}
+	if findings := contentrating.Check(items); len(findings) != 0 {
+		t.Fatalf("expected no findings at the pack's rating, got %v", findings)
+	}
+
+	lib.Packs[0].MinRating = 10
+	findings := contentrating.Check(RatingItems(lib))
+	if len(findings) != 2 || findings[1].ID != "after-hours-gin" || findings[1].Suggested != 20 {
+		t.Fatalf("unexpected findings %v", findings)
+	}
+}
@@ -0,0 +1,38 @@
+package triviacontent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultLibraryFile is the curated library the server seeds an empty
+// catalog from.
+const DefaultLibraryFile = "content/trivia/library.v1.json"
+
+// SeedIfEmpty imports the library at path when the database has no active
+// templates, so a fresh deploy never serves an empty catalog. It reports
+// whether it imported anything. A catalog that already has templates is left
+// alone; later content changes still go through cmd/trivia-content import.
+func SeedIfEmpty(ctx context.Context, pool *pgxpool.Pool, path string) (bool, error) {
+	var hasTemplates bool
+	if err := pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM trivia_templates WHERE is_active)`).Scan(&hasTemplates); err != nil {
+		return false, err
+	}
+	if hasTemplates {
+		return false, nil
+	}
+
+	lib, err := Load(path)
+	if err != nil {
+		return false, fmt.Errorf("load %s: %w", path, err)
+	}
+	if _, err := Validate(lib); err != nil {
+		return false, fmt.Errorf("validate %s: %w", path, err)
+	}
+	if err := Import(ctx, pool, lib); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,520 @@
+package triviacontent
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jgoodhcg/mindmeld/internal/contentrating"
+)
+
+type SourceMeta struct {
+	Version        string `json:"version"`
+	CreatedByLabel string `json:"created_by_label"`
+}
+
+type SourceDirLoadReport struct {
+	PackRows      int
+	TemplateRows  int
+	TemplateReady int
+	TemplateDraft int
+}
+
+// LoadSourceDir reads meta.json, packs.tsv, and templates.tsv from dir and
+// returns the library built from ready template rows.
+func LoadSourceDir(dir string) (Library, SourceDirLoadReport, error) {
+	meta, err := loadSourceMeta(filepath.Join(dir, "meta.json"))
+	if err != nil {
+		return Library{}, SourceDirLoadReport{}, err
+	}
+
+	var report SourceDirLoadReport
+
+	packRecords, err := readSourceTable(filepath.Join(dir, "packs.tsv"), packColumns)
+	if err != nil {
+		return Library{}, SourceDirLoadReport{}, err
+	}
+	templateRecords, err := readSourceTable(filepath.Join(dir, "templates.tsv"), templateColumns)
+	if err != nil {
+		return Library{}, SourceDirLoadReport{}, err
+	}
+
+	lib := Library{
+		Version:        meta.Version,
+		CreatedByLabel: meta.CreatedByLabel,
+		Packs:          make([]Pack, 0, len(packRecords)),
+		Templates:      make([]Template, 0, len(templateRecords)),
+	}
+
+	var errs []error
+	for _, rec := range packRecords {
+		report.PackRows++
+		pack, rowErr := parsePackRow(rec)
+		if rowErr != nil {
+			errs = append(errs, rowErr)
+			continue
+		}
+		lib.Packs = append(lib.Packs, pack)
+	}
+
+	for _, rec := range templateRecords {
+		report.TemplateRows++
+		tpl, status, rowErr := parseTemplateRow(rec)
+		if rowErr != nil {
+			errs = append(errs, rowErr)
+			continue
+		}
+		switch status {
+		case "draft":
+			report.TemplateDraft++
+			continue
+		case "ready":
+			report.TemplateReady++
+		}
+		lib.Templates = append(lib.Templates, tpl)
+	}
+
+	if len(errs) > 0 {
+		return lib, report, errors.Join(errs...)
+	}
+	return lib, report, nil
+}
+
+func loadSourceMeta(path string) (SourceMeta, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return SourceMeta{}, err
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.DisallowUnknownFields()
+
+	var meta SourceMeta
+	if err := dec.Decode(&meta); err != nil {
+		return SourceMeta{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if strings.TrimSpace(meta.Version) == "" {
+		return SourceMeta{}, fmt.Errorf("%s: version is required", path)
+	}
+	if strings.TrimSpace(meta.CreatedByLabel) == "" {
+		return SourceMeta{}, fmt.Errorf("%s: created_by_label is required", path)
+	}
+	return meta, nil
+}
+
+type sourceColumns struct {
+	allowed  []string
+	required []string
+}
+
+var packColumns = sourceColumns{
+	allowed:  []string{"slug", "name", "description", "min_rating"},
+	required: []string{"slug", "name", "min_rating"},
+}
+
+var templateColumns = sourceColumns{
+	allowed: []string{
+		"slug", "pack", "category", "question_text",
+		"correct_answer", "wrong_answer_1", "wrong_answer_2", "wrong_answer_3",
+		"min_rating", "difficulty", "status", "notes",
+	},
+	required: []string{
+		"slug", "pack", "category", "question_text",
+		"correct_answer", "wrong_answer_1", "wrong_answer_2", "wrong_answer_3",
+		"min_rating",
+	},
+}
+
+// sourceRecord is one non-blank data row keyed by normalized column name.
+type sourceRecord struct {
+	path   string
+	line   int
+	fields map[string]string
+}
+
+func (r sourceRecord) get(name string) string {
+	return strings.TrimSpace(r.fields[name])
+}
+
+func (r sourceRecord) errorf(format string, args ...any) error {
+	return fmt.Errorf("%s:%d: %s", r.path, r.line, fmt.Sprintf(format, args...))
+}
+
+func readSourceTable(path string, columns sourceColumns) ([]sourceRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	// Question text routinely contains quoted phrases like "Not Found".
+	reader.LazyQuotes = true
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tsv":
+		reader.Comma = '\t'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: missing header row", path)
+		}
+		return nil, fmt.Errorf("%s: read header: %w", path, err)
+	}
+
+	colIndex, err := parseSourceHeader(path, header, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		records []sourceRecord
+		errs    []error
+	)
+	for rowNum := 2; ; rowNum++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s:%d: read row: %w", path, rowNum, err))
+			continue
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		fields := make(map[string]string, len(colIndex))
+		for name, i := range colIndex {
+			if i < len(record) {
+				fields[name] = record[i]
+			}
+		}
+		records = append(records, sourceRecord{path: path, line: rowNum, fields: fields})
+	}
+
+	if len(errs) > 0 {
+		return records, errors.Join(errs...)
+	}
+	return records, nil
+}
+
+func parseSourceHeader(path string, rawHeader []string, columns sourceColumns) (map[string]int, error) {
+	allowed := make(map[string]bool, len(columns.allowed))
+	for _, name := range columns.allowed {
+		allowed[name] = true
+	}
+
+	colIndex := make(map[string]int, len(rawHeader))
+	var errs []error
+	for i, col := range rawHeader {
+		name := normalizeSourceHeader(col)
+		if name == "" {
+			continue
+		}
+		if !allowed[name] {
+			errs = append(errs, fmt.Errorf("%s: unknown column %q", path, strings.TrimSpace(col)))
+			continue
+		}
+		if _, exists := colIndex[name]; exists {
+			errs = append(errs, fmt.Errorf("%s: duplicate column %q", path, name))
+			continue
+		}
+		colIndex[name] = i
+	}
+	for _, key := range columns.required {
+		if _, ok := colIndex[key]; !ok {
+			errs = append(errs, fmt.Errorf("%s: missing required column %q", path, key))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return colIndex, nil
+}
+
+func parsePackRow(rec sourceRecord) (Pack, error) {
+	var errs []error
+	pack := Pack{
+		Slug:        rec.get("slug"),
+		Name:        rec.get("name"),
+		Description: rec.get("description"),
+	}
+	if pack.Slug == "" {
+		errs = append(errs, rec.errorf("slug is required"))
+	}
+	if pack.Name == "" {
+		errs = append(errs, rec.errorf("name is required"))
+	}
+
+	minRating, err := ParseMinRating(rec.get("min_rating"))
+	if err != nil {
+		errs = append(errs, rec.errorf("%v", err))
+	} else {
+		pack.MinRating = minRating
+	}
+
+	if len(errs) > 0 {
+		return pack, errors.Join(errs...)
+	}
+	return pack, nil
+}
+
+func parseTemplateRow(rec sourceRecord) (Template, string, error) {
+	var errs []error
+	tpl := Template{
+		Slug:          rec.get("slug"),
+		PackSlug:      rec.get("pack"),
+		Category:      rec.get("category"),
+		QuestionText:  rec.get("question_text"),
+		CorrectAnswer: rec.get("correct_answer"),
+		WrongAnswer1:  rec.get("wrong_answer_1"),
+		WrongAnswer2:  rec.get("wrong_answer_2"),
+		WrongAnswer3:  rec.get("wrong_answer_3"),
+	}
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"slug", tpl.Slug},
+		{"pack", tpl.PackSlug},
+		{"category", tpl.Category},
+		{"question_text", tpl.QuestionText},
+	} {
+		if field.value == "" {
+			errs = append(errs, rec.errorf("%s is required", field.name))
+		}
+	}
+
+	minRating, err := ParseMinRating(rec.get("min_rating"))
+	if err != nil {
+		errs = append(errs, rec.errorf("%v", err))
+	} else {
+		tpl.MinRating = minRating
+	}
+
+	difficulty, err := ParseDifficulty(rec.get("difficulty"))
+	if err != nil {
+		errs = append(errs, rec.errorf("%v", err))
+	} else {
+		tpl.Difficulty = difficulty
+	}
+
+	status := strings.ToLower(rec.get("status"))
+	if status == "" {
+		status = "ready"
+	}
+	switch status {
+	case "draft", "ready":
+	default:
+		errs = append(errs, rec.errorf("unsupported status %q (want draft|ready)", status))
+	}
+
+	if len(errs) > 0 {
+		return tpl, status, errors.Join(errs...)
+	}
+	return tpl, status, nil
+}
+
+func ParseMinRating(raw string) (int16, error) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	switch value {
+	case "mild", "kids", "kid":
+		return contentrating.Kids, nil
+	case "polite", "work":
+		return contentrating.Work, nil
+	case "adult", "adults":
+		return contentrating.Adults, nil
+	}
+
+	id, err := contentrating.ParseID(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid min_rating %q", raw)
+	}
+	return id, nil
+}
+
+func normalizeSourceHeader(value string) string {
+	value = strings.TrimSpace(strings.TrimPrefix(value, "\ufeff"))
+	value = strings.ToLower(value)
+	value = strings.ReplaceAll(value, " ", "_")
+	return value
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// SourceAppendOptions controls how AppendSourceDir writes imported rows.
+type SourceAppendOptions struct {
+	// Notes is written to the notes column of each appended template row.
+	Notes  string
+	DryRun bool
+}
+
+// SourceAppendReport summarizes an AppendSourceDir run.
+type SourceAppendReport struct {
+	PackCreated     bool
+	Appended        int
+	SkippedExisting int
+}
+
+// AppendSourceDir adds pack (when it is new) and templates to the source TSVs
+// in dir. Templates whose slug already has a row in templates.tsv, draft or
+// ready, are skipped so re-running an import is a no-op. The merged library is
+// validated before anything is written.
+func AppendSourceDir(dir string, pack Pack, templates []Template, opts SourceAppendOptions) (Report, SourceAppendReport, error) {
+	lib, _, err := LoadSourceDir(dir)
+	if err != nil {
+		return Report{}, SourceAppendReport{}, err
+	}
+
+	packsPath := filepath.Join(dir, "packs.tsv")
+	templatesPath := filepath.Join(dir, "templates.tsv")
+	existingRecords, err := readSourceTable(templatesPath, templateColumns)
+	if err != nil {
+		return Report{}, SourceAppendReport{}, err
+	}
+	existingSlugs := make(map[string]bool, len(existingRecords))
+	for _, rec := range existingRecords {
+		existingSlugs[rec.get("slug")] = true
+	}
+
+	var appendReport SourceAppendReport
+	packExists := false
+	for _, existing := range lib.Packs {
+		if existing.Slug == pack.Slug {
+			packExists = true
+			break
+		}
+	}
+	if !packExists {
+		lib.Packs = append(lib.Packs, pack)
+		appendReport.PackCreated = true
+	}
+
+	fresh := make([]Template, 0, len(templates))
+	for _, tpl := range templates {
+		if existingSlugs[tpl.Slug] {
+			appendReport.SkippedExisting++
+			continue
+		}
+		fresh = append(fresh, tpl)
+	}
+	lib.Templates = append(lib.Templates, fresh...)
+	appendReport.Appended = len(fresh)
+
+	report, err := Validate(lib)
+	if err != nil {
+		return report, appendReport, err
+	}
+	if opts.DryRun {
+		return report, appendReport, nil
+	}
+
+	if appendReport.PackCreated {
+		if err := appendSourceRows(packsPath, []map[string]string{{
+			"slug":        pack.Slug,
+			"name":        pack.Name,
+			"description": pack.Description,
+			"min_rating":  strconv.Itoa(int(pack.MinRating)),
+		}}); err != nil {
+			return report, appendReport, err
+		}
+	}
+
+	rows := make([]map[string]string, 0, len(fresh))
+	for _, tpl := range fresh {
+		rows = append(rows, map[string]string{
+			"slug":           tpl.Slug,
+			"pack":           tpl.PackSlug,
+			"category":       tpl.Category,
+			"question_text":  tpl.QuestionText,
+			"correct_answer": tpl.CorrectAnswer,
+			"wrong_answer_1": tpl.WrongAnswer1,
+			"wrong_answer_2": tpl.WrongAnswer2,
+			"wrong_answer_3": tpl.WrongAnswer3,
+			"min_rating":     strconv.Itoa(int(tpl.MinRating)),
+			"difficulty":     tpl.Difficulty,
+			"status":         "ready",
+			"notes":          opts.Notes,
+		})
+	}
+	if err := appendSourceRows(templatesPath, rows); err != nil {
+		return report, appendReport, err
+	}
+	return report, appendReport, nil
+}
+
+// appendSourceRows appends rows to a TSV using the column order of its
+// existing header. A non-empty value for a column the header lacks is an error
+// rather than being dropped silently.
+func appendSourceRows(path string, rows []map[string]string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	headerLine, _, _ := strings.Cut(string(raw), "\n")
+	header := strings.Split(strings.TrimRight(headerLine, "\r"), "\t")
+
+	known := make(map[string]bool, len(header))
+	for _, col := range header {
+		known[normalizeSourceHeader(col)] = true
+	}
+
+	var out strings.Builder
+	if len(raw) > 0 && raw[len(raw)-1] != '\n' {
+		out.WriteByte('\n')
+	}
+	for _, row := range rows {
+		for name, value := range row {
+			if value != "" && !known[name] {
+				return fmt.Errorf("%s: missing column %q needed for imported rows", path, name)
+			}
+		}
+		fields := make([]string, len(header))
+		for i, col := range header {
+			fields[i] = sourceField(row[normalizeSourceHeader(col)])
+		}
+		out.WriteString(strings.Join(fields, "\t"))
+		out.WriteByte('\n')
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(out.String()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// sourceField flattens a value onto one TSV cell. Quotes are left bare like
+// hand-written rows, except a leading quote, which the reader would otherwise
+// treat as the start of a quoted field.
+func sourceField(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if strings.HasPrefix(value, `"`) {
+		return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+	}
+	return value
+}
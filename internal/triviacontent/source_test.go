@@ -0,0 +1,125 @@
+package triviacontent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSourceDir(t *testing.T) {
+	dir := t.TempDir()
+
+	meta := `{"version":"v1","created_by_label":"trivia-library-v1"}`
+	if err := os.WriteFile(filepath.Join(dir, "meta.json"), []byte(meta), 0o644); err != nil {
+		t.Fatalf("write meta: %v", err)
+	}
+
+	packs := "slug\tname\tdescription\tmin_rating\n" +
+		"pack-a\tPack A\tFirst pack\tmild\n"
+	if err := os.WriteFile(filepath.Join(dir, "packs.tsv"), []byte(packs), 0o644); err != nil {
+		t.Fatalf("write packs: %v", err)
+	}
+
+	templates := "slug\tpack\tcategory\tquestion_text\tcorrect_answer\twrong_answer_1\twrong_answer_2\twrong_answer_3\tmin_rating\tstatus\tnotes\n" +
+		"tpl-ready\tpack-a\tWeb\tWhich HTTP status code means \"Not Found\"?\t404\t401\t403\t500\t10\tready\t\n" +
+		"tpl-draft\tpack-a\tWeb\tDraft question\tA\tB\tC\tD\t10\tdraft\tneeds review\n"
+	if err := os.WriteFile(filepath.Join(dir, "templates.tsv"), []byte(templates), 0o644); err != nil {
+		t.Fatalf("write templates: %v", err)
+	}
+
+	lib, report, err := LoadSourceDir(dir)
+	if err != nil {
+		t.Fatalf("load source dir: %v", err)
+	}
+
+	if report.PackRows != 1 || report.TemplateRows != 2 || report.TemplateReady != 1 || report.TemplateDraft != 1 {
+		t.Fatalf("unexpected load report: %+v", report)
+	}
+	if lib.Version != "v1" || lib.CreatedByLabel != "trivia-library-v1" {
+		t.Fatalf("unexpected meta in library: %+v", lib)
+	}
+	if len(lib.Packs) != 1 || lib.Packs[0].MinRating != 10 {
+		t.Fatalf("unexpected packs: %+v", lib.Packs)
+	}
+	if len(lib.Templates) != 1 || lib.Templates[0].Slug != "tpl-ready" {
+		t.Fatalf("unexpected templates: %+v", lib.Templates)
+	}
+	if lib.Templates[0].QuestionText != `Which HTTP status code means "Not Found"?` {
+		t.Fatalf("expected quotes preserved, got %q", lib.Templates[0].QuestionText)
+	}
+}
+
+func TestLoadSourceDirFailsOnMissingColumn(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "meta.json"), []byte(`{"version":"v1","created_by_label":"x"}`), 0o644); err != nil {
+		t.Fatalf("write meta: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "packs.tsv"), []byte("slug\tname\tmin_rating\n"), 0o644); err != nil {
+		t.Fatalf("write packs: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "templates.tsv"), []byte("slug\tpack\tquestion_text\n"), 0o644); err != nil {
+		t.Fatalf("write templates: %v", err)
+	}
+
+	if _, _, err := LoadSourceDir(dir); err == nil {
+		t.Fatal("expected missing template columns to fail")
+	}
+}
+
+func TestAppendSourceDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "meta.json"), []byte(`{"version":"v1","created_by_label":"x"}`), 0o644); err != nil {
+		t.Fatalf("write meta: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "packs.tsv"), []byte("slug\tname\tdescription\tmin_rating\npack-a\tPack A\t\t10\n"), 0o644); err != nil {
+		t.Fatalf("write packs: %v", err)
+	}
+	templatesTSV := "slug\tpack\tcategory\tquestion_text\tcorrect_answer\twrong_answer_1\twrong_answer_2\twrong_answer_3\tmin_rating\tdifficulty\tstatus\tnotes\n" +
+		"tpl-a\tpack-a\tWeb\tWhat is HTML?\tMarkup\tStyle\tScript\tDatabase\t10\t\tready\t"
+	if err := os.WriteFile(filepath.Join(dir, "templates.tsv"), []byte(templatesTSV), 0o644); err != nil {
+		t.Fatalf("write templates: %v", err)
+	}
+
+	pack := Pack{Slug: "team-bank", Name: "Team Bank", MinRating: 20}
+	imported := []Template{
+		{
+			Slug: "team-bank-1", PackSlug: "team-bank", Category: "Quotes",
+			QuestionText: `"Make it so" is whose catchphrase?`, CorrectAnswer: "Picard",
+			WrongAnswer1: "Kirk", WrongAnswer2: "Janeway", WrongAnswer3: "Sisko",
+			MinRating: 20, Difficulty: DifficultyMedium,
+		},
+		{
+			Slug: "tpl-a", PackSlug: "pack-a", Category: "Web",
+			QuestionText: "What is HTML?", CorrectAnswer: "Markup",
+			WrongAnswer1: "Style", WrongAnswer2: "Script", WrongAnswer3: "Database",
+			MinRating: 10,
+		},
+	}
+
+	_, appendReport, err := AppendSourceDir(dir, pack, imported, SourceAppendOptions{Notes: "imported"})
+	if err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if !appendReport.PackCreated || appendReport.Appended != 1 || appendReport.SkippedExisting != 1 {
+		t.Fatalf("unexpected append report: %+v", appendReport)
+	}
+
+	lib, _, err := LoadSourceDir(dir)
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if len(lib.Packs) != 2 || len(lib.Templates) != 2 {
+		t.Fatalf("unexpected library after append: %+v", lib)
+	}
+	if lib.Templates[1] != imported[0] {
+		t.Fatalf("appended template did not round trip:\n got %+v\nwant %+v", lib.Templates[1], imported[0])
+	}
+
+	_, appendReport, err = AppendSourceDir(dir, pack, imported, SourceAppendOptions{})
+	if err != nil {
+		t.Fatalf("second append: %v", err)
+	}
+	if appendReport.PackCreated || appendReport.Appended != 0 || appendReport.SkippedExisting != 2 {
+		t.Fatalf("expected second append to be a no-op, got %+v", appendReport)
+	}
+}
@@ -21,7 +21,10 @@ type Hub struct {
 	// lobbies maps lobby codes to their connected clients with player IDs (as UUID strings)
 	lobbies map[string]map[*websocket.Conn]string
 	players map[string]map[string]*playerState
-	mu      sync.RWMutex
+	// spectators maps lobby codes to read-only screen connections, which
+	// have no player and never affect presence.
+	spectators map[string]map[*websocket.Conn]struct{}
+	mu         sync.RWMutex
 
 	disconnectGracePeriod time.Duration
 	presenceHandler       func(lobbyCode string, update PresenceUpdate)
@@ -32,6 +35,7 @@ func NewHub() *Hub {
 	return &Hub{
 		lobbies:               make(map[string]map[*websocket.Conn]string),
 		players:               make(map[string]map[string]*playerState),
+		spectators:            make(map[string]map[*websocket.Conn]struct{}),
 		disconnectGracePeriod: defaultDisconnectGracePeriod,
 	}
 }
@@ -74,3 +74,32 @@ func assertUpdate(t *testing.T, updates <-chan PresenceUpdate, want PresenceUpda
 		t.Fatalf("timed out waiting for presence update %+v", want)
 	}
 }
+
+func TestHubSpectatorsDoNotAffectPresence(t *testing.T) {
+	hub := NewHub()
+
+	updates := make(chan PresenceUpdate, 1)
+	hub.SetPresenceHandler(func(lobbyCode string, update PresenceUpdate) {
+		updates <- update
+	})
+
+	conn := new(websocket.Conn)
+	hub.RegisterSpectator("ABC123", conn)
+	if hub.SpectatorCount("ABC123") != 1 {
+		t.Fatalf("expected one spectator, got %d", hub.SpectatorCount("ABC123"))
+	}
+	if hub.ConnectionCount("ABC123") != 0 || len(hub.Snapshot("ABC123")) != 0 {
+		t.Fatalf("expected spectators to stay out of player connections and presence")
+	}
+
+	hub.UnregisterSpectator("ABC123", conn)
+	if hub.SpectatorCount("ABC123") != 0 {
+		t.Fatalf("expected spectator to be removed, got %d", hub.SpectatorCount("ABC123"))
+	}
+
+	select {
+	case update := <-updates:
+		t.Fatalf("unexpected presence update for spectator: %+v", update)
+	default:
+	}
+}
@@ -0,0 +1,70 @@
+package ws
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/coder/websocket"
+)
+
+// RegisterSpectator adds a read-only screen connection to a lobby. Spectators
+// receive screen updates only: they are not players, so they never count
+// toward presence or get per-player fragments.
+func (h *Hub) RegisterSpectator(lobbyCode string, conn *websocket.Conn) {
+	h.mu.Lock()
+	if h.spectators[lobbyCode] == nil {
+		h.spectators[lobbyCode] = make(map[*websocket.Conn]struct{})
+	}
+	h.spectators[lobbyCode][conn] = struct{}{}
+	total := len(h.spectators[lobbyCode])
+	h.mu.Unlock()
+
+	log.Printf("[ws] Spectator connected to lobby %s (%d total)", lobbyCode, total)
+}
+
+// UnregisterSpectator removes a screen connection from a lobby.
+func (h *Hub) UnregisterSpectator(lobbyCode string, conn *websocket.Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if spectators, ok := h.spectators[lobbyCode]; ok {
+		delete(spectators, conn)
+		if len(spectators) == 0 {
+			delete(h.spectators, lobbyCode)
+		}
+	}
+}
+
+// SpectatorCount returns the number of screen connections in a lobby.
+func (h *Hub) SpectatorCount(lobbyCode string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.spectators[lobbyCode])
+}
+
+// BroadcastSpectators sends a message to every screen connection in a lobby.
+func (h *Hub) BroadcastSpectators(ctx context.Context, lobbyCode string, message []byte) {
+	h.mu.RLock()
+	conns := make([]*websocket.Conn, 0, len(h.spectators[lobbyCode]))
+	for conn := range h.spectators[lobbyCode] {
+		conns = append(conns, conn)
+	}
+	h.mu.RUnlock()
+
+	for _, conn := range conns {
+		if err := conn.Write(ctx, websocket.MessageText, message); err != nil {
+			log.Printf("[ws] Error writing to spectator: %v", err)
+		}
+	}
+}
+
+// BroadcastScreenTrigger sends an OOB swap that makes screens fetch their
+// content again, the spectator counterpart of BroadcastUpdateTrigger.
+func BroadcastScreenTrigger(ctx context.Context, lobbyCode string, hub *Hub) {
+	if hub.SpectatorCount(lobbyCode) == 0 {
+		return
+	}
+	html := fmt.Sprintf(`<div id="screen-updater" hx-swap-oob="true" hx-get="/lobbies/%s/screen/content" hx-target="#screen-content" hx-swap="outerHTML" hx-trigger="load" class="hidden"></div>`, lobbyCode)
+	hub.BroadcastSpectators(ctx, lobbyCode, []byte(html))
+}
@@ -106,10 +106,11 @@ func (s *Subscriber) shouldRefreshGameContentForPresence(ctx context.Context, lo
 }
 
 // BroadcastUpdateTrigger sends an OOB swap that triggers the client to fetch updated game content.
-// Exported for use by game packages.
+// Spectator screens get their own refresh trigger. Exported for use by game packages.
 func BroadcastUpdateTrigger(ctx context.Context, lobbyCode string, hub *Hub) {
 	html := fmt.Sprintf(`<div id="game-updater" hx-swap-oob="true" hx-get="/lobbies/%s/content" hx-target="#game-content" hx-swap="outerHTML" hx-trigger="load" class="hidden"></div>`, lobbyCode)
 	hub.Broadcast(ctx, lobbyCode, []byte(html))
+	BroadcastScreenTrigger(ctx, lobbyCode, hub)
 }
 
 // broadcastPlayerList fetches the current player list and broadcasts it.
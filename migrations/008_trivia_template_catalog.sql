@@ -0,0 +1,42 @@
+-- +goose Up
+
+-- Curated trivia template packs and templates, managed by cmd/trivia-content.
+-- Template slugs are what used_question_templates.template_id records.
+CREATE TABLE trivia_template_packs (
+    id UUID PRIMARY KEY,
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    min_rating SMALLINT NOT NULL DEFAULT 30 REFERENCES content_ratings(id),
+    display_order INT NOT NULL DEFAULT 0,
+    created_by_label TEXT NULL,
+    provenance JSONB NOT NULL DEFAULT '{}'::jsonb,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE trivia_templates (
+    id UUID PRIMARY KEY,
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    pack_id UUID NOT NULL REFERENCES trivia_template_packs(id) ON DELETE CASCADE,
+    category TEXT NOT NULL,
+    question_text TEXT NOT NULL,
+    correct_answer VARCHAR(200) NOT NULL,
+    wrong_answer_1 VARCHAR(200) NOT NULL,
+    wrong_answer_2 VARCHAR(200) NOT NULL,
+    wrong_answer_3 VARCHAR(200) NOT NULL,
+    min_rating SMALLINT NOT NULL DEFAULT 30 REFERENCES content_ratings(id),
+    display_order INT NOT NULL DEFAULT 0,
+    created_by_label TEXT NULL,
+    provenance JSONB NOT NULL DEFAULT '{}'::jsonb,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_trivia_templates_pack ON trivia_templates(pack_id);
+CREATE INDEX idx_trivia_templates_active_rating ON trivia_templates(is_active, min_rating);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS trivia_templates;
+DROP TABLE IF EXISTS trivia_template_packs;
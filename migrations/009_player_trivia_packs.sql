@@ -0,0 +1,43 @@
+-- +goose Up
+
+-- Player-owned trivia packs built from questions they wrote in past lobbies.
+-- min_rating only ever rises: it is the highest rating of any saved question.
+CREATE TABLE player_trivia_packs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    name VARCHAR(60) NOT NULL,
+    share_code VARCHAR(8) NOT NULL UNIQUE,
+    min_rating SMALLINT NOT NULL DEFAULT 10 REFERENCES content_ratings(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (owner_player_id, name)
+);
+
+CREATE TABLE player_trivia_pack_questions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    pack_id UUID NOT NULL REFERENCES player_trivia_packs(id) ON DELETE CASCADE,
+    question_text TEXT NOT NULL,
+    correct_answer VARCHAR(200) NOT NULL,
+    wrong_answer_1 VARCHAR(200) NOT NULL,
+    wrong_answer_2 VARCHAR(200) NOT NULL,
+    wrong_answer_3 VARCHAR(200) NOT NULL,
+    min_rating SMALLINT NOT NULL REFERENCES content_ratings(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (pack_id, question_text)
+);
+
+-- Packs other players shared with this player by code or link.
+CREATE TABLE player_saved_trivia_packs (
+    player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    pack_id UUID NOT NULL REFERENCES player_trivia_packs(id) ON DELETE CASCADE,
+    saved_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (player_id, pack_id)
+);
+
+CREATE INDEX idx_player_trivia_pack_questions_pack ON player_trivia_pack_questions(pack_id);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS player_saved_trivia_packs;
+DROP TABLE IF EXISTS player_trivia_pack_questions;
+DROP TABLE IF EXISTS player_trivia_packs;
@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Category and difficulty carried over from imported question banks.
+-- Empty difficulty means unrated.
+ALTER TABLE trivia_templates
+    ADD COLUMN difficulty VARCHAR(10) NOT NULL DEFAULT '';
+
+ALTER TABLE player_trivia_pack_questions
+    ADD COLUMN category TEXT NOT NULL DEFAULT '',
+    ADD COLUMN difficulty VARCHAR(10) NOT NULL DEFAULT '';
+
+-- +goose Down
+
+ALTER TABLE player_trivia_pack_questions
+    DROP COLUMN IF EXISTS difficulty,
+    DROP COLUMN IF EXISTS category;
+
+ALTER TABLE trivia_templates
+    DROP COLUMN IF EXISTS difficulty;
@@ -0,0 +1,29 @@
+-- +goose Up
+
+-- Pack rounds are built from templates instead of player submissions, so their
+-- questions have no author and everyone answers every question.
+ALTER TABLE trivia_questions ALTER COLUMN author DROP NOT NULL;
+ALTER TABLE trivia_questions ADD COLUMN template_id VARCHAR(50);
+
+-- players: questions written by players; packs: questions drawn from templates.
+ALTER TABLE trivia_rounds ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'players';
+
+CREATE INDEX idx_trivia_questions_template ON trivia_questions(template_id) WHERE template_id IS NOT NULL;
+
+-- +goose Down
+
+-- Pack-round questions have no author; refuse rather than delete them (and
+-- their answers) just to restore NOT NULL.
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM trivia_questions WHERE author IS NULL) THEN
+        RAISE EXCEPTION 'trivia_questions has pack-round questions without an author; remove them before rolling back 011';
+    END IF;
+END $$;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_trivia_questions_template;
+ALTER TABLE trivia_rounds DROP COLUMN IF EXISTS source;
+ALTER TABLE trivia_questions DROP COLUMN IF EXISTS template_id;
+ALTER TABLE trivia_questions ALTER COLUMN author SET NOT NULL;
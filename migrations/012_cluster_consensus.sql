@@ -0,0 +1,19 @@
+-- +goose Up
+
+-- Per-lobby Cluster settings chosen by the host.
+CREATE TABLE coordinates_lobby_settings (
+    lobby_id UUID PRIMARY KEY REFERENCES lobbies(id) ON DELETE CASCADE,
+    consensus_strategy TEXT NOT NULL DEFAULT 'mean'
+        CHECK (consensus_strategy IN ('mean', 'geometric_median', 'trimmed_mean', 'density_peak')),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Strategy used when the round was revealed, so history survives setting changes.
+ALTER TABLE coordinates_rounds
+    ADD COLUMN consensus_strategy TEXT NOT NULL DEFAULT 'mean'
+        CHECK (consensus_strategy IN ('mean', 'geometric_median', 'trimmed_mean', 'density_peak'));
+
+-- +goose Down
+
+ALTER TABLE coordinates_rounds DROP COLUMN IF EXISTS consensus_strategy;
+DROP TABLE IF EXISTS coordinates_lobby_settings;
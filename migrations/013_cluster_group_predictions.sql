@@ -0,0 +1,21 @@
+-- +goose Up
+
+-- Optional second placement: each player predicts where the group target lands.
+ALTER TABLE coordinates_lobby_settings
+    ADD COLUMN predict_group BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Snapshot of the lobby setting when the round started.
+ALTER TABLE coordinates_rounds
+    ADD COLUMN predict_group BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE coordinates_submissions
+    ADD COLUMN predicted_x DOUBLE PRECISION NULL CHECK (predicted_x >= 0 AND predicted_x <= 1),
+    ADD COLUMN predicted_y DOUBLE PRECISION NULL CHECK (predicted_y >= 0 AND predicted_y <= 1);
+
+-- +goose Down
+
+ALTER TABLE coordinates_submissions
+    DROP COLUMN IF EXISTS predicted_y,
+    DROP COLUMN IF EXISTS predicted_x;
+ALTER TABLE coordinates_rounds DROP COLUMN IF EXISTS predict_group;
+ALTER TABLE coordinates_lobby_settings DROP COLUMN IF EXISTS predict_group;
@@ -0,0 +1,32 @@
+-- +goose Up
+
+-- Axis sets can be a 2D plane, a 1D spectrum on the x axis, or a pick between
+-- labeled buckets. Submissions keep using x/y: spectrum pins y to 0.5 and
+-- buckets store the center of the chosen bucket on x.
+ALTER TABLE coordinates_axis_sets
+    ADD COLUMN mode TEXT NOT NULL DEFAULT 'plane' CHECK (mode IN ('plane', 'spectrum', 'buckets')),
+    ADD COLUMN bucket_labels TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE coordinates_axis_sets
+    ADD CONSTRAINT coordinates_axis_sets_buckets_check CHECK (
+        (mode = 'buckets' AND cardinality(bucket_labels) BETWEEN 2 AND 6)
+        OR (mode <> 'buckets' AND cardinality(bucket_labels) = 0)
+    );
+
+-- Rounds snapshot the mode and bucket labels they started with, so a content
+-- re-import that changes an axis set cannot re-score past rounds. Every round
+-- before this migration was a plane round, which the defaults describe.
+ALTER TABLE coordinates_rounds
+    ADD COLUMN axis_mode TEXT NOT NULL DEFAULT 'plane' CHECK (axis_mode IN ('plane', 'spectrum', 'buckets')),
+    ADD COLUMN axis_bucket_labels TEXT[] NOT NULL DEFAULT '{}';
+
+-- +goose Down
+
+ALTER TABLE coordinates_rounds
+    DROP COLUMN IF EXISTS axis_bucket_labels,
+    DROP COLUMN IF EXISTS axis_mode;
+
+ALTER TABLE coordinates_axis_sets DROP CONSTRAINT IF EXISTS coordinates_axis_sets_buckets_check;
+ALTER TABLE coordinates_axis_sets
+    DROP COLUMN IF EXISTS bucket_labels,
+    DROP COLUMN IF EXISTS mode;
@@ -0,0 +1,29 @@
+-- +goose Up
+
+-- Optional reveal variant: one anonymous dot is highlighted and players guess
+-- whose it is.
+ALTER TABLE coordinates_lobby_settings
+    ADD COLUMN guess_player BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The featured player is picked at reveal. The answer stays hidden until
+-- every other player has guessed or the host shows it.
+ALTER TABLE coordinates_rounds
+    ADD COLUMN mystery_player_id UUID NULL REFERENCES lobby_players(id) ON DELETE SET NULL,
+    ADD COLUMN mystery_revealed_at TIMESTAMPTZ NULL;
+
+CREATE TABLE coordinates_player_guesses (
+    round_id UUID NOT NULL REFERENCES coordinates_rounds(id) ON DELETE CASCADE,
+    guesser_id UUID NOT NULL REFERENCES lobby_players(id) ON DELETE CASCADE,
+    guessed_player_id UUID NOT NULL REFERENCES lobby_players(id) ON DELETE CASCADE,
+    guessed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (round_id, guesser_id),
+    CHECK (guesser_id <> guessed_player_id)
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS coordinates_player_guesses;
+ALTER TABLE coordinates_rounds
+    DROP COLUMN IF EXISTS mystery_revealed_at,
+    DROP COLUMN IF EXISTS mystery_player_id;
+ALTER TABLE coordinates_lobby_settings DROP COLUMN IF EXISTS guess_player;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- One public link per player for their Cluster profile card. The card is
+-- rebuilt from play history on every view, so only the code is stored.
+CREATE TABLE coordinates_profile_shares (
+    player_id UUID PRIMARY KEY REFERENCES players(id) ON DELETE CASCADE,
+    share_code VARCHAR(8) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS coordinates_profile_shares;
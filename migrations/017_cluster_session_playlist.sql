@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- Chosen when the lobby is created. An empty theme list plays every theme
+-- and a round limit of 0 plays until the eligible pairs run out.
+ALTER TABLE coordinates_lobby_settings
+    ADD COLUMN themes TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN round_limit INT NOT NULL DEFAULT 0 CHECK (round_limit >= 0);
+
+-- +goose Down
+
+ALTER TABLE coordinates_lobby_settings
+    DROP COLUMN IF EXISTS round_limit,
+    DROP COLUMN IF EXISTS themes;
@@ -0,0 +1,33 @@
+-- +goose Up
+
+-- One row per cluster content import or rollback. parent_id is the release
+-- whose active set this one replaced; a rollback copies its target's parent
+-- so repeated rollbacks keep walking back through history.
+CREATE TABLE coordinates_content_releases (
+    id UUID PRIMARY KEY,
+    created_by_label TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('import', 'rollback')),
+    library_version TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    parent_id UUID NULL REFERENCES coordinates_content_releases(id),
+    restored_from_id UUID NULL REFERENCES coordinates_content_releases(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK ((kind = 'rollback') = (restored_from_id IS NOT NULL))
+);
+
+CREATE INDEX idx_coordinates_content_releases_label ON coordinates_content_releases(created_by_label, created_at DESC);
+
+-- Every managed row that is active after a release ('activated' or 'kept'),
+-- plus the rows the release deactivated.
+CREATE TABLE coordinates_content_release_rows (
+    release_id UUID NOT NULL REFERENCES coordinates_content_releases(id) ON DELETE CASCADE,
+    entity TEXT NOT NULL CHECK (entity IN ('prompt', 'axis_set', 'pair')),
+    row_id UUID NOT NULL,
+    change TEXT NOT NULL CHECK (change IN ('activated', 'kept', 'deactivated')),
+    PRIMARY KEY (release_id, entity, row_id)
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS coordinates_content_release_rows;
+DROP TABLE IF EXISTS coordinates_content_releases;
@@ -0,0 +1,27 @@
+-- +goose Up
+
+-- Per-locale variants keyed by locale code, e.g. {"es": {"text": "..."}}.
+-- The base columns stay the default-locale (English) text and are used
+-- whenever a locale or field is missing.
+ALTER TABLE coordinates_prompts
+    ADD COLUMN translations JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+ALTER TABLE coordinates_axis_sets
+    ADD COLUMN translations JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Chosen when the lobby is created. It lives on the lobby rather than in
+-- Cluster settings so every game and the shared lobby UI can read it; Cluster
+-- is the only game with translated content so far.
+ALTER TABLE lobbies
+    ADD COLUMN locale TEXT NOT NULL DEFAULT 'en';
+
+-- +goose Down
+
+ALTER TABLE lobbies
+    DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE coordinates_axis_sets
+    DROP COLUMN IF EXISTS translations;
+
+ALTER TABLE coordinates_prompts
+    DROP COLUMN IF EXISTS translations;
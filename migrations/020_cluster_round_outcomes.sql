@@ -0,0 +1,23 @@
+-- +goose Up
+
+-- One row per round saying whether it was revealed or skipped. A round is
+-- skipped when a later round in its lobby started before it was revealed.
+-- Rotation and the content quality report both read this, so "skipped" has a
+-- single definition. Filters on prompt_axis_set_id push down into the view.
+CREATE VIEW coordinates_round_outcomes AS
+SELECT
+    cr.id AS round_id,
+    cr.lobby_id,
+    cr.prompt_axis_set_id,
+    cr.centroid_x IS NOT NULL AS revealed,
+    cr.centroid_x IS NULL AND EXISTS (
+        SELECT 1
+        FROM coordinates_rounds later
+        WHERE later.lobby_id = cr.lobby_id
+          AND later.round_number > cr.round_number
+    ) AS skipped
+FROM coordinates_rounds cr;
+
+-- +goose Down
+
+DROP VIEW IF EXISTS coordinates_round_outcomes;
@@ -1,6 +1,6 @@
 -- name: CreateLobby :one
-INSERT INTO lobbies (code, name, game_type, content_rating)
-VALUES ($1, $2, $3, $4)
+INSERT INTO lobbies (code, name, game_type, content_rating, locale)
+VALUES ($1, $2, $3, $4, $5)
 RETURNING *;
 
 -- name: GetLobbyByCode :one
@@ -39,6 +39,9 @@ LIMIT 1;
 -- name: UpdateRoundPhase :exec
 UPDATE trivia_rounds SET phase = $2 WHERE id = $1;
 
+-- name: UpdateRoundSource :exec
+UPDATE trivia_rounds SET source = $2 WHERE id = $1;
+
 -- name: UpdateRoundQuestionState :exec
 UPDATE trivia_rounds 
 SET current_question_id = $2, question_state = $3 
@@ -60,8 +63,13 @@ WHERE question_id = $1
 GROUP BY selected_answer;
 
 -- name: CreateQuestion :one
-INSERT INTO trivia_questions (round_id, author, question_text, correct_answer, wrong_answer_1, wrong_answer_2, wrong_answer_3, min_rating)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO trivia_questions (round_id, author, question_text, correct_answer, wrong_answer_1, wrong_answer_2, wrong_answer_3, min_rating, template_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: CreatePackQuestion :one
+INSERT INTO trivia_questions (round_id, template_id, question_text, correct_answer, wrong_answer_1, wrong_answer_2, wrong_answer_3, min_rating, display_order)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: GetQuestionsForRound :many
@@ -117,7 +125,131 @@ ORDER BY score DESC;
 -- name: GetUsedTemplatesForLobby :many
 SELECT template_id FROM used_question_templates WHERE lobby_id = $1;
 
+-- name: ListTemplateAnswerStats :many
+-- Answer history for the given templates, used to learn difficulty. Scoped to
+-- the catalog being rendered so it stays on the template_id index.
+SELECT
+    q.template_id::text AS template_id,
+    COUNT(a.id) AS answer_count,
+    COUNT(a.id) FILTER (WHERE a.is_correct) AS correct_count
+FROM trivia_questions q
+JOIN trivia_answers a ON a.question_id = q.id
+WHERE q.template_id = ANY(sqlc.arg(template_ids)::text[])
+GROUP BY q.template_id;
+
 -- name: MarkTemplateUsed :exec
 INSERT INTO used_question_templates (lobby_id, template_id)
 VALUES ($1, $2)
 ON CONFLICT (lobby_id, template_id) DO NOTHING;
+
+-- name: ListTriviaTemplatePacks :many
+SELECT slug, name, description, min_rating
+FROM trivia_template_packs
+WHERE is_active = TRUE AND min_rating <= $1
+ORDER BY display_order, slug;
+
+-- name: ListTriviaTemplates :many
+SELECT
+    t.slug,
+    p.slug AS pack_slug,
+    t.category,
+    t.question_text,
+    t.correct_answer,
+    t.wrong_answer_1,
+    t.wrong_answer_2,
+    t.wrong_answer_3,
+    t.min_rating,
+    t.difficulty
+FROM trivia_templates t
+JOIN trivia_template_packs p ON p.id = t.pack_id
+WHERE t.is_active = TRUE
+  AND p.is_active = TRUE
+  AND t.min_rating <= $1
+  AND p.min_rating <= $1
+ORDER BY p.display_order, t.display_order, t.slug;
+
+-- name: GetTriviaTemplateFill :one
+-- The text a curated template fills in, if it is allowed for the lobby rating.
+SELECT t.question_text, t.correct_answer
+FROM trivia_templates t
+JOIN trivia_template_packs p ON p.id = t.pack_id
+WHERE t.slug = $1
+  AND t.is_active = TRUE
+  AND p.is_active = TRUE
+  AND t.min_rating <= $2
+  AND p.min_rating <= $2;
+
+-- name: UpsertPlayerTriviaPack :one
+INSERT INTO player_trivia_packs (owner_player_id, name, share_code, min_rating)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (owner_player_id, name) DO UPDATE SET
+    min_rating = GREATEST(player_trivia_packs.min_rating, EXCLUDED.min_rating),
+    updated_at = NOW()
+RETURNING *;
+
+-- name: AddPlayerTriviaPackQuestion :execrows
+INSERT INTO player_trivia_pack_questions (pack_id, question_text, correct_answer, wrong_answer_1, wrong_answer_2, wrong_answer_3, min_rating, category, difficulty)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (pack_id, question_text) DO NOTHING;
+
+-- name: GetPlayerTriviaPackByShareCode :one
+SELECT * FROM player_trivia_packs WHERE share_code = $1;
+
+-- name: CountPlayerTriviaPackQuestions :one
+SELECT COUNT(*) FROM player_trivia_pack_questions WHERE pack_id = $1;
+
+-- name: ListPlayerTriviaPackQuestionsByPack :many
+SELECT * FROM player_trivia_pack_questions
+WHERE pack_id = $1
+ORDER BY created_at, question_text;
+
+-- name: SavePlayerTriviaPack :exec
+INSERT INTO player_saved_trivia_packs (player_id, pack_id)
+VALUES ($1, $2)
+ON CONFLICT (player_id, pack_id) DO NOTHING;
+
+-- name: ListPlayerTriviaPackQuestions :many
+SELECT
+    p.id AS pack_id,
+    p.name AS pack_name,
+    p.share_code,
+    p.min_rating AS pack_min_rating,
+    (p.owner_player_id = $1)::boolean AS is_owner,
+    q.id,
+    q.question_text,
+    q.correct_answer,
+    q.wrong_answer_1,
+    q.wrong_answer_2,
+    q.wrong_answer_3,
+    q.min_rating,
+    q.category,
+    q.difficulty
+FROM player_trivia_packs p
+JOIN player_trivia_pack_questions q ON q.pack_id = p.id
+WHERE (
+        p.owner_player_id = $1
+        OR EXISTS (
+            SELECT 1 FROM player_saved_trivia_packs s
+            WHERE s.pack_id = p.id AND s.player_id = $1
+        )
+    )
+  AND p.min_rating <= $2
+  AND q.min_rating <= $2
+ORDER BY is_owner DESC, p.name, q.created_at;
+
+-- name: GetPlayerTriviaPackQuestionFill :one
+-- The text a question from the player's own or saved packs fills in, if it is
+-- allowed for the lobby rating.
+SELECT q.question_text, q.correct_answer
+FROM player_trivia_pack_questions q
+JOIN player_trivia_packs p ON p.id = q.pack_id
+WHERE q.id = $1
+  AND (
+        p.owner_player_id = $2
+        OR EXISTS (
+            SELECT 1 FROM player_saved_trivia_packs s
+            WHERE s.pack_id = p.id AND s.player_id = $2
+        )
+    )
+  AND p.min_rating <= $3
+  AND q.min_rating <= $3;
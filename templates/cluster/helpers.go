@@ -3,7 +3,10 @@ package cluster
 import (
 	"fmt"
 	"math"
+	"sort"
 	"strings"
+
+	"github.com/jgoodhcg/mindmeld/internal/clustercontent"
 )
 
 func plotStyle(x, y float64, radiusPx int, withAnimation bool) string {
@@ -104,10 +107,280 @@ func outlierSummary(outliers []string) string {
 	}
 }
 
+func predictionMarkerClass(dot DotView) string {
+	className := "absolute z-10 h-3 w-3 rounded-full border-2 border-dashed border-text-muted"
+	if dot.IsCurrentPlayer {
+		className = "absolute z-10 h-3 w-3 rounded-full border-2 border-dashed border-cyan"
+	}
+	return className
+}
+
+// placementAction posts the viewer's own point, or their group prediction
+// once the point is locked.
+func placementAction(lobbyCode string, prediction bool) string {
+	if prediction {
+		return "/lobbies/" + lobbyCode + "/cluster/predictions"
+	}
+	return "/lobbies/" + lobbyCode + "/cluster/submissions"
+}
+
+func placementSelectionKey(lobbyCode string, roundNumber int32, prediction bool) string {
+	prefix := "cluster-selection:"
+	if prediction {
+		prefix = "cluster-prediction:"
+	}
+	return fmt.Sprintf("%s%s:%d", prefix, lobbyCode, roundNumber)
+}
+
+// bestPredictors returns the players whose prediction scored highest.
+func bestPredictors(dots []DotView) []string {
+	best := -1
+	var names []string
+	for _, dot := range dots {
+		if !dot.HasPrediction {
+			continue
+		}
+		switch {
+		case dot.PredictionPoints > best:
+			best = dot.PredictionPoints
+			names = []string{dot.Nickname}
+		case dot.PredictionPoints == best:
+			names = append(names, dot.Nickname)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func bestPredictorSummary(names []string) string {
+	return "Best read of the room: " + strings.Join(names, ", ")
+}
+
+func isSpectrum(prompt PromptAxisView) bool {
+	return prompt.Mode == clustercontent.ModeSpectrum
+}
+
+func isBuckets(prompt PromptAxisView) bool {
+	return prompt.Mode == clustercontent.ModeBuckets && len(prompt.Buckets) > 0
+}
+
+// selectedBucket returns the label of the bucket x falls in.
+func selectedBucket(prompt PromptAxisView, x float64) string {
+	n := len(prompt.Buckets)
+	i := min(int(math.Floor(clampUnit(x)*float64(n))), n-1)
+	return prompt.Buckets[i]
+}
+
+func selectionReadout(prompt PromptAxisView, x float64, y float64) string {
+	switch {
+	case isBuckets(prompt):
+		return "Selected: " + selectedBucket(prompt, x)
+	case isSpectrum(prompt):
+		return "Selected point: " + displayCoord(x)
+	default:
+		return fmt.Sprintf("Selected point: (%s, %s)", displayCoord(x), displayCoord(y))
+	}
+}
+
+func distanceScaleNote(prompt PromptAxisView) string {
+	if isSpectrum(prompt) {
+		return "Dist from you: 0.00 = same point, 1.00 = opposite ends."
+	}
+	return "Dist from you: 0.00 = same point, 1.41 = opposite corners."
+}
+
+func bucketResultClass(bucket BucketView, anonymous bool) string {
+	className := "rounded border p-3 bg-base"
+	if anonymous && bucket.IsMystery {
+		return className + " border-amber ring-2 ring-amber/40"
+	}
+	if bucket.IsPlurality {
+		return className + " border-amber/60"
+	}
+	return className + " border-border"
+}
+
+func bucketCountLabel(count int) string {
+	if count == 1 {
+		return "1 pick"
+	}
+	return fmt.Sprintf("%d picks", count)
+}
+
+func joinNames(names []string) string {
+	return strings.Join(names, ", ")
+}
+
+func guessProgress(guess GuessView) string {
+	return fmt.Sprintf("%d / %d guessed", guess.GuessCount, guess.ExpectedGuesses)
+}
+
+func correctGuessSummary(names []string) string {
+	if len(names) == 0 {
+		return "Nobody guessed right."
+	}
+	return "Guessed right: " + joinNames(names)
+}
+
+func mysteryMarkerStyle(x, y float64) string {
+	return centerStyle(x, y) + " transform: translate(-50%, -50%);"
+}
+
 func spreadRingStyle(x, y float64) string {
 	return centerStyle(x, y) + "width: 25%; height: 25%; transform: translate(-50%, -50%);"
 }
 
+// axisLean describes which way a player leans on an axis set. Averages within
+// a tenth of the midpoint count as the middle.
+func axisLean(axis AxisTendencyView) string {
+	if axis.Mode == clustercontent.ModeBuckets {
+		if axis.TopBucket == "" {
+			return "No clear pick"
+		}
+		return fmt.Sprintf("Usually picks %s (%s)", axis.TopBucket, bucketCountLabel(axis.TopBucketCount))
+	}
+
+	leans := make([]string, 0, 2)
+	if lean := leanToward(axis.AvgX, axis.XMinLabel, axis.XMaxLabel); lean != "" {
+		leans = append(leans, lean)
+	}
+	if axis.Mode != clustercontent.ModeSpectrum {
+		if lean := leanToward(axis.AvgY, axis.YMinLabel, axis.YMaxLabel); lean != "" {
+			leans = append(leans, lean)
+		}
+	}
+	if len(leans) == 0 {
+		return "Sits in the middle"
+	}
+	return "Leans " + strings.Join(leans, " and ")
+}
+
+func leanToward(v float64, minLabel string, maxLabel string) string {
+	switch {
+	case v <= 0.4:
+		return minLabel
+	case v >= 0.6:
+		return maxLabel
+	default:
+		return ""
+	}
+}
+
+func axisTitle(axis AxisTendencyView) string {
+	return axesSummary(PromptAxisView{
+		XMinLabel: axis.XMinLabel,
+		XMaxLabel: axis.XMaxLabel,
+		YMinLabel: axis.YMinLabel,
+		YMaxLabel: axis.YMaxLabel,
+		Mode:      axis.Mode,
+		Buckets:   axis.Buckets,
+	})
+}
+
+// axesSummary describes an axis set on one line.
+func axesSummary(axes PromptAxisView) string {
+	switch {
+	case isBuckets(axes):
+		return strings.Join(axes.Buckets, " / ")
+	case isSpectrum(axes):
+		return axes.XMinLabel + " ↔ " + axes.XMaxLabel
+	default:
+		return fmt.Sprintf("%s ↔ %s / %s ↕ %s", axes.XMinLabel, axes.XMaxLabel, axes.YMinLabel, axes.YMaxLabel)
+	}
+}
+
+func choiceCountLabel(view PromptPickerView) string {
+	if len(view.Choices) == view.TotalChoices {
+		return fmt.Sprintf("%d unplayed pairs", view.TotalChoices)
+	}
+	return fmt.Sprintf("%d of %d unplayed pairs", len(view.Choices), view.TotalChoices)
+}
+
+func leanMarkerStyle(v float64) string {
+	return fmt.Sprintf("left: %.2f%%;", clampUnit(v)*100)
+}
+
+func roundCountLabel(count int) string {
+	if count == 1 {
+		return "1 round"
+	}
+	return fmt.Sprintf("%d rounds", count)
+}
+
+func teammateSummary(teammate TeammateView) string {
+	return fmt.Sprintf("avg dist %s over %s", formatDistance(teammate.AvgDistance), roundCountLabel(teammate.SharedRounds))
+}
+
+func sessionSummary(session SessionView) string {
+	themes := "All themes"
+	if len(session.Themes) > 0 {
+		themes = "Themes: " + strings.Join(session.Themes, ", ")
+	}
+	if session.RoundLimit > 0 {
+		return fmt.Sprintf("%s · %d rounds", themes, session.RoundLimit)
+	}
+	return themes + " · until prompts run out"
+}
+
+// sessionRoundLabel counts the round in play against the limit. Skipped
+// prompts do not use up a round.
+func sessionRoundLabel(session SessionView, revealed bool) string {
+	current := session.RevealedRounds
+	if !revealed {
+		current++
+	}
+	return fmt.Sprintf("ROUND %d OF %d", current, max(session.RoundLimit, current))
+}
+
+func sessionCompleteNote(session SessionView) string {
+	if session.RoundLimit > 0 && session.RevealedRounds >= session.RoundLimit {
+		return fmt.Sprintf("You played all %d rounds.", session.RoundLimit)
+	}
+	return "You have played all available prompts."
+}
+
+// finalWinners names the top scorers, or "" before anyone has scored.
+func finalWinners(standings []StandingView) string {
+	if len(standings) == 0 || standings[0].TotalPoints == 0 {
+		return ""
+	}
+	names := make([]string, 0, 1)
+	for _, standing := range standings {
+		if standing.TotalPoints != standings[0].TotalPoints {
+			break
+		}
+		names = append(names, standing.Nickname)
+	}
+	if len(names) == 1 {
+		return "Winner: " + names[0]
+	}
+	return "Tied for first: " + joinNames(names)
+}
+
+// screenStandingsLimit keeps the big-screen standings readable from the back
+// of the room.
+const screenStandingsLimit = 8
+
+func screenTopStandings(standings []StandingView) []StandingView {
+	if len(standings) > screenStandingsLimit {
+		return standings[:screenStandingsLimit]
+	}
+	return standings
+}
+
+func screenPlayerCount(count int) string {
+	if count == 1 {
+		return "1 player joined"
+	}
+	return fmt.Sprintf("%d players joined", count)
+}
+
+// screenCentroidStyle drops the target in once every dot has landed. The
+// marker box is empty, so the animation's transform does not shift it.
+func screenCentroidStyle(x, y float64, dotCount int) string {
+	return centerStyle(x, y) + fmt.Sprintf(" animation: cluster-centroid-drop 420ms ease-out %dms both;", dotCount*80+200)
+}
+
 func clampUnit(v float64) float64 {
 	if v < 0 {
 		return 0
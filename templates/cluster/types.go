@@ -1,5 +1,7 @@
 package cluster
 
+import "github.com/jgoodhcg/mindmeld/internal/lobbyview"
+
 // PromptAxisView is the current prompt and axis labeling shown to players.
 type PromptAxisView struct {
 	PromptText string
@@ -7,6 +9,21 @@ type PromptAxisView struct {
 	XMaxLabel  string
 	YMinLabel  string
 	YMaxLabel  string
+	// Mode is plane, spectrum, or buckets. Empty means plane.
+	Mode    string
+	Buckets []string
+}
+
+// BucketView is one bucket in a revealed bucket round.
+type BucketView struct {
+	Label           string
+	Count           int
+	Players         []string
+	Predictors      []string
+	IsPlurality     bool
+	IsCurrentPlayer bool
+	// IsMystery marks the bucket holding the guess-the-player dot.
+	IsMystery bool
 }
 
 // DotView is one plotted player coordinate.
@@ -18,6 +35,11 @@ type DotView struct {
 	AnimationDelay  int
 	IsOutlier       bool
 	IsCurrentPlayer bool
+
+	HasPrediction    bool
+	PredictedX       float64
+	PredictedY       float64
+	PredictionPoints int
 }
 
 // StandingView is one row in standings.
@@ -30,4 +52,212 @@ type StandingView struct {
 	IsCurrentPlayer    bool
 	TotalPoints        int
 	AvgPointsPerRound  float64
+
+	RoundPrediction    int
+	HasRoundPrediction bool
+	PredictionPoints   int
+	PredictionRounds   int
+	AvgPredictionPts   float64
+
+	GuessPoints    int
+	CorrectGuesses int
+}
+
+// ConsensusOption is one way of placing the group target.
+type ConsensusOption struct {
+	Value       string
+	Label       string
+	Description string
+}
+
+// ConsensusView is the lobby's scoring strategy, the one used by the shown
+// reveal, and the choices offered to the host.
+type ConsensusView struct {
+	Current  ConsensusOption
+	Revealed ConsensusOption
+	Options  []ConsensusOption
+}
+
+// PredictionView describes the predict-the-group placement for the viewer.
+type PredictionView struct {
+	// Enabled is the lobby setting applied to new rounds.
+	Enabled bool
+	// RoundEnabled reports whether the shown round asks for predictions.
+	RoundEnabled bool
+	// NeedsPrediction is set once the viewer placed their own point but not
+	// their prediction.
+	NeedsPrediction bool
+	// Leaders are players with scored predictions, best total first.
+	Leaders []StandingView
+}
+
+// GuessOption is a player the viewer can attribute the mystery dot to.
+type GuessOption struct {
+	Value    string
+	Nickname string
+}
+
+// GuessView describes the guess-the-player reveal for the viewer.
+type GuessView struct {
+	// Enabled is the lobby setting applied to new reveals.
+	Enabled bool
+	// Active reports whether the shown round features a mystery dot.
+	Active bool
+	// AnswerShown is set once everyone guessed or the host revealed it. Until
+	// then the reveal hides which player placed which dot.
+	AnswerShown bool
+	MysteryX    float64
+	MysteryY    float64
+	// IsMystery is set when the viewer placed the mystery dot.
+	IsMystery bool
+	CanGuess  bool
+	// GuessedValue and GuessedNickname are the viewer's current guess, if any.
+	GuessedValue    string
+	GuessedNickname string
+	Options         []GuessOption
+	GuessCount      int
+	ExpectedGuesses int
+	PointsPerGuess  int
+	AnswerNickname  string
+	CorrectGuessers []string
+	// Leaders are players with correct guesses, best total first.
+	Leaders []StandingView
+}
+
+// AxisTendencyView is where a player tends to sit on one axis set.
+type AxisTendencyView struct {
+	Slug      string
+	Mode      string
+	XMinLabel string
+	XMaxLabel string
+	YMinLabel string
+	YMaxLabel string
+	Buckets   []string
+	// AvgX and AvgY are the player's mean placement. AvgY is only meaningful
+	// on plane axis sets.
+	AvgX   float64
+	AvgY   float64
+	Rounds int
+	// TopBucket is the player's most picked bucket on bucket axis sets.
+	TopBucket      string
+	TopBucketCount int
+}
+
+// TeammateView is how closely a teammate's answers track the player's.
+type TeammateView struct {
+	Nickname     string
+	AvgDistance  float64
+	SharedRounds int
+}
+
+// ProfileView summarizes a player's Cluster tendencies.
+type ProfileView struct {
+	Nickname string
+	// Rounds counts revealed rounds the player placed a point in.
+	Rounds       int
+	Axes         []AxisTendencyView
+	MostAligned  []TeammateView
+	LeastAligned []TeammateView
+}
+
+// RatingOption is a content rating the host can filter prompts by.
+type RatingOption struct {
+	Value int16
+	Label string
+}
+
+// PromptChoiceView is an unplayed prompt-axis pair in the host picker.
+type PromptChoiceView struct {
+	ID          string
+	PromptText  string
+	Theme       string
+	RatingLabel string
+	Axes        PromptAxisView
+}
+
+// AxisSetOption is an axis set a custom prompt can be played on.
+type AxisSetOption struct {
+	ID   string
+	Axes PromptAxisView
+}
+
+// PromptPickerView is the host's page for choosing or writing the next prompt.
+type PromptPickerView struct {
+	LobbyCode string
+	// Theme and Rating are the applied filters; an empty Theme means any.
+	Theme   string
+	Themes  []string
+	Rating  int16
+	Ratings []RatingOption
+	Choices []PromptChoiceView
+	// TotalChoices counts eligible pairs before filtering.
+	TotalChoices    int
+	AxisSets        []AxisSetOption
+	MaxPromptLength int
+}
+
+// SessionView is the lobby's theme playlist and length.
+type SessionView struct {
+	// Themes limits prompts to these themes; empty means every theme.
+	Themes []string
+	// RoundLimit ends the session after this many rounds; 0 means the session
+	// runs until the eligible prompts run out.
+	RoundLimit     int
+	RevealedRounds int
+}
+
+// GameView is the lobby state one player sees in the game content area.
+type GameView struct {
+	LobbyCode           string
+	Phase               string
+	IsHost              bool
+	HostTransferOptions []lobbyview.HostTransferOption
+	MinPlayers          int
+	Session             SessionView
+	HasRound            bool
+	RoundNumber         int32
+	Prompt              PromptAxisView
+	SubmittedCount      int
+	ExpectedCount       int
+	HasSubmitted        bool
+	Revealed            bool
+	Dots                []DotView
+	Buckets             []BucketView
+	CentroidX           float64
+	CentroidY           float64
+	Consensus           ConsensusView
+	Predictions         PredictionView
+	Guess               GuessView
+	Profile             ProfileView
+	Outliers            []string
+	Standings           []StandingView
+	RemainingPairs      int
+	Exhausted           bool
+}
+
+// ScreenView is the shared round state for the big-screen view. It has no
+// viewer, so nothing is marked as the current player's.
+type ScreenView struct {
+	LobbyCode      string
+	Phase          string
+	PlayerCount    int
+	HasRound       bool
+	RoundNumber    int32
+	Session        SessionView
+	Prompt         PromptAxisView
+	SubmittedCount int
+	ExpectedCount  int
+	Revealed       bool
+	// Anonymous hides names while a guess-the-player reveal is open.
+	Anonymous bool
+	MysteryX  float64
+	MysteryY  float64
+	Dots      []DotView
+	Buckets   []BucketView
+	CentroidX float64
+	CentroidY float64
+	Consensus ConsensusOption
+	Outliers  []string
+	Standings []StandingView
+	Exhausted bool
 }
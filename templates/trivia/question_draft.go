@@ -0,0 +1,13 @@
+package trivia
+
+// QuestionDraft is what a player typed into the question form, so a rejected
+// submission can be shown again without losing it.
+type QuestionDraft struct {
+	TemplateID    string
+	QuestionText  string
+	CorrectAnswer string
+	WrongAnswer1  string
+	WrongAnswer2  string
+	WrongAnswer3  string
+	SavePackName  string
+}